@@ -1,7 +1,12 @@
 Feature: Delete workspaces via REST API
 
-  @skip
   Scenario: users can delete owned workspaces
+    Given An user is onboarded
+    And   The user requests a new private workspace
+    When  The user deletes the workspace
+    Then  The workspace is deleted
 
-  @skip
   Scenario: users cannot delete default workspace
+    Given An user is onboarded
+    And   Default workspace is created for them
+    Then  The user can not delete the default workspace
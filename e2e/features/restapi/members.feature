@@ -0,0 +1,9 @@
+Feature: Manage workspace members via REST API
+
+  Scenario: users can remove members from owned workspaces
+    Given An user is onboarded
+    And   User "alice" is onboarded
+    And   The user requests a new private workspace
+    And   The user shares the workspace with "alice" as "viewer"
+    When  The user removes "alice" from the workspace members
+    Then  "alice" is not a member of the workspace
@@ -11,6 +11,11 @@ Feature: Update workspaces via REST API
     And   User "alice" is onboarded
     Then "alice" can not change workspace visibility to "community"
 
+  Scenario: users can not update non-owned InternalWorkspaces impersonated by the REST API Server
+    Given A community workspace exists for an user
+    And   User "alice" is onboarded
+    Then "alice" can not update the InternalWorkspace directly
+
   Scenario: visibility changes from private to community
     Given A private workspace exists for an user
     When  The owner changes visibility to community
@@ -1,9 +1,12 @@
 package rest
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -55,6 +58,26 @@ func BuildDefaultHostClient() (client.Client, error) {
 	return client.New(cfg, client.Options{Scheme: scheme})
 }
 
+// BuildImpersonatingHostClient builds a host client impersonating the user, as the REST API Server does.
+// It uses NewDefaultClientConfig for retrieving the client configuration.
+func BuildImpersonatingHostClient(user toolchainv1alpha1.UserSignup) (client.Client, error) {
+	cfg, err := NewDefaultClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error building config: %v", err)
+	}
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: user.Status.CompliantUsername,
+		Groups:   []string{"system:authenticated", toolchainv1alpha1.KubesawAuthenticatedUsername},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(workspacesiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(toolchainv1alpha1.AddToScheme(scheme))
+
+	return client.New(cfg, client.Options{Scheme: scheme})
+}
+
 // BuildWorkspacesClient builds a client that targets the Workspaces REST API server.
 // It also builds a valid JWT token for authenticating the requests.
 func BuildWorkspacesClient(ctx context.Context) (client.Client, error) {
@@ -99,6 +122,31 @@ func BuildWorkspacesClientWithToken(ctx context.Context, t string) (client.Clien
 
 }
 
+// DoWorkspacesRequest sends as the context's user a request to the Workspaces REST API server,
+// for the endpoints not served as Kubernetes resources, e.g. the workspaces' members.
+func DoWorkspacesRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	t, err := auth.BuildJwtForContextUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	k := rest.CopyConfig(tcontext.RetrieveUnauthKubeconfig(ctx))
+	k.BearerToken = t
+	k.Host = os.Getenv("PROXY_URL")
+
+	hc, err := rest.HTTPClientFor(k)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(k.Host, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	return hc.Do(r)
+}
+
 // BuildDefaultRESTMapper builds a RESTMapper from the default client configuration.
 func BuildDefaultRESTMapper() (meta.RESTMapper, error) {
 	cfg, err := NewDefaultClientConfig()
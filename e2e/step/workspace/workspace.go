@@ -11,9 +11,14 @@ func RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Given(`^Default workspace is created for "([^"]*)"$`, givenDefaultWorkspaceIsCreatedForCustomUser)
 	ctx.Given(`^Workspace\'s Space has cluster URL set$`, givenWorkspaceHasClusterURLSet)
 	ctx.Given(`^Workspace\'s Space has no cluster URL set$`, givenWorkspaceHasNoClusterURLSet)
+	ctx.Given(`^The user shares the workspace with "([^"]*)" as "([^"]*)"$`, givenTheUserSharesTheWorkspaceWith)
 
 	// when
 	ctx.When(`^A workspace is created for an user$`, whenAWorkspaceIsCreatedForUser)
+	ctx.When(`^The user requests a new private workspace$`, whenTheUserRequestsANewPrivateWorkspace)
+	ctx.When(`^The user requests a new community workspace$`, whenTheUserRequestsANewCommunityWorkspace)
+	ctx.When(`^The user deletes the workspace$`, whenTheUserDeletesTheWorkspace)
+	ctx.When(`^The user removes "([^"]*)" from the workspace members$`, whenTheUserRemovesFromTheWorkspaceMembers)
 	ctx.When(`^The owner changes visibility to community$`, whenOwnerChangesVisibilityToCommunity)
 	ctx.When(`^The owner changes visibility to private$`, whenOwnerChangesVisibilityToPrivate)
 
@@ -22,11 +27,15 @@ func RegisterSteps(ctx *godog.ScenarioContext) {
 	ctx.Then(`^The workspace is readable only for the ones directly granted access to$`, thenTheWorkspaceIsReadableOnlyForGranted)
 	ctx.Then(`^A community workspace is created$`, thenACommunityWorkspaceIsCreated)
 	ctx.Then(`^A private workspace is created$`, thenAPrivateWorkspaceIsCreated)
+	ctx.Then(`^The workspace is deleted$`, thenTheWorkspaceIsDeleted)
+	ctx.Then(`^The user can not delete the default workspace$`, thenTheUserCanNotDeleteTheDefaultWorkspace)
+	ctx.Then(`^"([^"]*)" is not a member of the workspace$`, thenUserIsNotAMemberOfTheWorkspace)
 	ctx.Then(`^Default workspace is created for them$`, thenDefaultWorkspaceIsCreatedForThem)
 	ctx.Then(`^The owner is granted admin access to the workspace$`, thenTheOwnerIsGrantedAdminAccessToTheWorkspace)
 	ctx.Then(`^The workspace visibility is set to "([^"]*)"$`, thenTheWorkspaceVisibilityIsSetTo)
 	ctx.Then(`^"([^"]*)" can not change workspace visibility to "([^"]*)"$`, thenUserCanNotChangeVisibilityTo)
 	ctx.Then(`^"([^"]*)" can not patch workspace visibility to "([^"]*)"$`, thenUserCanNotPatchVisibilityTo)
+	ctx.Then(`^"([^"]*)" can not update the InternalWorkspace directly$`, thenUserCanNotUpdateTheInternalWorkspaceDirectly)
 
 	ctx.Then(`^The workspace visibility is updated to "([^"]*)"$`, thenTheWorkspaceVisibilityIsUpdatedTo)
 	ctx.Then(`^Workspace has cluster URL in status$`, thenDefaultWorkspaceHasClusterURLInStatus)
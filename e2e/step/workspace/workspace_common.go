@@ -3,6 +3,9 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -10,6 +13,7 @@ import (
 	"github.com/konflux-workspaces/workspaces/e2e/pkg/cli"
 	tcontext "github.com/konflux-workspaces/workspaces/e2e/pkg/context"
 	"github.com/konflux-workspaces/workspaces/e2e/pkg/poll"
+	wrest "github.com/konflux-workspaces/workspaces/e2e/pkg/rest"
 	"github.com/konflux-workspaces/workspaces/e2e/step/user"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
@@ -90,3 +94,43 @@ func checkWorkspaceVisibility(ctx context.Context, name string, visibility works
 	}
 	return nil
 }
+
+// doMemberRequest sends as the context's user a request for the member `name` of the context's workspace
+func doMemberRequest(ctx context.Context, method, name string, body []byte) error {
+	u := tcontext.RetrieveUser(ctx)
+	m := tcontext.RetrieveCustomUser(ctx, name)
+	iw := tcontext.RetrieveInternalWorkspace(ctx)
+
+	p := fmt.Sprintf("/apis/workspaces.konflux-ci.dev/v1alpha1/namespaces/%s/workspaces/%s/members/%s",
+		u.Status.CompliantUsername, iw.Spec.DisplayName, m.Status.CompliantUsername)
+	r, err := wrest.DoWorkspacesRequest(ctx, method, p, body)
+	if err != nil {
+		return fmt.Errorf("error requesting %s %s: %w", method, p, err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		d, _ := io.ReadAll(r.Body)
+		return fmt.Errorf("unexpected status code requesting %s %s: %d %s", method, p, r.StatusCode, d)
+	}
+	return nil
+}
+
+// waitForMembership waits until the context's workspace's members include the user `name`, or not
+func waitForMembership(ctx context.Context, name string, member bool) error {
+	cli := tcontext.RetrieveHostClient(ctx)
+	m := tcontext.RetrieveCustomUser(ctx, name)
+	iw := tcontext.RetrieveInternalWorkspace(ctx)
+
+	return poll.WaitForConditionImmediately(ctx, func(ctx context.Context) (done bool, err error) {
+		w := workspacesv1alpha1.InternalWorkspace{}
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(&iw), &w); err != nil {
+			return false, err
+		}
+
+		found := slices.ContainsFunc(w.Spec.Members, func(wm workspacesv1alpha1.InternalWorkspaceMember) bool {
+			return wm.Username == m.Status.CompliantUsername
+		})
+		return found == member, nil
+	})
+}
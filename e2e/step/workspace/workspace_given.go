@@ -2,6 +2,8 @@ package workspace
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	tcontext "github.com/konflux-workspaces/workspaces/e2e/pkg/context"
@@ -92,3 +94,15 @@ func givenACommunityWorkspaceExists(ctx context.Context) (context.Context, error
 
 	return ctx, nil
 }
+
+func givenTheUserSharesTheWorkspaceWith(ctx context.Context, name, role string) (context.Context, error) {
+	b := []byte(fmt.Sprintf(`{"role":%q}`, role))
+	if err := doMemberRequest(ctx, http.MethodPut, name, b); err != nil {
+		return ctx, err
+	}
+
+	if err := waitForMembership(ctx, name, true); err != nil {
+		return ctx, fmt.Errorf("error waiting for %s to become a member of the workspace: %w", name, err)
+	}
+	return ctx, nil
+}
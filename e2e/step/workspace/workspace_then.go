@@ -159,6 +159,26 @@ func thenUserCanNotChangeVisibilityTo(ctx context.Context, user, visibility stri
 	}
 }
 
+func thenUserCanNotUpdateTheInternalWorkspaceDirectly(ctx context.Context, user string) error {
+	iw := tcontext.RetrieveInternalWorkspace(ctx)
+	u := tcontext.RetrieveCustomUser(ctx, user)
+	cli, err := wrest.BuildImpersonatingHostClient(u)
+	if err != nil {
+		return err
+	}
+
+	iw.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+	err = cli.Update(ctx, &iw)
+	switch {
+	case err == nil:
+		return fmt.Errorf("expected forbidden error updating the InternalWorkspace, got no error")
+	case errors.IsForbidden(err):
+		return nil
+	default:
+		return fmt.Errorf("expected forbidden error updating the InternalWorkspace, got %v", err)
+	}
+}
+
 func thenUserCanNotPatchVisibilityTo(ctx context.Context, user, visibility string) error {
 	iw := tcontext.RetrieveInternalWorkspace(ctx)
 	u := tcontext.RetrieveCustomUser(ctx, user)
@@ -240,3 +260,57 @@ func thenTheWorkspaceVisibilityIsUpdatedTo(ctx context.Context, visibility strin
 		return true, nil
 	})
 }
+
+func thenTheWorkspaceIsDeleted(ctx context.Context) error {
+	cli := tcontext.RetrieveHostClient(ctx)
+	kns := tcontext.RetrieveKubespaceNamespace(ctx)
+	iw := tcontext.RetrieveInternalWorkspace(ctx)
+
+	return poll.WaitForConditionImmediately(ctx, func(ctx context.Context) (done bool, err error) {
+		// the InternalWorkspace is deleted
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(&iw), &workspacesv1alpha1.InternalWorkspace{}); !errors.IsNotFound(err) {
+			return false, client.IgnoreNotFound(err)
+		}
+
+		// the backing Space is deleted
+		sk := types.NamespacedName{Name: iw.Name, Namespace: kns}
+		if err := cli.Get(ctx, sk, &toolchainv1alpha1.Space{}); !errors.IsNotFound(err) {
+			return false, client.IgnoreNotFound(err)
+		}
+		return true, nil
+	})
+}
+
+func thenTheUserCanNotDeleteTheDefaultWorkspace(ctx context.Context) error {
+	u := tcontext.RetrieveUser(ctx)
+	iw := tcontext.RetrieveInternalWorkspace(ctx)
+	cli, err := wrest.BuildWorkspacesClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := restworkspacesv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workspacesv1alpha1.DisplayNameDefaultWorkspace,
+			Namespace: u.Status.CompliantUsername,
+		},
+	}
+	err = cli.Delete(ctx, &w)
+	switch {
+	case err == nil:
+		return fmt.Errorf("expected forbidden error deleting the default workspace, got no error")
+	case !errors.IsForbidden(err):
+		return fmt.Errorf("expected forbidden error deleting the default workspace, got %v", err)
+	}
+
+	// the InternalWorkspace still exists
+	hcli := tcontext.RetrieveHostClient(ctx)
+	return hcli.Get(ctx, client.ObjectKeyFromObject(&iw), &workspacesv1alpha1.InternalWorkspace{})
+}
+
+func thenUserIsNotAMemberOfTheWorkspace(ctx context.Context, name string) error {
+	if err := waitForMembership(ctx, name, false); err != nil {
+		return fmt.Errorf("error waiting for %s to be removed from the workspace members: %w", name, err)
+	}
+	return nil
+}
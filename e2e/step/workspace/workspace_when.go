@@ -3,15 +3,23 @@ package workspace
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	tcontext "github.com/konflux-workspaces/workspaces/e2e/pkg/context"
+	"github.com/konflux-workspaces/workspaces/e2e/pkg/poll"
+	wrest "github.com/konflux-workspaces/workspaces/e2e/pkg/rest"
 	"github.com/konflux-workspaces/workspaces/e2e/step/user"
 
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 )
 
+const newWorkspaceName = "new-workspace"
+
 func whenAWorkspaceIsCreatedForUser(ctx context.Context) (context.Context, error) {
 	cli := tcontext.RetrieveHostClient(ctx)
 	ns := tcontext.RetrieveKubespaceNamespace(ctx)
@@ -56,3 +64,89 @@ func ownerChangesVisibilityTo(ctx context.Context, visibility workspacesv1alpha1
 
 	return tcontext.InjectInternalWorkspace(ctx, w), nil
 }
+
+func whenTheUserRequestsANewPrivateWorkspace(ctx context.Context) (context.Context, error) {
+	return userRequestsANewWorkspace(ctx, restworkspacesv1alpha1.WorkspaceVisibilityPrivate)
+}
+
+func whenTheUserRequestsANewCommunityWorkspace(ctx context.Context) (context.Context, error) {
+	return userRequestsANewWorkspace(ctx, restworkspacesv1alpha1.WorkspaceVisibilityCommunity)
+}
+
+func userRequestsANewWorkspace(ctx context.Context, visibility restworkspacesv1alpha1.WorkspaceVisibility) (context.Context, error) {
+	u := tcontext.RetrieveUser(ctx)
+	hcli := tcontext.RetrieveHostClient(ctx)
+
+	c, err := wrest.BuildWorkspacesClient(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	// create the workspace via REST API
+	w := restworkspacesv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hcli.EnsurePrefix(newWorkspaceName),
+			Namespace: u.Status.CompliantUsername,
+		},
+		Spec: restworkspacesv1alpha1.WorkspaceSpec{
+			Visibility: visibility,
+		},
+	}
+	if err := c.Create(ctx, &w); err != nil {
+		return ctx, fmt.Errorf("error creating workspace %s/%s: %w", w.Namespace, w.Name, err)
+	}
+
+	// retrieve the InternalWorkspace backing the new workspace
+	iw, err := getUserInternalWorkspaceByDisplayName(ctx, u.Spec.IdentityClaims.Sub, w.Name)
+	if err != nil {
+		return ctx, err
+	}
+	return tcontext.InjectInternalWorkspace(ctx, *iw), nil
+}
+
+func getUserInternalWorkspaceByDisplayName(ctx context.Context, sub, displayName string) (*workspacesv1alpha1.InternalWorkspace, error) {
+	cli := tcontext.RetrieveHostClient(ctx)
+	ns := tcontext.RetrieveWorkspacesNamespace(ctx)
+
+	var iw *workspacesv1alpha1.InternalWorkspace
+	if err := poll.WaitForConditionImmediately(ctx, func(ctx context.Context) (done bool, err error) {
+		ww := workspacesv1alpha1.InternalWorkspaceList{}
+		if err := cli.Client.List(ctx, &ww, client.InNamespace(ns)); err != nil {
+			return false, err
+		}
+
+		for _, w := range ww.Items {
+			if w.Spec.DisplayName == displayName && w.Spec.Owner.JwtInfo.Sub == sub {
+				iw = &w
+				return true, nil
+			}
+		}
+		return false, nil
+	}); err != nil {
+		return nil, fmt.Errorf("error retrieving workspace %s for user %s: %w", displayName, sub, err)
+	}
+	return iw, nil
+}
+
+func whenTheUserDeletesTheWorkspace(ctx context.Context) (context.Context, error) {
+	iw := tcontext.RetrieveInternalWorkspace(ctx)
+	cli, err := wrest.BuildWorkspacesClient(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	w := restworkspacesv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      iw.Spec.DisplayName,
+			Namespace: iw.Status.Owner.Username,
+		},
+	}
+	if err := cli.Delete(ctx, &w); err != nil {
+		return ctx, fmt.Errorf("error deleting workspace %s/%s: %w", w.Namespace, w.Name, err)
+	}
+	return ctx, nil
+}
+
+func whenTheUserRemovesFromTheWorkspaceMembers(ctx context.Context, name string) (context.Context, error) {
+	return ctx, doMemberRequest(ctx, http.MethodDelete, name, nil)
+}
@@ -22,10 +22,18 @@ import (
 
 type InternalWorkspaceVisibility string
 
+type InternalWorkspaceRole string
+
+// OwnerDeletionPolicy defines what happens to an InternalWorkspace
+// when the UserSignup of its owner is deleted
+type OwnerDeletionPolicy string
+
 const (
-	// PublicViewerName the name of the KubeSaw's PublicViewer user
+	// PublicViewerName the name of the KubeSaw's PublicViewer user.
+	// It is not configurable, as KubeSaw grants the community access to this user only.
 	PublicViewerName string = "kubesaw-authenticated"
-	// DisplayNameDefaultWorkspace display name for the default Workspace
+	// DisplayNameDefaultWorkspace display name for the default Workspace.
+	// It is not configurable, as the existing home InternalWorkspaces are identified by it.
 	DisplayNameDefaultWorkspace string = "default"
 
 	// InternalWorkspaceVisibilityCommunity Community value for InternalWorkspaces visibility
@@ -33,19 +41,98 @@ const (
 	// InternalWorkspaceVisibilityPrivate Private value for InternalWorkspaces visibility
 	InternalWorkspaceVisibilityPrivate InternalWorkspaceVisibility = "private"
 
+	// InternalWorkspaceRoleViewer grants read-only access to the InternalWorkspace
+	InternalWorkspaceRoleViewer InternalWorkspaceRole = "viewer"
+	// InternalWorkspaceRoleContributor grants contributor access to the InternalWorkspace
+	InternalWorkspaceRoleContributor InternalWorkspaceRole = "contributor"
+	// InternalWorkspaceRoleMaintainer grants maintainer access to the InternalWorkspace
+	InternalWorkspaceRoleMaintainer InternalWorkspaceRole = "maintainer"
+	// InternalWorkspaceRoleAdmin grants admin access to the InternalWorkspace
+	InternalWorkspaceRoleAdmin InternalWorkspaceRole = "admin"
+
 	// LabelInternalDomain domain for internal labels
 	LabelInternalDomain string = "internal.workspaces.konflux-ci.dev/"
 
-	// ConditionTypeReady indicates whether an InternalWorkspace is Ready
+	// FinalizerCleanUp finalizer ensuring the resources backing an InternalWorkspace
+	// are cleaned up before the InternalWorkspace is deleted
+	FinalizerCleanUp string = "workspaces.konflux-ci.dev/cleanup"
+
+	// LabelWorkspaceMember marks the SpaceBindings granting access to InternalWorkspace's members
+	LabelWorkspaceMember string = LabelInternalDomain + "member"
+
+	// OwnerDeletionPolicyDelete deletes the InternalWorkspace together with its Space
+	OwnerDeletionPolicyDelete OwnerDeletionPolicy = "Delete"
+	// OwnerDeletionPolicyArchive keeps the InternalWorkspace and its Space, but revokes any access to it
+	OwnerDeletionPolicyArchive OwnerDeletionPolicy = "Archive"
+	// OwnerDeletionPolicyTransfer transfers the InternalWorkspace to the fallback owner
+	OwnerDeletionPolicyTransfer OwnerDeletionPolicy = "Transfer"
+	// OwnerDeletionPolicyOrphan keeps the InternalWorkspace as is, and flags it as orphaned
+	OwnerDeletionPolicyOrphan OwnerDeletionPolicy = "Orphan"
+
+	// LabelOrphaned marks the InternalWorkspaces whose owner was deleted
+	// and that were orphaned according to their owner deletion policy
+	LabelOrphaned string = LabelInternalDomain + "orphaned"
+	// LabelArchived marks the InternalWorkspaces whose owner was deleted
+	// and that were archived according to their owner deletion policy
+	LabelArchived string = LabelInternalDomain + "archived"
+
+	// AnnotationOwnerTransfer authorizes the change of an InternalWorkspace's owner.
+	// Its value must be the Sub of the new owner.
+	// It is removed by the operator once the transfer is completed.
+	AnnotationOwnerTransfer string = LabelInternalDomain + "owner-transfer"
+
+	// AnnotationUserSignupIssuer records on UserSignups the issuer of the identity provider
+	// the user's Sub belongs to. UserSignups lacking it belong to the default issuer.
+	AnnotationUserSignupIssuer string = "workspaces.konflux-ci.dev/issuer"
+
+	// ConditionTypeReady indicates whether an InternalWorkspace is Ready.
+	// It rolls up the OwnerResolved, SpaceProvisioned and VisibilityApplied conditions
 	ConditionTypeReady string = "Ready"
+	// ConditionTypeOwnerResolved indicates whether the UserSignup of the InternalWorkspace's
+	// owner was found
+	ConditionTypeOwnerResolved string = "OwnerResolved"
+	// ConditionTypeSpaceProvisioned indicates whether the Space backing the InternalWorkspace
+	// exists
+	ConditionTypeSpaceProvisioned string = "SpaceProvisioned"
+	// ConditionTypeVisibilityApplied indicates whether the InternalWorkspace's visibility
+	// is reflected by the community SpaceBinding
+	ConditionTypeVisibilityApplied string = "VisibilityApplied"
+
 	// ConditionReasonEverythingFine indicates "everything is fine"
 	ConditionReasonEverythingFine string = "EverythingFine"
+	// ConditionReasonReconciling means that some of the checks rolled up into
+	// the Ready condition were not evaluated yet
+	ConditionReasonReconciling string = "Reconciling"
+	// ConditionReasonOwnerFound means that the UserSignup for the InternalWorkspace
+	// was found
+	ConditionReasonOwnerFound string = "OwnerFound"
 	// ConditionReasonOwnerNotFound means that the UserSignup for the InternalWorkspace
 	// was not found
 	ConditionReasonOwnerNotFound string = "OwnerNotFound"
+	// ConditionReasonOwnerAmbiguous means that more than one UserSignup
+	// matches the owner of the InternalWorkspace
+	ConditionReasonOwnerAmbiguous string = "OwnerAmbiguous"
+	// ConditionReasonSpaceReady means that the Space for the InternalWorkspace
+	// is provisioned and ready
+	ConditionReasonSpaceReady string = "SpaceReady"
 	// ConditionReasonSpaceNotFound means that the Space for the InternalWorkspace
 	// was not found
 	ConditionReasonSpaceNotFound string = "SpaceNotFound"
+	// ConditionReasonSpaceProvisioning means that the Space for the InternalWorkspace
+	// is still being provisioned
+	ConditionReasonSpaceProvisioning string = "SpaceProvisioning"
+	// ConditionReasonSpaceProvisioningFailed means that KubeSaw failed to provision
+	// the Space for the InternalWorkspace
+	ConditionReasonSpaceProvisioningFailed string = "SpaceProvisioningFailed"
+	// ConditionReasonSpaceTerminating means that the Space for the InternalWorkspace
+	// is being deleted
+	ConditionReasonSpaceTerminating string = "SpaceTerminating"
+	// ConditionReasonVisibilitySatisfied means that the community SpaceBinding
+	// reflects the InternalWorkspace's visibility
+	ConditionReasonVisibilitySatisfied string = "VisibilitySatisfied"
+	// ConditionReasonVisibilityNotSatisfied means that the community SpaceBinding
+	// could not be aligned to the InternalWorkspace's visibility
+	ConditionReasonVisibilityNotSatisfied string = "VisibilityNotSatisfied"
 )
 
 // UserInfo contains information about a user identity
@@ -62,6 +149,21 @@ type JwtInfo struct {
 	UserId string `json:"userId"`
 	//+required
 	Sub string `json:"sub"`
+	// Issuer is the issuer of the identity provider the Sub belongs to.
+	// If not set, the default issuer configured in the WorkspacesConfig is assumed.
+	//+optional
+	Issuer string `json:"issuer,omitempty"`
+}
+
+// IdentityKey returns the key identifying the user across identity providers
+func (j JwtInfo) IdentityKey() string {
+	return IdentityKey(j.Issuer, j.Sub)
+}
+
+// IdentityKey returns the key identifying the user with the given Sub
+// among the ones of the identity provider with the given issuer
+func IdentityKey(issuer, sub string) string {
+	return issuer + " " + sub
 }
 
 // InternalWorkspaceSpec defines the desired state of Workspace
@@ -73,8 +175,44 @@ type InternalWorkspaceSpec struct {
 	Visibility InternalWorkspaceVisibility `json:"visibility"`
 	//+required
 	Owner UserInfo `json:"owner"`
+	// Members contains the users the InternalWorkspace is shared with
+	//+optional
+	//+listType=map
+	//+listMapKey=username
+	Members []InternalWorkspaceMember `json:"members,omitempty"`
+	// OwnerDeletionPolicy overrides the cluster's policy applied
+	// when the UserSignup of the owner is deleted.
+	// It is ignored for home InternalWorkspaces, that are always deleted.
+	//+optional
+	//+kubebuilder:validation:Enum:=Delete;Archive;Transfer;Orphan
+	OwnerDeletionPolicy OwnerDeletionPolicy `json:"ownerDeletionPolicy,omitempty"`
 }
 
+// InternalWorkspaceMember a user granted access to the InternalWorkspace
+type InternalWorkspaceMember struct {
+	// Username the compliant username of the user
+	//+required
+	Username string `json:"username"`
+	// Role the role granted to the user
+	//+required
+	//+kubebuilder:validation:Enum:=viewer;contributor;maintainer;admin
+	Role InternalWorkspaceRole `json:"role"`
+}
+
+// SpacePhase the provisioning phase of a Space
+type SpacePhase string
+
+const (
+	// SpacePhaseProvisioning the Space is being provisioned by KubeSaw
+	SpacePhaseProvisioning SpacePhase = "Provisioning"
+	// SpacePhaseProvisioned the Space is provisioned and ready
+	SpacePhaseProvisioned SpacePhase = "Provisioned"
+	// SpacePhaseProvisioningFailed KubeSaw failed to provision the Space
+	SpacePhaseProvisioningFailed SpacePhase = "ProvisioningFailed"
+	// SpacePhaseTerminating the Space is being deleted
+	SpacePhaseTerminating SpacePhase = "Terminating"
+)
+
 // SpaceInfo Information about a Space
 type SpaceInfo struct {
 	//+required
@@ -84,16 +222,45 @@ type SpaceInfo struct {
 	// TargetCluster contains the URL to the cluster where the workspace's namespaces live
 	//+optional
 	TargetCluster string `json:"targetCluster,omitempty"`
+	// Tier contains the name of the KubeSaw tier the Space is provisioned with
+	//+optional
+	Tier string `json:"tier,omitempty"`
+	// Phase contains the provisioning phase of the Space
+	//+optional
+	//+kubebuilder:validation:Enum:=Provisioning;Provisioned;ProvisioningFailed;Terminating
+	Phase SpacePhase `json:"phase,omitempty"`
+	// Message contains the reason why the Space's provisioning failed
+	//+optional
+	Message string `json:"message,omitempty"`
+	// Namespaces contains the namespaces provisioned for the Space
+	//+optional
+	Namespaces []SpaceNamespace `json:"namespaces,omitempty"`
+}
+
+// SpaceNamespace a namespace provisioned for a Space
+type SpaceNamespace struct {
+	//+required
+	Name string `json:"name"`
+	// Type the type of the namespace, e.g. default
+	//+optional
+	Type string `json:"type,omitempty"`
 }
 
 // UserInfoStatus User info stored in the status
 type UserInfoStatus struct {
 	//+optional
 	Username string `json:"username,omitempty"`
+	// Email contains the owner's current email, as reported by its UserSignup
+	//+optional
+	Email string `json:"email,omitempty"`
 }
 
 // InternalWorkspaceStatus defines the observed state of Workspace
 type InternalWorkspaceStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	//+optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -104,6 +271,10 @@ type InternalWorkspaceStatus struct {
 	// Owner contains information on the owner
 	//+optional
 	Owner UserInfoStatus `json:"owner,omitempty"`
+
+	// Members contains the users actually granted access to the InternalWorkspace
+	//+optional
+	Members []InternalWorkspaceMember `json:"members,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WorkspacesConfigName the name of the only WorkspacesConfig read by the operator and the REST server
+	WorkspacesConfigName string = "workspaces-config"
+
+	// DefaultWorkspacesNamespace the namespace InternalWorkspaces are stored in, if not configured
+	DefaultWorkspacesNamespace string = "workspaces-system"
+	// DefaultKubesawNamespace the namespace of KubeSaw's host operator, if not configured
+	DefaultKubesawNamespace string = "toolchain-host-operator"
+	// DefaultCommunityRole the KubeSaw SpaceRole granted on community InternalWorkspaces, if not configured
+	DefaultCommunityRole string = "viewer"
+	// DefaultMaxNameLength the maximum length of InternalWorkspaces' DisplayName, if not configured
+	DefaultMaxNameLength int32 = 63
+)
+
+// WorkspacesConfigSpec defines the configuration shared by the operator and the REST server.
+// Unset fields assume their default value.
+// The public viewer kubesaw-authenticated and the home InternalWorkspaces' display name default
+// are not configurable, as KubeSaw and the existing home InternalWorkspaces rely on them.
+type WorkspacesConfigSpec struct {
+	// Namespaces configures where InternalWorkspaces and KubeSaw's resources are stored.
+	// Changing them restarts the operator and the REST server.
+	//+optional
+	Namespaces WorkspacesConfigNamespaces `json:"namespaces,omitempty"`
+	// Identity configures how users are identified across identity providers
+	//+optional
+	Identity WorkspacesConfigIdentity `json:"identity,omitempty"`
+	// Community configures how community InternalWorkspaces are shared
+	//+optional
+	Community WorkspacesConfigCommunity `json:"community,omitempty"`
+	// Spaces configures the KubeSaw Spaces backing non-home InternalWorkspaces
+	//+optional
+	Spaces WorkspacesConfigSpaces `json:"spaces,omitempty"`
+	// Quotas configures the limits applied to users
+	//+optional
+	Quotas WorkspacesConfigQuotas `json:"quotas,omitempty"`
+	// NameRules configures the names InternalWorkspaces can have
+	//+optional
+	NameRules WorkspacesConfigNameRules `json:"nameRules,omitempty"`
+	// Features enables or disables optional features
+	//+optional
+	Features WorkspacesConfigFeatures `json:"features,omitempty"`
+}
+
+// WorkspacesConfigNamespaces configures the namespaces the operator and the REST server work in
+type WorkspacesConfigNamespaces struct {
+	// Workspaces the namespace InternalWorkspaces are stored in.
+	// Defaults to workspaces-system.
+	//+optional
+	Workspaces string `json:"workspaces,omitempty"`
+	// Kubesaw the namespace of KubeSaw's host operator, storing UserSignups, Spaces and SpaceBindings.
+	// Defaults to toolchain-host-operator.
+	//+optional
+	Kubesaw string `json:"kubesaw,omitempty"`
+}
+
+// WorkspacesConfigIdentity configures how users are identified.
+// Users are identified by the issuer of their identity provider and their Sub.
+type WorkspacesConfigIdentity struct {
+	// DefaultIssuer the issuer assumed for the users whose issuer is not known,
+	// e.g. InternalWorkspaces and UserSignups created before multiple identity providers were supported.
+	// If not set, the users whose issuer is not known match the users of any issuer with the same Sub.
+	//+optional
+	DefaultIssuer string `json:"defaultIssuer,omitempty"`
+}
+
+// WorkspacesConfigCommunity configures community InternalWorkspaces
+type WorkspacesConfigCommunity struct {
+	// Role the KubeSaw SpaceRole granted to all the authenticated users on community InternalWorkspaces.
+	// Defaults to viewer.
+	//+optional
+	Role string `json:"role,omitempty"`
+}
+
+// WorkspacesConfigSpaces configures the KubeSaw Spaces backing non-home InternalWorkspaces
+type WorkspacesConfigSpaces struct {
+	// Tier the KubeSaw NSTemplateTier the Spaces are provisioned with.
+	// Defaults to the tier of the owner's home Space.
+	//+optional
+	Tier string `json:"tier,omitempty"`
+}
+
+// WorkspacesConfigQuotas configures the limits applied to users
+type WorkspacesConfigQuotas struct {
+	// MaxWorkspacesPerUser the maximum number of InternalWorkspaces a user can own,
+	// the home one included. Unlimited if not set.
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	MaxWorkspacesPerUser *int32 `json:"maxWorkspacesPerUser,omitempty"`
+	// Overrides replace MaxWorkspacesPerUser for the users they match.
+	// If more than one override matches a user, the first one in the list is applied.
+	//+listType=atomic
+	//+optional
+	Overrides []WorkspacesConfigQuotaOverride `json:"overrides,omitempty"`
+}
+
+// WorkspacesConfigQuotaOverride overrides the maximum number of InternalWorkspaces
+// the matching users can own. A user matches if they satisfy all the set criteria.
+//
+//+kubebuilder:validation:XValidation:rule="has(self.userSignupSelector) || has(self.tier)",message="at least one of userSignupSelector and tier must be set"
+type WorkspacesConfigQuotaOverride struct {
+	// UserSignupSelector matches the labels of the users' UserSignup
+	//+optional
+	UserSignupSelector *metav1.LabelSelector `json:"userSignupSelector,omitempty"`
+	// Tier matches the KubeSaw tier of the users' home Space
+	//+optional
+	Tier string `json:"tier,omitempty"`
+	// MaxWorkspacesPerUser the maximum number of InternalWorkspaces the matching users can own,
+	// the home one included. Unlimited if not set.
+	//+kubebuilder:validation:Minimum:=1
+	//+optional
+	MaxWorkspacesPerUser *int32 `json:"maxWorkspacesPerUser,omitempty"`
+}
+
+// WorkspacesConfigNameRules configures the names InternalWorkspaces can have
+type WorkspacesConfigNameRules struct {
+	// MaxLength the maximum length of InternalWorkspaces' DisplayName.
+	// Defaults to 63.
+	//+kubebuilder:validation:Minimum:=1
+	//+kubebuilder:validation:Maximum:=63
+	//+optional
+	MaxLength int32 `json:"maxLength,omitempty"`
+	// ReservedNames the DisplayNames users can not give to their InternalWorkspaces.
+	// Already existing InternalWorkspaces are not affected.
+	//+listType=set
+	//+optional
+	ReservedNames []string `json:"reservedNames,omitempty"`
+}
+
+// WorkspacesConfigFeatures enables or disables optional features.
+// All features are enabled if not set.
+type WorkspacesConfigFeatures struct {
+	// WorkspaceCreation allows users to create InternalWorkspaces other than the home one
+	//+optional
+	WorkspaceCreation *bool `json:"workspaceCreation,omitempty"`
+	// CommunityVisibility allows InternalWorkspaces to be shared with the community.
+	// When disabled, existing community InternalWorkspaces are treated as private.
+	//+optional
+	CommunityVisibility *bool `json:"communityVisibility,omitempty"`
+	// OwnershipTransfer allows InternalWorkspaces to be transferred to another owner
+	//+optional
+	OwnershipTransfer *bool `json:"ownershipTransfer,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:validation:XValidation:rule="self.metadata.name == 'workspaces-config'",message="the WorkspacesConfig must be named workspaces-config"
+
+// WorkspacesConfig is the Schema for the workspacesconfigs API.
+// It configures both the operator and the REST server,
+// which watch it and apply its changes without being redeployed.
+type WorkspacesConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspacesConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// WorkspacesConfigList contains a list of WorkspacesConfig
+type WorkspacesConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspacesConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspacesConfig{}, &WorkspacesConfigList{})
+}
+
+// Default sets the unset fields to their default value
+func (s *WorkspacesConfigSpec) Default() {
+	if s.Namespaces.Workspaces == "" {
+		s.Namespaces.Workspaces = DefaultWorkspacesNamespace
+	}
+	if s.Namespaces.Kubesaw == "" {
+		s.Namespaces.Kubesaw = DefaultKubesawNamespace
+	}
+	if s.Community.Role == "" {
+		s.Community.Role = DefaultCommunityRole
+	}
+	if s.NameRules.MaxLength == 0 {
+		s.NameRules.MaxLength = DefaultMaxNameLength
+	}
+}
+
+// MaxWorkspacesFor returns the maximum number of InternalWorkspaces a user can own, or nil if unlimited.
+// The user is identified by the labels of their UserSignup and the tier of their home Space.
+func (q WorkspacesConfigQuotas) MaxWorkspacesFor(userSignupLabels map[string]string, tier string) (*int32, error) {
+	for _, o := range q.Overrides {
+		if o.Tier != "" && o.Tier != tier {
+			continue
+		}
+		if o.UserSignupSelector != nil {
+			s, err := metav1.LabelSelectorAsSelector(o.UserSignupSelector)
+			if err != nil {
+				return nil, err
+			}
+			if !s.Matches(labels.Set(userSignupLabels)) {
+				continue
+			}
+		}
+		return o.MaxWorkspacesPerUser, nil
+	}
+	return q.MaxWorkspacesPerUser, nil
+}
+
+// IssuerOrDefault returns the issuer, or the default issuer if not set
+func (i WorkspacesConfigIdentity) IssuerOrDefault(issuer string) string {
+	if issuer == "" {
+		return i.DefaultIssuer
+	}
+	return issuer
+}
+
+// IdentityKeys returns the keys the user can be recorded with. An unset issuer and
+// the default issuer are equivalent, so both keys are returned for the users of the default issuer.
+// If no default issuer is configured, records lacking the issuer match the users of any issuer.
+func (i WorkspacesConfigIdentity) IdentityKeys(issuer, sub string) []string {
+	kk := []string{IdentityKey(issuer, sub)}
+	switch {
+	case issuer == "" && i.DefaultIssuer != "":
+		kk = append(kk, IdentityKey(i.DefaultIssuer, sub))
+	case issuer != "" && (i.DefaultIssuer == "" || issuer == i.DefaultIssuer):
+		kk = append(kk, IdentityKey("", sub))
+	}
+	return kk
+}
+
+// IsWorkspaceCreationEnabled returns true if users can create InternalWorkspaces
+func (f WorkspacesConfigFeatures) IsWorkspaceCreationEnabled() bool {
+	return isEnabled(f.WorkspaceCreation)
+}
+
+// IsCommunityVisibilityEnabled returns true if InternalWorkspaces can be shared with the community
+func (f WorkspacesConfigFeatures) IsCommunityVisibilityEnabled() bool {
+	return isEnabled(f.CommunityVisibility)
+}
+
+// IsOwnershipTransferEnabled returns true if InternalWorkspaces can be transferred to another owner
+func (f WorkspacesConfigFeatures) IsOwnershipTransferEnabled() bool {
+	return isEnabled(f.OwnershipTransfer)
+}
+
+// isEnabled returns the value of the feature toggle, or true if not set
+func isEnabled(toggle *bool) bool {
+	return toggle == nil || *toggle
+}
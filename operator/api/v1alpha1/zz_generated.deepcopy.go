@@ -30,7 +30,7 @@ func (in *InternalWorkspace) DeepCopyInto(out *InternalWorkspace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -84,10 +84,30 @@ func (in *InternalWorkspaceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalWorkspaceMember) DeepCopyInto(out *InternalWorkspaceMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalWorkspaceMember.
+func (in *InternalWorkspaceMember) DeepCopy() *InternalWorkspaceMember {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalWorkspaceMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InternalWorkspaceSpec) DeepCopyInto(out *InternalWorkspaceSpec) {
 	*out = *in
 	out.Owner = in.Owner
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]InternalWorkspaceMember, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalWorkspaceSpec.
@@ -110,8 +130,13 @@ func (in *InternalWorkspaceStatus) DeepCopyInto(out *InternalWorkspaceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Space = in.Space
+	in.Space.DeepCopyInto(&out.Space)
 	out.Owner = in.Owner
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]InternalWorkspaceMember, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalWorkspaceStatus.
@@ -142,6 +167,11 @@ func (in *JwtInfo) DeepCopy() *JwtInfo {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceInfo) DeepCopyInto(out *SpaceInfo) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]SpaceNamespace, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceInfo.
@@ -154,6 +184,21 @@ func (in *SpaceInfo) DeepCopy() *SpaceInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceNamespace) DeepCopyInto(out *SpaceNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceNamespace.
+func (in *SpaceNamespace) DeepCopy() *SpaceNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -184,3 +229,245 @@ func (in *UserInfoStatus) DeepCopy() *UserInfoStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfig) DeepCopyInto(out *WorkspacesConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfig.
+func (in *WorkspacesConfig) DeepCopy() *WorkspacesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspacesConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigCommunity) DeepCopyInto(out *WorkspacesConfigCommunity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigCommunity.
+func (in *WorkspacesConfigCommunity) DeepCopy() *WorkspacesConfigCommunity {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigCommunity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigFeatures) DeepCopyInto(out *WorkspacesConfigFeatures) {
+	*out = *in
+	if in.WorkspaceCreation != nil {
+		in, out := &in.WorkspaceCreation, &out.WorkspaceCreation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CommunityVisibility != nil {
+		in, out := &in.CommunityVisibility, &out.CommunityVisibility
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OwnershipTransfer != nil {
+		in, out := &in.OwnershipTransfer, &out.OwnershipTransfer
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigFeatures.
+func (in *WorkspacesConfigFeatures) DeepCopy() *WorkspacesConfigFeatures {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigFeatures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigIdentity) DeepCopyInto(out *WorkspacesConfigIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigIdentity.
+func (in *WorkspacesConfigIdentity) DeepCopy() *WorkspacesConfigIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigList) DeepCopyInto(out *WorkspacesConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspacesConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigList.
+func (in *WorkspacesConfigList) DeepCopy() *WorkspacesConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspacesConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigNameRules) DeepCopyInto(out *WorkspacesConfigNameRules) {
+	*out = *in
+	if in.ReservedNames != nil {
+		in, out := &in.ReservedNames, &out.ReservedNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigNameRules.
+func (in *WorkspacesConfigNameRules) DeepCopy() *WorkspacesConfigNameRules {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigNameRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigNamespaces) DeepCopyInto(out *WorkspacesConfigNamespaces) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigNamespaces.
+func (in *WorkspacesConfigNamespaces) DeepCopy() *WorkspacesConfigNamespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigNamespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigQuotaOverride) DeepCopyInto(out *WorkspacesConfigQuotaOverride) {
+	*out = *in
+	if in.UserSignupSelector != nil {
+		in, out := &in.UserSignupSelector, &out.UserSignupSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxWorkspacesPerUser != nil {
+		in, out := &in.MaxWorkspacesPerUser, &out.MaxWorkspacesPerUser
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigQuotaOverride.
+func (in *WorkspacesConfigQuotaOverride) DeepCopy() *WorkspacesConfigQuotaOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigQuotaOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigQuotas) DeepCopyInto(out *WorkspacesConfigQuotas) {
+	*out = *in
+	if in.MaxWorkspacesPerUser != nil {
+		in, out := &in.MaxWorkspacesPerUser, &out.MaxWorkspacesPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]WorkspacesConfigQuotaOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigQuotas.
+func (in *WorkspacesConfigQuotas) DeepCopy() *WorkspacesConfigQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigSpaces) DeepCopyInto(out *WorkspacesConfigSpaces) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigSpaces.
+func (in *WorkspacesConfigSpaces) DeepCopy() *WorkspacesConfigSpaces {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigSpaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacesConfigSpec) DeepCopyInto(out *WorkspacesConfigSpec) {
+	*out = *in
+	out.Namespaces = in.Namespaces
+	out.Identity = in.Identity
+	out.Community = in.Community
+	out.Spaces = in.Spaces
+	in.Quotas.DeepCopyInto(&out.Quotas)
+	in.NameRules.DeepCopyInto(&out.NameRules)
+	in.Features.DeepCopyInto(&out.Features)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacesConfigSpec.
+func (in *WorkspacesConfigSpec) DeepCopy() *WorkspacesConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacesConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"flag"
 	"os"
+	"slices"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -37,8 +39,10 @@ import (
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	workspacesiov1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/config"
 	"github.com/konflux-workspaces/workspaces/operator/internal/controller"
 	"github.com/konflux-workspaces/workspaces/operator/internal/metrics"
+	webhookinternalworkspace "github.com/konflux-workspaces/workspaces/operator/internal/webhook/internalworkspace"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -59,28 +63,35 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var ownerDeletionPolicy string
+	var ownerDeletionGracePeriod time.Duration
+	var fallbackOwner string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&ownerDeletionPolicy, "owner-deletion-policy", string(workspacesiov1alpha1.OwnerDeletionPolicyOrphan),
+		"The policy applied to workspaces whose owner is deleted, unless overridden by the workspace. "+
+			"One of Delete, Archive, Transfer, Orphan.")
+	flag.DurationVar(&ownerDeletionGracePeriod, "owner-deletion-grace-period", controller.DefaultOwnerDeletionGracePeriod,
+		"The time to wait before applying the owner deletion policy.")
+	flag.StringVar(&fallbackOwner, "owner-deletion-fallback-owner", "",
+		"The username of the user workspaces are transferred to by the Transfer owner deletion policy.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
-	kns, ok := os.LookupEnv("KUBESAW_NAMESPACE")
-	if !ok {
-		panic("Environment variable KUBESAW_NAMESPACE not found")
+	if !slices.Contains([]workspacesiov1alpha1.OwnerDeletionPolicy{
+		workspacesiov1alpha1.OwnerDeletionPolicyDelete,
+		workspacesiov1alpha1.OwnerDeletionPolicyArchive,
+		workspacesiov1alpha1.OwnerDeletionPolicyTransfer,
+		workspacesiov1alpha1.OwnerDeletionPolicyOrphan,
+	}, workspacesiov1alpha1.OwnerDeletionPolicy(ownerDeletionPolicy)) {
+		panic("invalid owner deletion policy: " + ownerDeletionPolicy)
 	}
-	setupLog.Info("Set KubesawNamespace", "value", kns)
-
-	wns, ok := os.LookupEnv("WORKSPACES_NAMESPACE")
-	if !ok {
-		panic("Environment variable WORKSPACE_NAMESPACE not found")
-	}
-	setupLog.Info("Set WorkspacesNamespace", "value", kns)
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
@@ -91,7 +102,7 @@ func main() {
 			FilterProvider: filters.WithAuthenticationAndAuthorization,
 		},
 		WebhookServer: webhook.NewServer(webhook.Options{
-			Port: 944,
+			Port: 9443,
 		}),
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -113,11 +124,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the namespaces are read once, as the reconcilers can not switch to new ones
+	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
+	defer cancel()
+	cfg, err := config.Get(ctx, mgr.GetAPIReader())
+	if err != nil {
+		setupLog.Error(err, "unable to retrieve WorkspacesConfig")
+		os.Exit(1)
+	}
+	kns, wns := cfg.Namespaces.Kubesaw, cfg.Namespaces.Workspaces
+	setupLog.Info("configured namespaces", "kubesaw-namespace", kns, "workspaces-namespace", wns)
+
+	if err = (&controller.WorkspacesConfigReconciler{
+		Client:     mgr.GetClient(),
+		Namespaces: cfg.Namespaces,
+		// stopping the manager restarts the operator with the new namespaces
+		OnNamespacesChanged: cancel,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WorkspacesConfig")
+		os.Exit(1)
+	}
 	if err = (&controller.WorkspaceReconciler{
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		KubesawNamespace:    kns,
 		WorkspacesNamespace: wns,
+
+		OwnerDeletionPolicy:      workspacesiov1alpha1.OwnerDeletionPolicy(ownerDeletionPolicy),
+		OwnerDeletionGracePeriod: ownerDeletionGracePeriod,
+		FallbackOwner:            fallbackOwner,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Workspace")
 		os.Exit(1)
@@ -130,6 +165,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "UserSignup")
 		os.Exit(1)
 	}
+	// webhooks can be disabled for running the operator locally without certificates
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookinternalworkspace.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "InternalWorkspace")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	toolchainStatusGauge := metrics.NewToolchainStatusGauge(mgr.GetClient(), kns)
@@ -145,7 +187,7 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=workspaces.konflux-ci.dev,resources=workspacesconfigs,verbs=get;list;watch
+
+// Get retrieves the operator's configuration from the WorkspacesConfig, with defaults applied.
+// If the WorkspacesConfig does not exist, the default configuration is returned.
+func Get(ctx context.Context, c client.Reader) (*workspacesv1alpha1.WorkspacesConfigSpec, error) {
+	wc := workspacesv1alpha1.WorkspacesConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, &wc); err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	wc.Spec.Default()
+	return &wc.Spec, nil
+}
@@ -3,9 +3,13 @@ package controller
 import (
 	"github.com/konflux-workspaces/workspaces/operator/internal/controller/internalworkspace"
 	"github.com/konflux-workspaces/workspaces/operator/internal/controller/usersignup"
+	"github.com/konflux-workspaces/workspaces/operator/internal/controller/workspacesconfig"
 )
 
 type (
-	UserSignupReconciler = usersignup.UserSignupReconciler
-	WorkspaceReconciler  = internalworkspace.WorkspaceReconciler
+	UserSignupReconciler       = usersignup.UserSignupReconciler
+	WorkspaceReconciler        = internalworkspace.WorkspaceReconciler
+	WorkspacesConfigReconciler = workspacesconfig.WorkspacesConfigReconciler
 )
+
+const DefaultOwnerDeletionGracePeriod = internalworkspace.DefaultOwnerDeletionGracePeriod
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalworkspace
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+// ensureEditorsRoleBindingExists grants the owner and the admins of the InternalWorkspace
+// the permissions to update and delete it, and only it, through a Role and a RoleBinding
+// named `{workspace}-editor`. The REST API Server performs these requests impersonating the users,
+// so that they can not act on the InternalWorkspaces of others.
+// Home InternalWorkspaces can not be deleted, so the deletion is not granted for them.
+func (r *WorkspaceReconciler) ensureEditorsRoleBindingExists(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	name := fmt.Sprintf("%s-editor", w.Name)
+
+	vv := []string{"get", "update", "patch"}
+	if !isHomeWorkspace(w) {
+		vv = append(vv, "delete")
+	}
+
+	ro := rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &ro, func() error {
+		ro.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{workspacesv1alpha1.GroupVersion.Group},
+				Resources:     []string{"internalworkspaces"},
+				ResourceNames: []string{w.Name},
+				Verbs:         vv,
+			},
+		}
+		return controllerutil.SetControllerReference(w, &ro, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	rb := rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: w.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, &rb, func() error {
+		rb.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}
+		rb.Subjects = editorsSubjects(w)
+		return controllerutil.SetControllerReference(w, &rb, r.Scheme)
+	})
+	return err
+}
+
+// editorsSubjects returns the owner, if resolved, and the admins of the InternalWorkspace as RBAC subjects
+func editorsSubjects(w *workspacesv1alpha1.InternalWorkspace) []rbacv1.Subject {
+	ss := []rbacv1.Subject{}
+	if o := w.Status.Owner.Username; o != "" {
+		ss = append(ss, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: o})
+	}
+	for _, m := range w.Spec.Members {
+		if m.Role == workspacesv1alpha1.InternalWorkspaceRoleAdmin && m.Username != w.Status.Owner.Username {
+			ss = append(ss, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: m.Username})
+		}
+	}
+	return ss
+}
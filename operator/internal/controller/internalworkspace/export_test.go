@@ -0,0 +1,10 @@
+package internalworkspace
+
+// MapUserSignupToWorkspace exposes mapUserSignupToWorkspace to tests
+var MapUserSignupToWorkspace = (*WorkspaceReconciler).mapUserSignupToWorkspace
+
+// MapWorkspacesConfigToWorkspace exposes mapWorkspacesConfigToWorkspace to tests
+var MapWorkspacesConfigToWorkspace = (*WorkspaceReconciler).mapWorkspacesConfigToWorkspace
+
+// MapSpaceToWorkspace exposes mapSpaceToWorkspace to tests
+var MapSpaceToWorkspace = (*WorkspaceReconciler).mapSpaceToWorkspace
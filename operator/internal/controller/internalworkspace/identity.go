@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalworkspace
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+// UserSignupIssuer returns the issuer of the identity provider the UserSignup's Sub belongs to,
+// or an empty string if it is not known
+func UserSignupIssuer(u *toolchainv1alpha1.UserSignup) string {
+	return u.GetAnnotations()[workspacesv1alpha1.AnnotationUserSignupIssuer]
+}
+
+// JwtInfoForUserSignup builds the JwtInfo identifying the user the UserSignup belongs to.
+// UserSignups lacking the issuer are assumed to belong to the default issuer.
+func JwtInfoForUserSignup(u *toolchainv1alpha1.UserSignup, identity workspacesv1alpha1.WorkspacesConfigIdentity) workspacesv1alpha1.JwtInfo {
+	return workspacesv1alpha1.JwtInfo{
+		Email:  u.Spec.IdentityClaims.Email,
+		UserId: u.Spec.IdentityClaims.UserID,
+		Sub:    u.Spec.IdentityClaims.Sub,
+		Issuer: identity.IssuerOrDefault(UserSignupIssuer(u)),
+	}
+}
+
+// ListUserSignupsByIdentity lists the UserSignups of the user identified by issuer and Sub.
+// UserSignups lacking the issuer match the users of the default issuer.
+func ListUserSignupsByIdentity(
+	ctx context.Context,
+	c client.Reader,
+	namespace string,
+	identity workspacesv1alpha1.WorkspacesConfigIdentity,
+	issuer, sub string,
+) ([]toolchainv1alpha1.UserSignup, error) {
+	uu := []toolchainv1alpha1.UserSignup{}
+	for _, k := range identity.IdentityKeys(issuer, sub) {
+		l := toolchainv1alpha1.UserSignupList{}
+		if err := c.List(ctx, &l, client.InNamespace(namespace), client.MatchingFields{IndexKeyUserSignupIdentity: k}); err != nil {
+			return nil, err
+		}
+		uu = append(uu, l.Items...)
+	}
+	return uu, nil
+}
+
+// ListInternalWorkspacesByOwner lists the InternalWorkspaces owned by the user identified by issuer and Sub.
+// InternalWorkspaces lacking the owner's issuer match the users of the default issuer.
+func ListInternalWorkspacesByOwner(
+	ctx context.Context,
+	c client.Reader,
+	namespace string,
+	identity workspacesv1alpha1.WorkspacesConfigIdentity,
+	issuer, sub string,
+) ([]workspacesv1alpha1.InternalWorkspace, error) {
+	ww := []workspacesv1alpha1.InternalWorkspace{}
+	for _, k := range identity.IdentityKeys(issuer, sub) {
+		l := workspacesv1alpha1.InternalWorkspaceList{}
+		if err := c.List(ctx, &l, client.InNamespace(namespace), client.MatchingFields{IndexKeyInternalWorkspaceOwnerIdentity: k}); err != nil {
+			return nil, err
+		}
+		ww = append(ww, l.Items...)
+	}
+	return ww, nil
+}
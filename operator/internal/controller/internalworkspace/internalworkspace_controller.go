@@ -21,7 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +40,7 @@ import (
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/config"
 )
 
 // WorkspaceReconciler reconciles a Workspace object
@@ -44,12 +49,30 @@ type WorkspaceReconciler struct {
 	Scheme              *runtime.Scheme
 	KubesawNamespace    string
 	WorkspacesNamespace string
+
+	// OwnerDeletionPolicy the policy applied to InternalWorkspaces whose owner's UserSignup is deleted,
+	// unless overridden by the InternalWorkspace. Defaults to Orphan.
+	OwnerDeletionPolicy workspacesv1alpha1.OwnerDeletionPolicy
+	// OwnerDeletionGracePeriod the time waited before applying the owner deletion policy
+	OwnerDeletionGracePeriod time.Duration
+	// FallbackOwner the compliant username of the user InternalWorkspaces are transferred to
+	// by the Transfer owner deletion policy
+	FallbackOwner string
 }
 
 var (
 	ErrNonTransient = fmt.Errorf("object non reconcilable")
 )
 
+const (
+	// IndexKeyUserSignupIdentity key for UserSignup's indexer on the user's identity key, i.e. issuer and Sub
+	IndexKeyUserSignupIdentity string = "identity"
+	// IndexKeyUserSignupCompliantUsername key for UserSignup's indexer on field for the compliant username
+	IndexKeyUserSignupCompliantUsername string = "status.compliantUsername"
+	// IndexKeyInternalWorkspaceOwnerIdentity key for InternalWorkspace's indexer on the Owner's identity key, i.e. issuer and Sub
+	IndexKeyInternalWorkspaceOwnerIdentity string = "owner.identity"
+)
+
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
@@ -72,30 +95,290 @@ func (r *WorkspaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// clean up backing resources if the InternalWorkspace is being deleted
+	if !w.DeletionTimestamp.IsZero() {
+		if err := r.finalize(ctx, &w); err != nil {
+			l.Error(err, "error cleaning up InternalWorkspace's resources")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(&w, workspacesv1alpha1.FinalizerCleanUp) {
+		if err := r.Update(ctx, &w); err != nil {
+			l.Error(err, "error adding clean up finalizer to InternalWorkspace")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.ensureOwnerIssuerIsSet(ctx, &w); err != nil {
+		l.Error(err, "error setting InternalWorkspace's owner issuer")
+		return ctrl.Result{}, err
+	}
+
 	if err := r.ensureBackendResourcesExists(ctx, &w); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if err := r.ensureWorkspaceVisibilityIsSatisfied(ctx, w); err != nil {
-		l.Error(err, "error ensuring InternalWorkspace Visibility is satisfied")
+	res, stop, err := r.ensureOwnerDeletionIsHandled(ctx, &w)
+	if err != nil {
+		l.Error(err, "error applying InternalWorkspace's owner deletion policy")
+		return ctrl.Result{}, err
+	}
+	if stop {
+		return res, nil
+	}
+
+	if err := r.ensureOwnerSpaceBindingExists(ctx, &w); err != nil {
+		l.Error(err, "error ensuring InternalWorkspace's owner SpaceBinding exists")
 		return ctrl.Result{}, err
 	}
 
+	if err := r.ensureMembersSpaceBindingsExist(ctx, &w); err != nil {
+		l.Error(err, "error ensuring InternalWorkspace's members SpaceBindings exist")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureEditorsRoleBindingExists(ctx, &w); err != nil {
+		l.Error(err, "error ensuring InternalWorkspace's editors RoleBinding exists")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureOwnerTransferIsCompleted(ctx, &w); err != nil {
+		l.Error(err, "error completing InternalWorkspace's owner transfer")
+		return ctrl.Result{}, err
+	}
+
+	verr := r.ensureWorkspaceVisibilityIsSatisfied(ctx, w)
+	setVisibilityAppliedCondition(&w, verr)
+	if err := r.updateStatus(ctx, &w); err != nil {
+		l.Error(err, "error updating InternalWorkspace's status")
+		return ctrl.Result{}, errors.Join(verr, err)
+	}
+	if verr != nil {
+		l.Error(verr, "error ensuring InternalWorkspace Visibility is satisfied")
+		return ctrl.Result{}, verr
+	}
+
 	l.V(6).Info("InternalWorkspace's visibility is satisfied", "visibility", w.Spec.Visibility)
-	return ctrl.Result{}, nil
+	return res, nil
 }
 
-func (r *WorkspaceReconciler) ensureBackendResourcesExists(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
-	// reset ready condition
-	meta.SetStatusCondition(&w.Status.Conditions,
-		metav1.Condition{
-			Type:   workspacesv1alpha1.ConditionTypeReady,
-			Reason: workspacesv1alpha1.ConditionReasonEverythingFine,
-			Status: metav1.ConditionTrue,
+// ensureOwnerIssuerIsSet migrates InternalWorkspaces created before multiple identity providers
+// were supported, setting their owner's issuer to the default one, if configured
+func (r *WorkspaceReconciler) ensureOwnerIssuerIsSet(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	if w.Spec.Owner.JwtInfo.Issuer != "" {
+		return nil
+	}
+
+	c, err := config.Get(ctx, r.Client)
+	if err != nil || c.Identity.DefaultIssuer == "" {
+		return err
+	}
+
+	log.FromContext(ctx).Info("setting owner issuer to the default one", "issuer", c.Identity.DefaultIssuer)
+	w.Spec.Owner.JwtInfo.Issuer = c.Identity.DefaultIssuer
+	return r.Update(ctx, w)
+}
+
+// ensureSpaceIsProvisioned creates the Space backing non-home InternalWorkspaces
+// once their owner is resolved. The Space is placed on the cluster of the owner's home Space,
+// and provisioned with the tier configured in the WorkspacesConfig or, if not set, with the one of the owner's home Space.
+// Home InternalWorkspaces' Spaces are provisioned by KubeSaw.
+func (r *WorkspaceReconciler) ensureSpaceIsProvisioned(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	o := w.Status.Owner.Username
+	if isHomeWorkspace(w) || o == "" {
+		return nil
+	}
+
+	h := toolchainv1alpha1.Space{}
+	if err := r.Get(ctx, types.NamespacedName{Name: o, Namespace: r.KubesawNamespace}, &h); err != nil {
+		if kerrors.IsNotFound(err) {
+			// the InternalWorkspace is enqueued again as soon as the owner's home Space is created
+			log.FromContext(ctx).Info("owner's home Space not found, postponing Space provisioning", "owner", o)
+			return nil
+		}
+		return err
+	}
+
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+
+	s := toolchainv1alpha1.Space{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      w.Name,
+			Namespace: r.KubesawNamespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, &s, func() error {
+		if s.Labels == nil {
+			s.Labels = map[string]string{}
+		}
+		s.Labels[toolchainv1alpha1.OwnerLabelKey] = o
+		if cr, ok := h.Labels[toolchainv1alpha1.SpaceCreatorLabelKey]; ok {
+			s.Labels[toolchainv1alpha1.SpaceCreatorLabelKey] = cr
+		}
+
+		// retargeting and tier changes are left to KubeSaw's administrators
+		if s.Spec.TargetCluster == "" {
+			s.Spec.TargetCluster = h.Spec.TargetCluster
+		}
+		if s.Spec.TierName == "" {
+			s.Spec.TierName = c.Spaces.Tier
+		}
+		if s.Spec.TierName == "" {
+			s.Spec.TierName = h.Spec.TierName
+		}
+		return nil
+	})
+	return err
+}
+
+// ensureOwnerSpaceBindingExists grants the owner admin access to non-home InternalWorkspaces' Spaces.
+// Home InternalWorkspaces' owner SpaceBindings are provisioned by KubeSaw.
+func (r *WorkspaceReconciler) ensureOwnerSpaceBindingExists(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	o := w.Status.Owner.Username
+	if isHomeWorkspace(w) || o == "" {
+		return nil
+	}
+
+	s := toolchainv1alpha1.SpaceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-owner", w.Name),
+			Namespace: r.KubesawNamespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, &s, func() error {
+		if s.Labels == nil {
+			s.Labels = map[string]string{}
+		}
+		s.Labels[toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey] = o
+		s.Labels[toolchainv1alpha1.SpaceBindingSpaceLabelKey] = w.Name
+
+		s.Spec.Space = w.Name
+		s.Spec.MasterUserRecord = o
+		s.Spec.SpaceRole = "admin"
+		return nil
+	})
+	return err
+}
+
+// ensureMembersSpaceBindingsExist grants the InternalWorkspace's members access to its Space,
+// revokes the access of users that are not members anymore, and reports
+// the granted accesses in the InternalWorkspace's status.
+func (r *WorkspaceReconciler) ensureMembersSpaceBindingsExist(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	o := w.Status.Owner.Username
+
+	// create or update the members' SpaceBindings
+	for _, m := range desiredMembers(w) {
+		if m.Username == o {
+			continue
+		}
+
+		s := toolchainv1alpha1.SpaceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      memberSpaceBindingName(w, m.Username),
+				Namespace: r.KubesawNamespace,
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &s, func() error {
+			if s.Labels == nil {
+				s.Labels = map[string]string{}
+			}
+			s.Labels[toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey] = m.Username
+			s.Labels[toolchainv1alpha1.SpaceBindingSpaceLabelKey] = w.Name
+			s.Labels[workspacesv1alpha1.LabelWorkspaceMember] = "true"
+
+			s.Spec.Space = w.Name
+			s.Spec.MasterUserRecord = m.Username
+			s.Spec.SpaceRole = string(m.Role)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// delete the SpaceBindings of users that are not members anymore
+	sbb := toolchainv1alpha1.SpaceBindingList{}
+	if err := r.List(ctx, &sbb,
+		client.InNamespace(r.KubesawNamespace),
+		client.MatchingLabels{toolchainv1alpha1.SpaceBindingSpaceLabelKey: w.Name},
+	); err != nil {
+		return err
+	}
+	mm := []workspacesv1alpha1.InternalWorkspaceMember{}
+	for _, sb := range sbb.Items {
+		if _, ok := sb.Labels[workspacesv1alpha1.LabelWorkspaceMember]; ok && !isDesiredMemberSpaceBinding(w, &sb) {
+			if err := client.IgnoreNotFound(r.Delete(ctx, &sb)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		u := sb.Spec.MasterUserRecord
+		if u == o || u == workspacesv1alpha1.PublicViewerName {
+			continue
+		}
+		mm = append(mm, workspacesv1alpha1.InternalWorkspaceMember{
+			Username: u,
+			Role:     workspacesv1alpha1.InternalWorkspaceRole(sb.Spec.SpaceRole),
+		})
+	}
+
+	// report the granted accesses in the status
+	slices.SortFunc(mm, func(a, b workspacesv1alpha1.InternalWorkspaceMember) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+	if slices.Equal(mm, w.Status.Members) {
+		return nil
+	}
+	w.Status.Members = mm
+	return r.Status().Update(ctx, w)
+}
+
+// ensureOwnerTransferIsCompleted removes the owner transfer annotation once the new owner
+// has been resolved and granted access, so that it can not be used for further owner changes
+func (r *WorkspaceReconciler) ensureOwnerTransferIsCompleted(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	if _, ok := w.Annotations[workspacesv1alpha1.AnnotationOwnerTransfer]; !ok || w.Status.Owner.Username == "" {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("owner transfer completed", "owner", w.Status.Owner.Username)
+	delete(w.Annotations, workspacesv1alpha1.AnnotationOwnerTransfer)
+	return r.Update(ctx, w)
+}
+
+// memberSpaceBindingName returns the name of the SpaceBinding granting `username` access to the InternalWorkspace
+func memberSpaceBindingName(w *workspacesv1alpha1.InternalWorkspace, username string) string {
+	return fmt.Sprintf("%s-member-%s", w.Name, username)
+}
+
+// desiredMembers returns the members to grant access to the InternalWorkspace.
+// Archived InternalWorkspaces are not shared with anyone.
+func desiredMembers(w *workspacesv1alpha1.InternalWorkspace) []workspacesv1alpha1.InternalWorkspaceMember {
+	if isArchived(w) {
+		return nil
+	}
+	return w.Spec.Members
+}
+
+// isDesiredMemberSpaceBinding checks whether `sb` grants access to one of the InternalWorkspace's members
+func isDesiredMemberSpaceBinding(w *workspacesv1alpha1.InternalWorkspace, sb *toolchainv1alpha1.SpaceBinding) bool {
+	u := sb.Spec.MasterUserRecord
+	return u != w.Status.Owner.Username &&
+		sb.Name == memberSpaceBindingName(w, u) &&
+		slices.ContainsFunc(desiredMembers(w), func(m workspacesv1alpha1.InternalWorkspaceMember) bool {
+			return m.Username == u
 		})
+}
 
+func (r *WorkspaceReconciler) ensureBackendResourcesExists(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
 	// run checks
 	oerr := r.ensureWorkspaceOwnerExists(ctx, w)
+	// provision the Space once the owner is resolved, so that it is checked right away
+	perr := r.ensureSpaceIsProvisioned(ctx, w)
 	uerr := r.ensureSpaceExists(ctx, w)
 
 	// if all checks failed, return the errors
@@ -104,10 +387,80 @@ func (r *WorkspaceReconciler) ensureBackendResourcesExists(ctx context.Context,
 	}
 
 	// if at least one check was successful, update the status
-	err := r.Status().Update(ctx, w)
+	err := r.updateStatus(ctx, w)
 
 	// return joined errors
-	return errors.Join(err, oerr, uerr)
+	return errors.Join(err, oerr, perr, uerr)
+}
+
+// updateStatus rolls the checks' conditions up into the Ready condition,
+// records the observed generation, and updates the InternalWorkspace's status
+func (r *WorkspaceReconciler) updateStatus(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	setReadyCondition(w)
+	w.Status.ObservedGeneration = w.Generation
+	return r.Status().Update(ctx, w)
+}
+
+// readinessConditionTypes are the conditions rolled up into the Ready condition,
+// in order of precedence
+var readinessConditionTypes = []string{
+	workspacesv1alpha1.ConditionTypeOwnerResolved,
+	workspacesv1alpha1.ConditionTypeSpaceProvisioned,
+	workspacesv1alpha1.ConditionTypeVisibilityApplied,
+}
+
+// setReadyCondition sets the Ready condition to False if any of the readiness conditions is False,
+// to Unknown if any of them was not evaluated yet, and to True otherwise.
+// When the Ready condition is False, its reason and message are copied from the first failing condition.
+func setReadyCondition(w *workspacesv1alpha1.InternalWorkspace) {
+	ready := metav1.Condition{
+		Type:   workspacesv1alpha1.ConditionTypeReady,
+		Reason: workspacesv1alpha1.ConditionReasonEverythingFine,
+		Status: metav1.ConditionTrue,
+	}
+	for _, t := range readinessConditionTypes {
+		c := meta.FindStatusCondition(w.Status.Conditions, t)
+		if c != nil && c.Status == metav1.ConditionFalse {
+			ready.Status = metav1.ConditionFalse
+			ready.Reason = c.Reason
+			ready.Message = c.Message
+			break
+		}
+		if (c == nil || c.Status != metav1.ConditionTrue) && ready.Status == metav1.ConditionTrue {
+			ready.Status = metav1.ConditionUnknown
+			ready.Reason = workspacesv1alpha1.ConditionReasonReconciling
+			ready.Message = fmt.Sprintf("condition %s not evaluated yet", t)
+		}
+	}
+
+	setCondition(w, ready)
+}
+
+// setVisibilityAppliedCondition sets the VisibilityApplied condition
+// according to the outcome of ensureWorkspaceVisibilityIsSatisfied
+func setVisibilityAppliedCondition(w *workspacesv1alpha1.InternalWorkspace, err error) {
+	if err != nil {
+		setCondition(w, metav1.Condition{
+			Type:    workspacesv1alpha1.ConditionTypeVisibilityApplied,
+			Reason:  workspacesv1alpha1.ConditionReasonVisibilityNotSatisfied,
+			Status:  metav1.ConditionFalse,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	setCondition(w, metav1.Condition{
+		Type:   workspacesv1alpha1.ConditionTypeVisibilityApplied,
+		Reason: workspacesv1alpha1.ConditionReasonVisibilitySatisfied,
+		Status: metav1.ConditionTrue,
+	})
+}
+
+// setCondition sets the condition on the InternalWorkspace's status,
+// stamping it with the InternalWorkspace's generation
+func setCondition(w *workspacesv1alpha1.InternalWorkspace, c metav1.Condition) {
+	c.ObservedGeneration = w.Generation
+	meta.SetStatusCondition(&w.Status.Conditions, c)
 }
 
 func (r *WorkspaceReconciler) ensureSpaceExists(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
@@ -116,24 +469,27 @@ func (r *WorkspaceReconciler) ensureSpaceExists(ctx context.Context, w *workspac
 
 	err := r.Get(ctx, k, s)
 	switch {
-	// if the space exists, update the target cluster value
+	// if the space exists, mirror its provisioning state
 	case err == nil:
 		w.Status.Space.TargetCluster = s.Status.TargetCluster
+		w.Status.Space.Tier = s.Spec.TierName
+		w.Status.Space.Phase, w.Status.Space.Message = spacePhase(s)
+		w.Status.Space.Namespaces = spaceNamespaces(s)
+		setCondition(w, spaceProvisionedCondition(w))
 		return nil
 
-	// if the space does not exist, remove the target cluster value
+	// if the space does not exist, remove its provisioning state
 	case kerrors.IsNotFound(err):
 		w.Status.Space.TargetCluster = ""
-		// set Ready condition to false if it's true
-		if meta.IsStatusConditionTrue(w.Status.Conditions, workspacesv1alpha1.ConditionTypeReady) {
-			meta.SetStatusCondition(&w.Status.Conditions,
-				metav1.Condition{
-					Type:    workspacesv1alpha1.ConditionTypeReady,
-					Reason:  workspacesv1alpha1.ConditionReasonSpaceNotFound,
-					Status:  metav1.ConditionFalse,
-					Message: fmt.Sprintf("Space %s not found", w.Name),
-				})
-		}
+		w.Status.Space.Tier = ""
+		w.Status.Space.Phase, w.Status.Space.Message = "", ""
+		w.Status.Space.Namespaces = nil
+		setCondition(w, metav1.Condition{
+			Type:    workspacesv1alpha1.ConditionTypeSpaceProvisioned,
+			Reason:  workspacesv1alpha1.ConditionReasonSpaceNotFound,
+			Status:  metav1.ConditionFalse,
+			Message: fmt.Sprintf("Space %s not found", w.Name),
+		})
 		return nil
 
 	// if any other error occurred, forward it
@@ -142,36 +498,119 @@ func (r *WorkspaceReconciler) ensureSpaceExists(ctx context.Context, w *workspac
 	}
 }
 
+// spacePhase derives the provisioning phase of the Space from its Ready condition.
+// The returned message is set only if the provisioning failed.
+func spacePhase(s *toolchainv1alpha1.Space) (workspacesv1alpha1.SpacePhase, string) {
+	i := slices.IndexFunc(s.Status.Conditions, func(c toolchainv1alpha1.Condition) bool {
+		return c.Type == toolchainv1alpha1.ConditionReady
+	})
+	if i == -1 {
+		return workspacesv1alpha1.SpacePhaseProvisioning, ""
+	}
+
+	c := s.Status.Conditions[i]
+	switch {
+	case c.Status == corev1.ConditionTrue:
+		return workspacesv1alpha1.SpacePhaseProvisioned, ""
+	case c.Reason == toolchainv1alpha1.SpaceTerminatingReason:
+		return workspacesv1alpha1.SpacePhaseTerminating, ""
+	case c.Status == corev1.ConditionUnknown,
+		c.Reason == toolchainv1alpha1.SpaceProvisioningReason,
+		c.Reason == toolchainv1alpha1.SpaceProvisioningPendingReason,
+		c.Reason == toolchainv1alpha1.SpaceUpdatingReason,
+		c.Reason == toolchainv1alpha1.SpaceRetargetingReason:
+		return workspacesv1alpha1.SpacePhaseProvisioning, ""
+	default:
+		return workspacesv1alpha1.SpacePhaseProvisioningFailed, c.Message
+	}
+}
+
+// spaceNamespaces returns the namespaces provisioned for the Space
+func spaceNamespaces(s *toolchainv1alpha1.Space) []workspacesv1alpha1.SpaceNamespace {
+	if len(s.Status.ProvisionedNamespaces) == 0 {
+		return nil
+	}
+
+	nn := make([]workspacesv1alpha1.SpaceNamespace, len(s.Status.ProvisionedNamespaces))
+	for i, n := range s.Status.ProvisionedNamespaces {
+		nn[i] = workspacesv1alpha1.SpaceNamespace{Name: n.Name, Type: n.Type}
+	}
+	return nn
+}
+
+// spaceProvisionedCondition builds the SpaceProvisioned condition from the Space's provisioning phase
+func spaceProvisionedCondition(w *workspacesv1alpha1.InternalWorkspace) metav1.Condition {
+	c := metav1.Condition{
+		Type:   workspacesv1alpha1.ConditionTypeSpaceProvisioned,
+		Status: metav1.ConditionFalse,
+	}
+
+	switch w.Status.Space.Phase {
+	case workspacesv1alpha1.SpacePhaseProvisioned:
+		c.Status = metav1.ConditionTrue
+		c.Reason = workspacesv1alpha1.ConditionReasonSpaceReady
+	case workspacesv1alpha1.SpacePhaseTerminating:
+		c.Reason = workspacesv1alpha1.ConditionReasonSpaceTerminating
+		c.Message = fmt.Sprintf("Space %s is being deleted", w.Status.Space.Name)
+	case workspacesv1alpha1.SpacePhaseProvisioningFailed:
+		c.Reason = workspacesv1alpha1.ConditionReasonSpaceProvisioningFailed
+		c.Message = w.Status.Space.Message
+	default:
+		c.Reason = workspacesv1alpha1.ConditionReasonSpaceProvisioning
+		c.Message = fmt.Sprintf("Space %s is being provisioned", w.Status.Space.Name)
+	}
+	return c
+}
+
+// ensureWorkspaceOwnerExists looks up the owner's UserSignup by issuer and Sub
 func (r *WorkspaceReconciler) ensureWorkspaceOwnerExists(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
-	uu := toolchainv1alpha1.UserSignupList{}
-	if err := r.List(ctx, &uu, client.InNamespace(r.KubesawNamespace)); err != nil {
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+	uu, err := ListUserSignupsByIdentity(ctx, r.Client, r.KubesawNamespace, c.Identity, w.Spec.Owner.JwtInfo.Issuer, w.Spec.Owner.JwtInfo.Sub)
+	if err != nil {
 		return err
 	}
 
 	// set Space information
 	w.Status.Space = workspacesv1alpha1.SpaceInfo{
-		IsHome: w.Spec.DisplayName == "default",
+		IsHome: isHomeWorkspace(w),
 		Name:   w.Name,
 	}
 
 	// set Owner information
 	w.Status.Owner = workspacesv1alpha1.UserInfoStatus{}
-	i := slices.IndexFunc(uu.Items, func(u toolchainv1alpha1.UserSignup) bool {
-		return u.Spec.IdentityClaims.Sub == w.Spec.Owner.JwtInfo.Sub
-	})
-	switch i {
-	case -1:
-		log.FromContext(ctx).Info("UserSignup not found by Sub", "sub", w.Spec.Owner.JwtInfo.Sub)
-		meta.SetStatusCondition(&w.Status.Conditions,
-			metav1.Condition{
-				Type:    workspacesv1alpha1.ConditionTypeReady,
-				Reason:  workspacesv1alpha1.ConditionReasonOwnerNotFound,
-				Status:  metav1.ConditionFalse,
-				Message: fmt.Sprintf("UserSignup with Sub %s not found", w.Spec.Owner.JwtInfo.Sub),
-			})
-	default:
+	switch len(uu) {
+	case 0:
+		log.FromContext(ctx).Info("UserSignup not found by identity", "issuer", w.Spec.Owner.JwtInfo.Issuer, "sub", w.Spec.Owner.JwtInfo.Sub)
+		setCondition(w, metav1.Condition{
+			Type:    workspacesv1alpha1.ConditionTypeOwnerResolved,
+			Reason:  workspacesv1alpha1.ConditionReasonOwnerNotFound,
+			Status:  metav1.ConditionFalse,
+			Message: fmt.Sprintf("UserSignup with Sub %s not found", w.Spec.Owner.JwtInfo.Sub),
+		})
+	case 1:
 		log.FromContext(ctx).Info("user signup found", "sub", w.Spec.Owner.JwtInfo.Sub)
-		w.Status.Owner.Username = uu.Items[i].Status.CompliantUsername
+		w.Status.Owner.Username = uu[0].Status.CompliantUsername
+		w.Status.Owner.Email = uu[0].Spec.IdentityClaims.Email
+		setCondition(w, metav1.Condition{
+			Type:   workspacesv1alpha1.ConditionTypeOwnerResolved,
+			Reason: workspacesv1alpha1.ConditionReasonOwnerFound,
+			Status: metav1.ConditionTrue,
+		})
+	default:
+		nn := make([]string, len(uu))
+		for i, u := range uu {
+			nn[i] = u.Name
+		}
+		log.FromContext(ctx).Info("more than one UserSignup found by identity", "issuer", w.Spec.Owner.JwtInfo.Issuer, "sub", w.Spec.Owner.JwtInfo.Sub, "usersignups", nn)
+		setCondition(w, metav1.Condition{
+			Type:    workspacesv1alpha1.ConditionTypeOwnerResolved,
+			Reason:  workspacesv1alpha1.ConditionReasonOwnerAmbiguous,
+			Status:  metav1.ConditionFalse,
+			Message: fmt.Sprintf("UserSignups %s match Sub %s", strings.Join(nn, ", "), w.Spec.Owner.JwtInfo.Sub),
+		})
 	}
 
 	return nil
@@ -183,7 +622,7 @@ func (r *WorkspaceReconciler) ensureWorkspaceVisibilityIsSatisfied(ctx context.C
 			Name:      fmt.Sprintf("%s-community", w.Name),
 			Namespace: r.KubesawNamespace,
 			Labels: map[string]string{
-				toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: toolchainv1alpha1.KubesawAuthenticatedUsername,
+				toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: workspacesv1alpha1.PublicViewerName,
 				toolchainv1alpha1.SpaceBindingSpaceLabelKey:            w.Name,
 			},
 		},
@@ -195,13 +634,25 @@ func (r *WorkspaceReconciler) ensureWorkspaceVisibilityIsSatisfied(ctx context.C
 		"space-binding-namespace", s.Namespace,
 	)
 
-	switch w.Spec.Visibility {
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+
+	// archived InternalWorkspaces are not visible to the community,
+	// nor are any when the community visibility is disabled
+	v := w.Spec.Visibility
+	if isArchived(&w) || !c.Features.IsCommunityVisibilityEnabled() {
+		v = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+	}
+
+	switch v {
 	case workspacesv1alpha1.InternalWorkspaceVisibilityCommunity:
 		l.Info("ensuring spacebinding exists")
 		_, err := controllerutil.CreateOrUpdate(ctx, r.Client, &s, func() error {
 			s.Spec.Space = w.Name
 			s.Spec.MasterUserRecord = workspacesv1alpha1.PublicViewerName
-			s.Spec.SpaceRole = "viewer"
+			s.Spec.SpaceRole = c.Community.Role
 			return nil
 		})
 		return err
@@ -213,23 +664,126 @@ func (r *WorkspaceReconciler) ensureWorkspaceVisibilityIsSatisfied(ctx context.C
 	}
 }
 
+// finalize deletes the Space and the SpaceBindings backing the InternalWorkspace
+// and then removes the clean up finalizer.
+// Home InternalWorkspaces' Spaces and the SpaceBindings not created by the operator,
+// like the owner's one, are managed by KubeSaw, so they are not deleted.
+func (r *WorkspaceReconciler) finalize(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	if !controllerutil.ContainsFinalizer(w, workspacesv1alpha1.FinalizerCleanUp) {
+		return nil
+	}
+
+	// delete the community and the sharing SpaceBindings
+	sbb := toolchainv1alpha1.SpaceBindingList{}
+	if err := r.List(ctx, &sbb,
+		client.InNamespace(r.KubesawNamespace),
+		client.MatchingLabels{toolchainv1alpha1.SpaceBindingSpaceLabelKey: w.Name},
+	); err != nil {
+		return err
+	}
+	for _, sb := range sbb.Items {
+		if isHomeWorkspace(w) && !isManagedSpaceBinding(w, &sb) {
+			continue
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, &sb)); err != nil {
+			return err
+		}
+	}
+
+	// delete the Space
+	if !isHomeWorkspace(w) {
+		s := toolchainv1alpha1.Space{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      w.Name,
+				Namespace: r.KubesawNamespace,
+			},
+		}
+		if err := client.IgnoreNotFound(r.Delete(ctx, &s)); err != nil {
+			return err
+		}
+	}
+
+	// remove the finalizer
+	controllerutil.RemoveFinalizer(w, workspacesv1alpha1.FinalizerCleanUp)
+	return client.IgnoreNotFound(r.Update(ctx, w))
+}
+
+// isManagedSpaceBinding returns true if the SpaceBinding was created by the operator for the InternalWorkspace,
+// i.e. it is the owner's or the community one, or a member's one
+func isManagedSpaceBinding(w *workspacesv1alpha1.InternalWorkspace, sb *toolchainv1alpha1.SpaceBinding) bool {
+	if _, ok := sb.Labels[workspacesv1alpha1.LabelWorkspaceMember]; ok {
+		return true
+	}
+	return sb.Name == fmt.Sprintf("%s-owner", w.Name) || sb.Name == fmt.Sprintf("%s-community", w.Name)
+}
+
+func isHomeWorkspace(w *workspacesv1alpha1.InternalWorkspace) bool {
+	return w.Spec.DisplayName == workspacesv1alpha1.DisplayNameDefaultWorkspace
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(), &toolchainv1alpha1.UserSignup{}, IndexKeyUserSignupIdentity, UserSignupIdentityIndexer,
+	); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(), &toolchainv1alpha1.UserSignup{}, IndexKeyUserSignupCompliantUsername, UserSignupCompliantUsernameIndexer,
+	); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(
+		context.Background(), &workspacesv1alpha1.InternalWorkspace{}, IndexKeyInternalWorkspaceOwnerIdentity, InternalWorkspaceOwnerIdentityIndexer,
+	); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&workspacesv1alpha1.InternalWorkspace{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
 		Watches(&toolchainv1alpha1.Space{}, handler.EnqueueRequestsFromMapFunc(r.mapSpaceToWorkspace)).
 		Watches(&toolchainv1alpha1.SpaceBinding{}, handler.EnqueueRequestsFromMapFunc(r.mapSpaceBindingToWorkspace)).
 		Watches(&toolchainv1alpha1.UserSignup{}, handler.EnqueueRequestsFromMapFunc(r.mapUserSignupToWorkspace)).
+		Watches(&workspacesv1alpha1.WorkspacesConfig{}, handler.EnqueueRequestsFromMapFunc(r.mapWorkspacesConfigToWorkspace)).
 		Complete(r)
 }
 
+// UserSignupIdentityIndexer indexes UserSignups by the user's identity key
+func UserSignupIdentityIndexer(o client.Object) []string {
+	u, ok := o.(*toolchainv1alpha1.UserSignup)
+	if !ok {
+		return nil
+	}
+	return []string{workspacesv1alpha1.IdentityKey(UserSignupIssuer(u), u.Spec.IdentityClaims.Sub)}
+}
+
+// UserSignupCompliantUsernameIndexer indexes UserSignups by their compliant username
+func UserSignupCompliantUsernameIndexer(o client.Object) []string {
+	u, ok := o.(*toolchainv1alpha1.UserSignup)
+	if !ok {
+		return nil
+	}
+	return []string{u.Status.CompliantUsername}
+}
+
+// InternalWorkspaceOwnerIdentityIndexer indexes InternalWorkspaces by their Owner's identity key
+func InternalWorkspaceOwnerIdentityIndexer(o client.Object) []string {
+	w, ok := o.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return nil
+	}
+	return []string{w.Spec.Owner.JwtInfo.IdentityKey()}
+}
+
 func (r *WorkspaceReconciler) mapSpaceToWorkspace(ctx context.Context, o client.Object) []reconcile.Request {
 	s, ok := o.(*toolchainv1alpha1.Space)
 	if !ok {
 		return nil
 	}
 
-	return []reconcile.Request{
+	rr := []reconcile.Request{
 		{
 			NamespacedName: types.NamespacedName{
 				Name:      s.Name,
@@ -237,6 +791,18 @@ func (r *WorkspaceReconciler) mapSpaceToWorkspace(ctx context.Context, o client.
 			},
 		},
 	}
+
+	// the owner's home Space is required for provisioning the Spaces of their other InternalWorkspaces,
+	// so all the InternalWorkspaces owned by the user the Space is the home of are enqueued too
+	uu := toolchainv1alpha1.UserSignupList{}
+	if err := r.List(ctx, &uu, client.InNamespace(r.KubesawNamespace), client.MatchingFields{IndexKeyUserSignupCompliantUsername: s.Name}); err != nil {
+		log.FromContext(ctx).Error(err, "error listing UserSignups by compliant username", "space", s.Name)
+		return rr
+	}
+	for _, u := range uu.Items {
+		rr = append(rr, r.mapUserSignupToWorkspace(ctx, &u)...)
+	}
+	return rr
 }
 
 func (r *WorkspaceReconciler) mapSpaceBindingToWorkspace(ctx context.Context, o client.Object) []reconcile.Request {
@@ -260,18 +826,47 @@ func (r *WorkspaceReconciler) mapSpaceBindingToWorkspace(ctx context.Context, o
 	}
 }
 
+// mapUserSignupToWorkspace enqueues all the InternalWorkspaces owned by the UserSignup's identity
 func (r *WorkspaceReconciler) mapUserSignupToWorkspace(ctx context.Context, o client.Object) []reconcile.Request {
 	u, ok := o.(*toolchainv1alpha1.UserSignup)
-	if !ok || u.Status.CompliantUsername == "" {
+	if !ok || u.Spec.IdentityClaims.Sub == "" {
 		return nil
 	}
 
-	return []reconcile.Request{
-		{
-			NamespacedName: types.NamespacedName{
-				Name:      u.Status.CompliantUsername,
-				Namespace: r.WorkspacesNamespace,
-			},
-		},
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error retrieving WorkspacesConfig")
+		return nil
+	}
+	ww, err := ListInternalWorkspacesByOwner(ctx, r.Client, r.WorkspacesNamespace, c.Identity, UserSignupIssuer(u), u.Spec.IdentityClaims.Sub)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error listing InternalWorkspaces owned by UserSignup", "usersignup", u.Name)
+		return nil
+	}
+
+	rr := make([]reconcile.Request, len(ww))
+	for i, w := range ww {
+		rr[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&w)}
+	}
+	return rr
+}
+
+// mapWorkspacesConfigToWorkspace enqueues all the InternalWorkspaces,
+// so that the changes of the WorkspacesConfig are applied to them
+func (r *WorkspaceReconciler) mapWorkspacesConfigToWorkspace(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetName() != workspacesv1alpha1.WorkspacesConfigName {
+		return nil
+	}
+
+	ww := workspacesv1alpha1.InternalWorkspaceList{}
+	if err := r.List(ctx, &ww, client.InNamespace(r.WorkspacesNamespace)); err != nil {
+		log.FromContext(ctx).Error(err, "error listing InternalWorkspaces")
+		return nil
+	}
+
+	rr := make([]reconcile.Request, len(ww.Items))
+	for i, w := range ww.Items {
+		rr[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&w)}
 	}
+	return rr
 }
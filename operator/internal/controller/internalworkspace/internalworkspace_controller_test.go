@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	kcorev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	corev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -45,6 +50,8 @@ var _ = Describe("InternalWorkspaceController", func() {
 			Scheme:              scheme,
 			KubesawNamespace:    kubesawNamespace,
 			WorkspacesNamespace: workspacesNamespace,
+
+			OwnerDeletionGracePeriod: internalworkspace.DefaultOwnerDeletionGracePeriod,
 		}
 	}
 
@@ -54,8 +61,13 @@ var _ = Describe("InternalWorkspaceController", func() {
 		scheme = runtime.NewScheme()
 		Expect(workspacesv1alpha1.AddToScheme(scheme)).To(Succeed())
 		Expect(toolchainv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(rbacv1.AddToScheme(scheme)).To(Succeed())
 
-		clientBuilder = fake.NewClientBuilder().WithScheme(scheme)
+		clientBuilder = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithIndex(&toolchainv1alpha1.UserSignup{}, internalworkspace.IndexKeyUserSignupIdentity, internalworkspace.UserSignupIdentityIndexer).
+			WithIndex(&toolchainv1alpha1.UserSignup{}, internalworkspace.IndexKeyUserSignupCompliantUsername, internalworkspace.UserSignupCompliantUsernameIndexer).
+			WithIndex(&workspacesv1alpha1.InternalWorkspace{}, internalworkspace.IndexKeyInternalWorkspaceOwnerIdentity, internalworkspace.InternalWorkspaceOwnerIdentityIndexer)
 
 		owner = toolchainv1alpha1.UserSignup{
 			ObjectMeta: corev1.ObjectMeta{
@@ -65,7 +77,8 @@ var _ = Describe("InternalWorkspaceController", func() {
 			Spec: toolchainv1alpha1.UserSignupSpec{
 				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
 					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
-						Sub: ownerSub,
+						Sub:   ownerSub,
+						Email: "owner@new-email.com",
 					},
 				},
 			},
@@ -79,7 +92,8 @@ var _ = Describe("InternalWorkspaceController", func() {
 				Name:      workspaceName,
 			},
 			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
-				Visibility: workspacesv1alpha1.InternalWorkspaceVisibilityCommunity,
+				DisplayName: workspacesv1alpha1.DisplayNameDefaultWorkspace,
+				Visibility:  workspacesv1alpha1.InternalWorkspaceVisibilityCommunity,
 				Owner: workspacesv1alpha1.UserInfo{
 					JwtInfo: workspacesv1alpha1.JwtInfo{
 						Sub:    ownerSub,
@@ -94,8 +108,21 @@ var _ = Describe("InternalWorkspaceController", func() {
 				Name:      workspace.Name,
 				Namespace: kubesawNamespace,
 			},
+			Spec: toolchainv1alpha1.SpaceSpec{
+				TierName: "appstudio",
+			},
 			Status: toolchainv1alpha1.SpaceStatus{
 				TargetCluster: "target-cluster",
+				ProvisionedNamespaces: []toolchainv1alpha1.SpaceNamespace{
+					{Name: "owner-tenant", Type: "default"},
+				},
+				Conditions: []toolchainv1alpha1.Condition{
+					{
+						Type:   toolchainv1alpha1.ConditionReady,
+						Status: kcorev1.ConditionTrue,
+						Reason: toolchainv1alpha1.SpaceProvisionedReason,
+					},
+				},
 			},
 		}
 	})
@@ -132,7 +159,7 @@ var _ = Describe("InternalWorkspaceController", func() {
 				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
 
 				// then
-				Expect(res).To(BeZero())
+				Expect(res.RequeueAfter).To(BeNumerically("~", internalworkspace.DefaultOwnerDeletionGracePeriod, time.Minute))
 				Expect(err).NotTo(HaveOccurred())
 
 				w := workspacesv1alpha1.InternalWorkspace{}
@@ -143,6 +170,62 @@ var _ = Describe("InternalWorkspaceController", func() {
 				Expect(c.Status).To(Equal(metav1.ConditionFalse))
 				Expect(c.Reason).To(Equal(workspacesv1alpha1.ConditionReasonOwnerNotFound))
 			})
+
+			It("reports both failing checks in their own conditions", func() {
+				// given
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+
+				o := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeOwnerResolved)
+				Expect(o).NotTo(BeNil())
+				Expect(o.Status).To(Equal(metav1.ConditionFalse))
+				Expect(o.Reason).To(Equal(workspacesv1alpha1.ConditionReasonOwnerNotFound))
+
+				s := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeSpaceProvisioned)
+				Expect(s).NotTo(BeNil())
+				Expect(s.Status).To(Equal(metav1.ConditionFalse))
+				Expect(s.Reason).To(Equal(workspacesv1alpha1.ConditionReasonSpaceNotFound))
+			})
+		})
+
+		When("more than one UserSignup matches the Owner", func() {
+			BeforeEach(func() {
+				duplicate := *owner.DeepCopy()
+				duplicate.Name = "duplicate-owner"
+				duplicate.Status.CompliantUsername = "duplicate-owner"
+				clientBuilder = clientBuilder.WithObjects(&owner, &duplicate, &space)
+			})
+
+			It("does not resolve the owner", func() {
+				// given
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Owner.Username).To(BeEmpty())
+
+				o := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeOwnerResolved)
+				Expect(o).NotTo(BeNil())
+				Expect(o.Status).To(Equal(metav1.ConditionFalse))
+				Expect(o.Reason).To(Equal(workspacesv1alpha1.ConditionReasonOwnerAmbiguous))
+				Expect(o.Message).To(And(ContainSubstring(owner.Name), ContainSubstring("duplicate-owner")))
+			})
 		})
 
 		When("Retrieval of Owner's UserSignup and Space fail", Label("none"), func() {
@@ -181,7 +264,8 @@ var _ = Describe("InternalWorkspaceController", func() {
 				w := workspacesv1alpha1.InternalWorkspace{}
 				err = r.Get(ctx, key, &w)
 				Expect(err).ToNot(HaveOccurred())
-				Expect(w).To(BeEquivalentTo(workspace))
+				Expect(w.Spec).To(BeEquivalentTo(workspace.Spec))
+				Expect(w.Status).To(BeEquivalentTo(workspace.Status))
 			})
 		})
 
@@ -214,7 +298,8 @@ var _ = Describe("InternalWorkspaceController", func() {
 				w := workspacesv1alpha1.InternalWorkspace{}
 				err = r.Get(ctx, key, &w)
 				Expect(err).ToNot(HaveOccurred())
-				Expect(w).To(BeEquivalentTo(workspace))
+				Expect(w.Spec).To(BeEquivalentTo(workspace.Spec))
+				Expect(w.Status).To(BeEquivalentTo(workspace.Status))
 			})
 		})
 
@@ -300,7 +385,7 @@ var _ = Describe("InternalWorkspaceController", func() {
 				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
 
 				// then
-				Expect(res).To(BeZero())
+				Expect(res.RequeueAfter).To(BeNumerically("~", internalworkspace.DefaultOwnerDeletionGracePeriod, time.Minute))
 				Expect(err).NotTo(HaveOccurred())
 
 				w := workspacesv1alpha1.InternalWorkspace{}
@@ -365,28 +450,315 @@ var _ = Describe("InternalWorkspaceController", func() {
 					return meta.IsStatusConditionTrue(cc, workspacesv1alpha1.ConditionTypeReady)
 				}))
 			})
+
+			It("sets every readiness condition to True and records the observed generation", func() {
+				// given
+				workspace.Generation = 3
+				key := client.ObjectKeyFromObject(&workspace)
+				r = buildReconciler()
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.ObservedGeneration).To(Equal(int64(3)))
+				Expect(w.Status.Owner.Username).To(Equal(owner.Status.CompliantUsername))
+				Expect(w.Status.Owner.Email).To(Equal(owner.Spec.IdentityClaims.Email))
+				for _, t := range []string{
+					workspacesv1alpha1.ConditionTypeOwnerResolved,
+					workspacesv1alpha1.ConditionTypeSpaceProvisioned,
+					workspacesv1alpha1.ConditionTypeVisibilityApplied,
+					workspacesv1alpha1.ConditionTypeReady,
+				} {
+					c := meta.FindStatusCondition(w.Status.Conditions, t)
+					Expect(c).NotTo(BeNil(), "condition %s", t)
+					Expect(c.Status).To(Equal(metav1.ConditionTrue), "condition %s", t)
+					Expect(c.ObservedGeneration).To(Equal(int64(3)), "condition %s", t)
+				}
+			})
 		})
 
-		Context("community SpaceBinding management", func() {
-			communitySpaceBinding := toolchainv1alpha1.SpaceBinding{
+		When("the Owner's UserSignup belongs to another issuer", func() {
+			BeforeEach(func() {
+				owner.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: "https://other-issuer"}
+				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+			})
+
+			It("does not resolve the owner", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+				r = buildReconciler()
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(meta.IsStatusConditionFalse(w.Status.Conditions, workspacesv1alpha1.ConditionTypeOwnerResolved)).To(BeTrue())
+			})
+		})
+
+		When("the Workspace's owner lacks the issuer and a default issuer is configured", func() {
+			defaultIssuer := "https://default-issuer"
+
+			BeforeEach(func() {
+				c := workspacesv1alpha1.WorkspacesConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+					Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+						Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: defaultIssuer},
+					},
+				}
+				owner.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: defaultIssuer}
+				clientBuilder = clientBuilder.WithObjects(&owner, &space, &c)
+			})
+
+			It("sets the default issuer and resolves the owner", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+				r = buildReconciler()
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Spec.Owner.JwtInfo.Issuer).To(Equal(defaultIssuer))
+				Expect(meta.IsStatusConditionTrue(w.Status.Conditions, workspacesv1alpha1.ConditionTypeOwnerResolved)).To(BeTrue())
+			})
+
+			It("is enqueued by the changes of the owner's UserSignup", func() {
+				// given
+				r = buildReconciler()
+
+				// when
+				rr := internalworkspace.MapUserSignupToWorkspace(&r, ctx, &owner)
+
+				// then
+				Expect(rr).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&workspace)}))
+			})
+		})
+
+		When("the Owner's UserSignup changes", func() {
+			var owned workspacesv1alpha1.InternalWorkspace
+			var other workspacesv1alpha1.InternalWorkspace
+
+			BeforeEach(func() {
+				owned = *workspace.DeepCopy()
+				owned.Name = "owned-workspace"
+				owned.Spec.DisplayName = "owned"
+
+				other = *workspace.DeepCopy()
+				other.Name = "other-workspace"
+				other.Spec.Owner.JwtInfo.Sub = "other-sub"
+
+				clientBuilder = clientBuilder.WithObjects(&owned, &other)
+			})
+
+			It("enqueues all the InternalWorkspaces owned by the identity", func() {
+				// given
+				r = buildReconciler()
+
+				// when
+				rr := internalworkspace.MapUserSignupToWorkspace(&r, ctx, &owner)
+
+				// then
+				Expect(rr).To(ConsistOf(
+					ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&workspace)},
+					ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&owned)},
+				))
+			})
+
+			It("enqueues the owned InternalWorkspaces even if the UserSignup is not approved yet", func() {
+				// given
+				owner.Status.CompliantUsername = ""
+				r = buildReconciler()
+
+				// when
+				rr := internalworkspace.MapUserSignupToWorkspace(&r, ctx, &owner)
+
+				// then
+				Expect(rr).To(HaveLen(2))
+			})
+
+			It("does not enqueue the InternalWorkspaces owned by the same sub from another issuer", func() {
+				// given
+				c := workspacesv1alpha1.WorkspacesConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+					Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+						Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+					},
+				}
+				clientBuilder = clientBuilder.WithObjects(&c)
+				owner.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: "https://other-issuer"}
+				r = buildReconciler()
+
+				// when
+				rr := internalworkspace.MapUserSignupToWorkspace(&r, ctx, &owner)
+
+				// then
+				Expect(rr).To(BeEmpty())
+			})
+
+			It("enqueues the InternalWorkspaces lacking the issuer if no default issuer is configured", func() {
+				// given
+				owner.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: "https://other-issuer"}
+				r = buildReconciler()
+
+				// when
+				rr := internalworkspace.MapUserSignupToWorkspace(&r, ctx, &owner)
+
+				// then
+				Expect(rr).To(HaveLen(2))
+			})
+		})
+
+		DescribeTable("the Space provisioning state is mirrored",
+			func(cc []toolchainv1alpha1.Condition, expectedPhase workspacesv1alpha1.SpacePhase, expectedMessage string, expectedCondition metav1.Condition) {
+				// given
+				space.Status.Conditions = cc
+				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+				key := client.ObjectKeyFromObject(&workspace)
+				r = buildReconciler()
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Space.Tier).To(Equal(space.Spec.TierName))
+				Expect(w.Status.Space.Namespaces).To(Equal([]workspacesv1alpha1.SpaceNamespace{
+					{Name: "owner-tenant", Type: "default"},
+				}))
+				Expect(w.Status.Space.Phase).To(Equal(expectedPhase))
+				Expect(w.Status.Space.Message).To(Equal(expectedMessage))
+
+				c := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeSpaceProvisioned)
+				Expect(c).NotTo(BeNil())
+				Expect(c.Status).To(Equal(expectedCondition.Status))
+				Expect(c.Reason).To(Equal(expectedCondition.Reason))
+
+				rc := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeReady)
+				Expect(rc).NotTo(BeNil())
+				Expect(rc.Status).To(Equal(expectedCondition.Status))
+				if expectedCondition.Status == metav1.ConditionFalse {
+					Expect(rc.Reason).To(Equal(expectedCondition.Reason))
+				}
+			},
+			Entry("provisioned",
+				[]toolchainv1alpha1.Condition{
+					{Type: toolchainv1alpha1.ConditionReady, Status: kcorev1.ConditionTrue, Reason: toolchainv1alpha1.SpaceProvisionedReason},
+				},
+				workspacesv1alpha1.SpacePhaseProvisioned, "",
+				metav1.Condition{Status: metav1.ConditionTrue, Reason: workspacesv1alpha1.ConditionReasonSpaceReady}),
+			Entry("without conditions",
+				nil,
+				workspacesv1alpha1.SpacePhaseProvisioning, "",
+				metav1.Condition{Status: metav1.ConditionFalse, Reason: workspacesv1alpha1.ConditionReasonSpaceProvisioning}),
+			Entry("provisioning",
+				[]toolchainv1alpha1.Condition{
+					{Type: toolchainv1alpha1.ConditionReady, Status: kcorev1.ConditionFalse, Reason: toolchainv1alpha1.SpaceProvisioningReason},
+				},
+				workspacesv1alpha1.SpacePhaseProvisioning, "",
+				metav1.Condition{Status: metav1.ConditionFalse, Reason: workspacesv1alpha1.ConditionReasonSpaceProvisioning}),
+			Entry("pending a target cluster",
+				[]toolchainv1alpha1.Condition{
+					{Type: toolchainv1alpha1.ConditionReady, Status: kcorev1.ConditionFalse, Reason: toolchainv1alpha1.SpaceProvisioningPendingReason},
+				},
+				workspacesv1alpha1.SpacePhaseProvisioning, "",
+				metav1.Condition{Status: metav1.ConditionFalse, Reason: workspacesv1alpha1.ConditionReasonSpaceProvisioning}),
+			Entry("failed provisioning",
+				[]toolchainv1alpha1.Condition{
+					{Type: toolchainv1alpha1.ConditionReady, Status: kcorev1.ConditionFalse, Reason: toolchainv1alpha1.SpaceProvisioningFailedReason, Message: "tier not found"},
+				},
+				workspacesv1alpha1.SpacePhaseProvisioningFailed, "tier not found",
+				metav1.Condition{Status: metav1.ConditionFalse, Reason: workspacesv1alpha1.ConditionReasonSpaceProvisioningFailed}),
+			Entry("terminating",
+				[]toolchainv1alpha1.Condition{
+					{Type: toolchainv1alpha1.ConditionReady, Status: kcorev1.ConditionFalse, Reason: toolchainv1alpha1.SpaceTerminatingReason},
+				},
+				workspacesv1alpha1.SpacePhaseTerminating, "",
+				metav1.Condition{Status: metav1.ConditionFalse, Reason: workspacesv1alpha1.ConditionReasonSpaceTerminating}),
+		)
+
+		When("the Visibility can not be applied", func() {
+			BeforeEach(func() {
+				workspace.Spec.Visibility = "invalid"
+				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+			})
+
+			It("sets VisibilityApplied and Ready conditions to False", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+				r = buildReconciler()
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).To(MatchError(internalworkspace.ErrNonTransient))
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(meta.IsStatusConditionTrue(w.Status.Conditions, workspacesv1alpha1.ConditionTypeOwnerResolved)).To(BeTrue())
+				Expect(meta.IsStatusConditionTrue(w.Status.Conditions, workspacesv1alpha1.ConditionTypeSpaceProvisioned)).To(BeTrue())
+
+				v := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeVisibilityApplied)
+				Expect(v).NotTo(BeNil())
+				Expect(v.Status).To(Equal(metav1.ConditionFalse))
+				Expect(v.Reason).To(Equal(workspacesv1alpha1.ConditionReasonVisibilityNotSatisfied))
+
+				c := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeReady)
+				Expect(c).NotTo(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(workspacesv1alpha1.ConditionReasonVisibilityNotSatisfied))
+			})
+		})
+
+		Context("non-home Workspace provisioning", func() {
+			ownerSpaceBinding := toolchainv1alpha1.SpaceBinding{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      fmt.Sprintf("%s-community", workspaceName),
+					Name:      fmt.Sprintf("%s-owner", workspaceName),
 					Namespace: kubesawNamespace,
 				},
 			}
 
+			var homeSpace toolchainv1alpha1.Space
+
 			BeforeEach(func() {
-				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+				workspace.Spec.DisplayName = "non-home"
+				homeSpace = toolchainv1alpha1.Space{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      owner.Status.CompliantUsername,
+						Namespace: kubesawNamespace,
+						Labels:    map[string]string{toolchainv1alpha1.SpaceCreatorLabelKey: owner.Name},
+					},
+					Spec: toolchainv1alpha1.SpaceSpec{
+						TargetCluster: "member-cluster",
+						TierName:      "base",
+					},
+				}
 			})
 
-			When("the Visibility is set to private", func() {
+			When("the Owner's UserSignup and home Space exist", func() {
 				BeforeEach(func() {
-					workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+					clientBuilder = clientBuilder.WithObjects(&owner, &homeSpace)
 				})
 
-				It("deletes the existing community SpaceBinding", func() {
+				It("creates the Space and the owner's SpaceBinding", func() {
 					// given
-					clientBuilder = clientBuilder.WithObjects(&communitySpaceBinding)
 					r = buildReconciler()
 					key := client.ObjectKeyFromObject(&workspace)
 
@@ -397,54 +769,129 @@ var _ = Describe("InternalWorkspaceController", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(res).To(BeZero())
 
-					err = r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &toolchainv1alpha1.SpaceBinding{})
-					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+					s := toolchainv1alpha1.Space{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&space), &s)).To(Succeed())
+					Expect(s.Spec.TargetCluster).To(Equal(homeSpace.Spec.TargetCluster))
+					Expect(s.Spec.TierName).To(Equal(homeSpace.Spec.TierName))
+					Expect(s.Labels).To(And(
+						HaveKeyWithValue(toolchainv1alpha1.OwnerLabelKey, owner.Status.CompliantUsername),
+						HaveKeyWithValue(toolchainv1alpha1.SpaceCreatorLabelKey, owner.Name),
+					))
+
+					sb := toolchainv1alpha1.SpaceBinding{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&ownerSpaceBinding), &sb)).To(Succeed())
+					Expect(sb.Spec.MasterUserRecord).To(Equal(owner.Status.CompliantUsername))
+					Expect(sb.Spec.Space).To(Equal(workspace.Name))
+					Expect(sb.Spec.SpaceRole).To(Equal("admin"))
+					Expect(sb.Labels).To(And(
+						HaveKeyWithValue(toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey, owner.Status.CompliantUsername),
+						HaveKeyWithValue(toolchainv1alpha1.SpaceBindingSpaceLabelKey, workspace.Name),
+					))
+
+					w := workspacesv1alpha1.InternalWorkspace{}
+					Expect(r.Get(ctx, key, &w)).To(Succeed())
+					Expect(w.Status.Space.IsHome).To(BeFalse())
+					Expect(w.Status.Space.Phase).To(Equal(workspacesv1alpha1.SpacePhaseProvisioning))
+
+					c := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeReady)
+					Expect(c).NotTo(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(workspacesv1alpha1.ConditionReasonSpaceProvisioning))
 				})
 
-				It("is fine if the community SpaceBinding does not exists", func() {
+				It("provisions the Space with the configured tier", func() {
 					// given
+					wc := workspacesv1alpha1.WorkspacesConfig{
+						ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+						Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+							Spaces: workspacesv1alpha1.WorkspacesConfigSpaces{Tier: "workspace"},
+						},
+					}
+					clientBuilder = clientBuilder.WithObjects(&wc)
 					r = buildReconciler()
 					key := client.ObjectKeyFromObject(&workspace)
 
 					// when
-					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+					_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
 
 					// then
 					Expect(err).ToNot(HaveOccurred())
-					Expect(res).To(BeZero())
+					s := toolchainv1alpha1.Space{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&space), &s)).To(Succeed())
+					Expect(s.Spec.TierName).To(Equal("workspace"))
+					Expect(s.Spec.TargetCluster).To(Equal(homeSpace.Spec.TargetCluster))
+				})
 
-					err = r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &toolchainv1alpha1.SpaceBinding{})
-					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+				It("does not retarget an existing Space", func() {
+					// given
+					existing := toolchainv1alpha1.Space{
+						ObjectMeta: metav1.ObjectMeta{Name: workspace.Name, Namespace: kubesawNamespace},
+						Spec: toolchainv1alpha1.SpaceSpec{
+							TargetCluster: "other-cluster",
+							TierName:      "other-tier",
+						},
+					}
+					clientBuilder = clientBuilder.WithObjects(&existing)
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+					s := toolchainv1alpha1.Space{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&space), &s)).To(Succeed())
+					Expect(s.Spec.TargetCluster).To(Equal("other-cluster"))
+					Expect(s.Spec.TierName).To(Equal("other-tier"))
+					Expect(s.Labels).To(HaveKeyWithValue(toolchainv1alpha1.OwnerLabelKey, owner.Status.CompliantUsername))
 				})
 			})
 
-			When("the Visibility is set to community", func() {
+			When("the Owner's home Space does not exist", func() {
 				BeforeEach(func() {
-					workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+					clientBuilder = clientBuilder.WithObjects(&owner)
 				})
 
-				It("creates the community SpaceBinding", func() {
+				It("postpones the Space provisioning", func() {
 					// given
 					r = buildReconciler()
 					key := client.ObjectKeyFromObject(&workspace)
 
 					// when
-					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+					_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
 
 					// then
 					Expect(err).ToNot(HaveOccurred())
-					Expect(res).To(BeZero())
+					err = r.Get(ctx, client.ObjectKeyFromObject(&space), &toolchainv1alpha1.Space{})
+					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
 
-					sb := toolchainv1alpha1.SpaceBinding{}
-					Expect(r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &sb)).To(Succeed())
-					Expect(sb.Spec.MasterUserRecord).To(Equal(toolchainv1alpha1.KubesawAuthenticatedUsername))
-					Expect(sb.Spec.Space).To(Equal(workspace.Name))
-					Expect(sb.Spec.SpaceRole).To(Equal("viewer"))
+					w := workspacesv1alpha1.InternalWorkspace{}
+					Expect(r.Get(ctx, key, &w)).To(Succeed())
+					c := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeSpaceProvisioned)
+					Expect(c).NotTo(BeNil())
+					Expect(c.Reason).To(Equal(workspacesv1alpha1.ConditionReasonSpaceNotFound))
 				})
 
-				It("updates the existing community SpaceBinding", func() {
+				It("is enqueued by the creation of the owner's home Space", func() {
+					// given
+					r = buildReconciler()
+
+					// when
+					rr := internalworkspace.MapSpaceToWorkspace(&r, ctx, &homeSpace)
+
+					// then
+					Expect(rr).To(ContainElement(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&workspace)}))
+				})
+			})
+
+			When("the Owner's UserSignup does not exist", func() {
+				BeforeEach(func() {
+					clientBuilder = clientBuilder.WithObjects(&homeSpace)
+				})
+
+				It("creates neither the Space nor the owner's SpaceBinding", func() {
 					// given
-					clientBuilder = clientBuilder.WithObjects(&communitySpaceBinding)
 					r = buildReconciler()
 					key := client.ObjectKeyFromObject(&workspace)
 
@@ -453,15 +900,904 @@ var _ = Describe("InternalWorkspaceController", func() {
 
 					// then
 					Expect(err).ToNot(HaveOccurred())
-					Expect(res).To(BeZero())
+					Expect(res.RequeueAfter).To(BeNumerically("~", internalworkspace.DefaultOwnerDeletionGracePeriod, time.Minute))
 
-					sb := toolchainv1alpha1.SpaceBinding{}
-					Expect(r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &sb)).To(Succeed())
-					Expect(sb.Spec.MasterUserRecord).To(Equal(toolchainv1alpha1.KubesawAuthenticatedUsername))
-					Expect(sb.Spec.Space).To(Equal(workspace.Name))
-					Expect(sb.Spec.SpaceRole).To(Equal("viewer"))
+					err = r.Get(ctx, client.ObjectKeyFromObject(&space), &toolchainv1alpha1.Space{})
+					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+
+					err = r.Get(ctx, client.ObjectKeyFromObject(&ownerSpaceBinding), &toolchainv1alpha1.SpaceBinding{})
+					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
 				})
 			})
 		})
+
+		When("the Workspace is the home one and its Space does not exist", func() {
+			It("does not create the Space", func() {
+				// given
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				err = r.Get(ctx, client.ObjectKeyFromObject(&space), &toolchainv1alpha1.Space{})
+				Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+			})
+		})
+
+		Context("community SpaceBinding management", func() {
+			communitySpaceBinding := toolchainv1alpha1.SpaceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-community", workspaceName),
+					Namespace: kubesawNamespace,
+				},
+			}
+
+			BeforeEach(func() {
+				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+			})
+
+			When("the Visibility is set to private", func() {
+				BeforeEach(func() {
+					workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+				})
+
+				It("deletes the existing community SpaceBinding", func() {
+					// given
+					clientBuilder = clientBuilder.WithObjects(&communitySpaceBinding)
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res).To(BeZero())
+
+					err = r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &toolchainv1alpha1.SpaceBinding{})
+					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+				})
+
+				It("is fine if the community SpaceBinding does not exists", func() {
+					// given
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res).To(BeZero())
+
+					err = r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &toolchainv1alpha1.SpaceBinding{})
+					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+				})
+			})
+
+			When("the Visibility is set to community", func() {
+				BeforeEach(func() {
+					workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+				})
+
+				It("creates the community SpaceBinding", func() {
+					// given
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res).To(BeZero())
+
+					sb := toolchainv1alpha1.SpaceBinding{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &sb)).To(Succeed())
+					Expect(sb.Spec.MasterUserRecord).To(Equal(toolchainv1alpha1.KubesawAuthenticatedUsername))
+					Expect(sb.Labels).To(HaveKeyWithValue(toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey, toolchainv1alpha1.KubesawAuthenticatedUsername))
+					Expect(sb.Spec.Space).To(Equal(workspace.Name))
+					Expect(sb.Spec.SpaceRole).To(Equal("viewer"))
+				})
+
+				It("updates the existing community SpaceBinding", func() {
+					// given
+					clientBuilder = clientBuilder.WithObjects(&communitySpaceBinding)
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res).To(BeZero())
+
+					sb := toolchainv1alpha1.SpaceBinding{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &sb)).To(Succeed())
+					Expect(sb.Spec.MasterUserRecord).To(Equal(toolchainv1alpha1.KubesawAuthenticatedUsername))
+					Expect(sb.Spec.Space).To(Equal(workspace.Name))
+					Expect(sb.Spec.SpaceRole).To(Equal("viewer"))
+				})
+
+				It("grants the community role configured in the WorkspacesConfig", func() {
+					// given
+					c := workspacesv1alpha1.WorkspacesConfig{
+						ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+						Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+							Community: workspacesv1alpha1.WorkspacesConfigCommunity{Role: "contributor"},
+						},
+					}
+					clientBuilder = clientBuilder.WithObjects(&communitySpaceBinding, &c)
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+
+					sb := toolchainv1alpha1.SpaceBinding{}
+					Expect(r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &sb)).To(Succeed())
+					Expect(sb.Spec.SpaceRole).To(Equal("contributor"))
+				})
+
+				It("deletes the community SpaceBinding if the community visibility is disabled", func() {
+					// given
+					c := workspacesv1alpha1.WorkspacesConfig{
+						ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+						Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+							Features: workspacesv1alpha1.WorkspacesConfigFeatures{CommunityVisibility: ptr.To(false)},
+						},
+					}
+					clientBuilder = clientBuilder.WithObjects(&communitySpaceBinding, &c)
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+
+					err = r.Get(ctx, client.ObjectKeyFromObject(&communitySpaceBinding), &toolchainv1alpha1.SpaceBinding{})
+					Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+				})
+			})
+		})
+
+		When("the WorkspacesConfig changes", func() {
+			It("enqueues all the InternalWorkspaces", func() {
+				// given
+				other := *workspace.DeepCopy()
+				other.Name = "other-workspace"
+				other.Spec.Owner.JwtInfo.Sub = "other-sub"
+				clientBuilder = clientBuilder.WithObjects(&other)
+				r = buildReconciler()
+				c := workspacesv1alpha1.WorkspacesConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+				}
+
+				// when
+				rr := internalworkspace.MapWorkspacesConfigToWorkspace(&r, ctx, &c)
+
+				// then
+				Expect(rr).To(ConsistOf(
+					ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&workspace)},
+					ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&other)},
+				))
+			})
+
+			It("ignores WorkspacesConfigs with other names", func() {
+				// given
+				r = buildReconciler()
+				c := workspacesv1alpha1.WorkspacesConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "other"},
+				}
+
+				// when
+				rr := internalworkspace.MapWorkspacesConfigToWorkspace(&r, ctx, &c)
+
+				// then
+				Expect(rr).To(BeEmpty())
+			})
+		})
+
+		Context("members SpaceBindings management", func() {
+			buildMemberSpaceBinding := func(user, role string) *toolchainv1alpha1.SpaceBinding {
+				return &toolchainv1alpha1.SpaceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      fmt.Sprintf("%s-member-%s", workspaceName, user),
+						Namespace: kubesawNamespace,
+						Labels: map[string]string{
+							toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: user,
+							toolchainv1alpha1.SpaceBindingSpaceLabelKey:            workspaceName,
+							workspacesv1alpha1.LabelWorkspaceMember:                "true",
+						},
+					},
+					Spec: toolchainv1alpha1.SpaceBindingSpec{
+						MasterUserRecord: user,
+						Space:            workspaceName,
+						SpaceRole:        role,
+					},
+				}
+			}
+
+			BeforeEach(func() {
+				workspace.Spec.DisplayName = "non-home"
+				workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+			})
+
+			It("creates the members' SpaceBindings and reports them in the status", func() {
+				// given
+				workspace.Spec.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "viewer", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+					{Username: "admin", Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+				}
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				for _, m := range workspace.Spec.Members {
+					sb := toolchainv1alpha1.SpaceBinding{}
+					k := client.ObjectKeyFromObject(buildMemberSpaceBinding(m.Username, string(m.Role)))
+					Expect(r.Get(ctx, k, &sb)).To(Succeed())
+					Expect(sb.Spec.MasterUserRecord).To(Equal(m.Username))
+					Expect(sb.Spec.Space).To(Equal(workspace.Name))
+					Expect(sb.Spec.SpaceRole).To(Equal(string(m.Role)))
+					Expect(sb.Labels).To(And(
+						HaveKeyWithValue(toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey, m.Username),
+						HaveKeyWithValue(toolchainv1alpha1.SpaceBindingSpaceLabelKey, workspace.Name),
+						HaveKeyWithValue(workspacesv1alpha1.LabelWorkspaceMember, "true"),
+					))
+				}
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Members).To(Equal([]workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "admin", Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+					{Username: "viewer", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}))
+			})
+
+			It("updates the role of existing members", func() {
+				// given
+				workspace.Spec.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "member", Role: workspacesv1alpha1.InternalWorkspaceRoleMaintainer},
+				}
+				sb := buildMemberSpaceBinding("member", "viewer")
+				clientBuilder = clientBuilder.WithObjects(sb)
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(sb), sb)).To(Succeed())
+				Expect(sb.Spec.SpaceRole).To(Equal("maintainer"))
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Members).To(Equal([]workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "member", Role: workspacesv1alpha1.InternalWorkspaceRoleMaintainer},
+				}))
+			})
+
+			It("deletes the SpaceBindings of revoked members", func() {
+				// given
+				workspace.Status.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "revoked", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}
+				sb := buildMemberSpaceBinding("revoked", "viewer")
+				clientBuilder = clientBuilder.WithObjects(sb)
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				err = r.Get(ctx, client.ObjectKeyFromObject(sb), &toolchainv1alpha1.SpaceBinding{})
+				Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Members).To(BeEmpty())
+			})
+
+			It("does not create a member SpaceBinding for the owner", func() {
+				// given
+				workspace.Spec.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: owner.Status.CompliantUsername, Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				k := client.ObjectKeyFromObject(buildMemberSpaceBinding(owner.Status.CompliantUsername, "viewer"))
+				err = r.Get(ctx, k, &toolchainv1alpha1.SpaceBinding{})
+				Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Members).To(BeEmpty())
+			})
+		})
+
+		Context("editors RoleBinding management", func() {
+			BeforeEach(func() {
+				workspace.Spec.DisplayName = "non-home"
+				workspace.Spec.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "viewer", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+					{Username: "admin", Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+				}
+				clientBuilder = clientBuilder.WithObjects(&owner, &space)
+			})
+
+			It("grants the owner and the admins the permissions on the workspace only", func() {
+				// given
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+
+				ek := types.NamespacedName{Name: fmt.Sprintf("%s-editor", workspace.Name), Namespace: workspace.Namespace}
+				ro := rbacv1.Role{}
+				Expect(r.Get(ctx, ek, &ro)).To(Succeed())
+				Expect(ro.Rules).To(ConsistOf(rbacv1.PolicyRule{
+					APIGroups:     []string{workspacesv1alpha1.GroupVersion.Group},
+					Resources:     []string{"internalworkspaces"},
+					ResourceNames: []string{workspace.Name},
+					Verbs:         []string{"get", "update", "patch", "delete"},
+				}))
+				Expect(ro.OwnerReferences).To(ContainElement(HaveField("Name", workspace.Name)))
+
+				rb := rbacv1.RoleBinding{}
+				Expect(r.Get(ctx, ek, &rb)).To(Succeed())
+				Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: ek.Name}))
+				Expect(rb.Subjects).To(ConsistOf(
+					rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: owner.Status.CompliantUsername},
+					rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "admin"},
+				))
+				Expect(rb.OwnerReferences).To(ContainElement(HaveField("Name", workspace.Name)))
+			})
+
+			It("does not grant the deletion of the home workspace", func() {
+				// given
+				workspace.Spec.DisplayName = workspacesv1alpha1.DisplayNameDefaultWorkspace
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+
+				ek := types.NamespacedName{Name: fmt.Sprintf("%s-editor", workspace.Name), Namespace: workspace.Namespace}
+				ro := rbacv1.Role{}
+				Expect(r.Get(ctx, ek, &ro)).To(Succeed())
+				Expect(ro.Rules).To(ConsistOf(HaveField("Verbs", ConsistOf("get", "update", "patch"))))
+			})
+
+			It("revokes the permissions of the users that are not admins anymore", func() {
+				// given
+				rb := rbacv1.RoleBinding{
+					ObjectMeta: corev1.ObjectMeta{Name: fmt.Sprintf("%s-editor", workspace.Name), Namespace: workspace.Namespace},
+					Subjects: []rbacv1.Subject{
+						{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: "revoked"},
+					},
+				}
+				clientBuilder = clientBuilder.WithObjects(&rb)
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&rb), &rb)).To(Succeed())
+				Expect(rb.Subjects).NotTo(ContainElement(HaveField("Name", "revoked")))
+			})
+		})
+
+		Context("owner transfer", func() {
+			var previousOwner toolchainv1alpha1.UserSignup
+
+			ownerSpaceBinding := toolchainv1alpha1.SpaceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-owner", workspaceName),
+					Namespace: kubesawNamespace,
+					Labels: map[string]string{
+						toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: "previous-owner",
+						toolchainv1alpha1.SpaceBindingSpaceLabelKey:            workspaceName,
+					},
+				},
+				Spec: toolchainv1alpha1.SpaceBindingSpec{
+					MasterUserRecord: "previous-owner",
+					Space:            workspaceName,
+					SpaceRole:        "admin",
+				},
+			}
+
+			BeforeEach(func() {
+				previousOwner = toolchainv1alpha1.UserSignup{
+					ObjectMeta: corev1.ObjectMeta{
+						Name:      "previous-owner",
+						Namespace: kubesawNamespace,
+					},
+					Spec: toolchainv1alpha1.UserSignupSpec{
+						IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+							PropagatedClaims: toolchainv1alpha1.PropagatedClaims{Sub: "previous-owner-sub"},
+						},
+					},
+					Status: toolchainv1alpha1.UserSignupStatus{
+						CompliantUsername: "previous-owner",
+					},
+				}
+
+				workspace.Spec.DisplayName = "non-home"
+				workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+				workspace.Spec.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "previous-owner", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}
+				workspace.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: ownerSub}
+				workspace.Status.Owner.Username = "previous-owner"
+				clientBuilder = clientBuilder.WithObjects(&owner, &previousOwner, &space, ownerSpaceBinding.DeepCopy())
+			})
+
+			It("grants the new owner admin access, downgrades the previous one, and completes the transfer", func() {
+				// given
+				r = buildReconciler()
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				sb := toolchainv1alpha1.SpaceBinding{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&ownerSpaceBinding), &sb)).To(Succeed())
+				Expect(sb.Spec.MasterUserRecord).To(Equal(owner.Status.CompliantUsername))
+				Expect(sb.Spec.SpaceRole).To(Equal("admin"))
+				Expect(sb.Labels).To(HaveKeyWithValue(toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey, owner.Status.CompliantUsername))
+
+				msb := toolchainv1alpha1.SpaceBinding{}
+				msbKey := client.ObjectKey{Namespace: kubesawNamespace, Name: fmt.Sprintf("%s-member-previous-owner", workspaceName)}
+				Expect(r.Get(ctx, msbKey, &msb)).To(Succeed())
+				Expect(msb.Spec.SpaceRole).To(Equal("viewer"))
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Status.Owner.Username).To(Equal(owner.Status.CompliantUsername))
+				Expect(w.Annotations).NotTo(HaveKey(workspacesv1alpha1.AnnotationOwnerTransfer))
+				Expect(w.Status.Members).To(Equal([]workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "previous-owner", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}))
+			})
+
+			When("the new owner can not be resolved", func() {
+				BeforeEach(func() {
+					workspace.Spec.Owner.JwtInfo.Sub = "not-found-sub"
+					workspace.Annotations[workspacesv1alpha1.AnnotationOwnerTransfer] = "not-found-sub"
+				})
+
+				It("keeps the transfer pending", func() {
+					// given
+					r = buildReconciler()
+					key := client.ObjectKeyFromObject(&workspace)
+
+					// when
+					res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+					// then
+					Expect(err).ToNot(HaveOccurred())
+					Expect(res.RequeueAfter).To(BeNumerically("~", internalworkspace.DefaultOwnerDeletionGracePeriod, time.Minute))
+
+					w := workspacesv1alpha1.InternalWorkspace{}
+					Expect(r.Get(ctx, key, &w)).To(Succeed())
+					Expect(w.Annotations).To(HaveKeyWithValue(workspacesv1alpha1.AnnotationOwnerTransfer, "not-found-sub"))
+				})
+			})
+		})
+
+		Context("owner deletion", func() {
+			var fallbackOwner toolchainv1alpha1.UserSignup
+			var fallbackOwnerName string
+
+			// ownerLostSince marks the owner as not found since the given time
+			ownerLostSince := func(t time.Time) {
+				workspace.Status.Conditions = []metav1.Condition{{
+					Type:               workspacesv1alpha1.ConditionTypeOwnerResolved,
+					Status:             metav1.ConditionFalse,
+					Reason:             workspacesv1alpha1.ConditionReasonOwnerNotFound,
+					LastTransitionTime: metav1.NewTime(t),
+				}}
+			}
+
+			reconcile := func(policy workspacesv1alpha1.OwnerDeletionPolicy) (internalworkspace.WorkspaceReconciler, workspacesv1alpha1.InternalWorkspace, ctrl.Result) {
+				r := buildReconciler()
+				r.OwnerDeletionPolicy = policy
+				r.FallbackOwner = fallbackOwnerName
+				key := client.ObjectKeyFromObject(&workspace)
+
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+				Expect(err).NotTo(HaveOccurred())
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				return r, w, res
+			}
+
+			BeforeEach(func() {
+				fallbackOwner = toolchainv1alpha1.UserSignup{
+					ObjectMeta: corev1.ObjectMeta{
+						Name:      "fallback",
+						Namespace: kubesawNamespace,
+					},
+					Spec: toolchainv1alpha1.UserSignupSpec{
+						IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+							PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
+								Sub:    "fallback-sub",
+								Email:  "fallback@email.com",
+								UserID: "fallback-userid",
+							},
+						},
+					},
+					Status: toolchainv1alpha1.UserSignupStatus{
+						CompliantUsername: "fallback",
+					},
+				}
+
+				fallbackOwnerName = fallbackOwner.Status.CompliantUsername
+
+				workspace.Spec.DisplayName = "non-home"
+				workspace.Spec.Members = []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "fallback", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+					{Username: "member", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}
+				ownerLostSince(time.Now().Add(-2 * internalworkspace.DefaultOwnerDeletionGracePeriod))
+				clientBuilder = clientBuilder.WithObjects(&space, &fallbackOwner)
+			})
+
+			When("the grace period has not elapsed yet", func() {
+				BeforeEach(func() {
+					ownerLostSince(time.Now().Add(-time.Minute))
+				})
+
+				It("waits for the grace period before applying the policy", func() {
+					// when
+					_, w, res := reconcile(workspacesv1alpha1.OwnerDeletionPolicyDelete)
+
+					// then
+					Expect(w.DeletionTimestamp).To(BeNil())
+					Expect(res.RequeueAfter).To(BeNumerically("~", internalworkspace.DefaultOwnerDeletionGracePeriod-time.Minute, time.Minute))
+				})
+			})
+
+			It("deletes the workspace with the Delete policy", func() {
+				// when
+				_, w, res := reconcile(workspacesv1alpha1.OwnerDeletionPolicyDelete)
+
+				// then
+				Expect(res).To(BeZero())
+				Expect(w.DeletionTimestamp).NotTo(BeNil())
+			})
+
+			It("flags the workspace with the Orphan policy", func() {
+				// when
+				_, w, res := reconcile(workspacesv1alpha1.OwnerDeletionPolicyOrphan)
+
+				// then
+				Expect(res).To(BeZero())
+				Expect(w.DeletionTimestamp).To(BeNil())
+				Expect(w.Labels).To(HaveKeyWithValue(workspacesv1alpha1.LabelOrphaned, "true"))
+			})
+
+			It("orphans the workspace if no policy is configured", func() {
+				// when
+				_, w, _ := reconcile("")
+
+				// then
+				Expect(w.Labels).To(HaveKeyWithValue(workspacesv1alpha1.LabelOrphaned, "true"))
+			})
+
+			It("applies the workspace's policy over the cluster's one", func() {
+				// given
+				workspace.Spec.OwnerDeletionPolicy = workspacesv1alpha1.OwnerDeletionPolicyOrphan
+
+				// when
+				_, w, _ := reconcile(workspacesv1alpha1.OwnerDeletionPolicyDelete)
+
+				// then
+				Expect(w.DeletionTimestamp).To(BeNil())
+				Expect(w.Labels).To(HaveKeyWithValue(workspacesv1alpha1.LabelOrphaned, "true"))
+			})
+
+			It("revokes every access with the Archive policy", func() {
+				// given
+				workspace.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+
+				// when
+				r, w, _ := reconcile(workspacesv1alpha1.OwnerDeletionPolicyArchive)
+
+				// then
+				Expect(w.DeletionTimestamp).To(BeNil())
+				Expect(w.Labels).To(HaveKeyWithValue(workspacesv1alpha1.LabelArchived, "true"))
+				Expect(w.Status.Members).To(BeEmpty())
+
+				sbb := toolchainv1alpha1.SpaceBindingList{}
+				Expect(r.List(ctx, &sbb)).To(Succeed())
+				Expect(sbb.Items).To(BeEmpty())
+			})
+
+			It("transfers the workspace to the fallback owner with the Transfer policy", func() {
+				// when
+				_, w, res := reconcile(workspacesv1alpha1.OwnerDeletionPolicyTransfer)
+
+				// then
+				Expect(res).To(BeZero())
+				Expect(w.Spec.Owner.JwtInfo).To(Equal(workspacesv1alpha1.JwtInfo{
+					Sub:    "fallback-sub",
+					Email:  "fallback@email.com",
+					UserId: "fallback-userid",
+				}))
+				Expect(w.Annotations).To(HaveKeyWithValue(workspacesv1alpha1.AnnotationOwnerTransfer, "fallback-sub"))
+				Expect(w.Spec.Members).To(Equal([]workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: "member", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				}))
+			})
+
+			It("orphans the workspace with the Transfer policy if the fallback owner does not exist", func() {
+				// given
+				fallbackOwnerName = "not-found"
+
+				// when
+				_, w, _ := reconcile(workspacesv1alpha1.OwnerDeletionPolicyTransfer)
+
+				// then
+				Expect(w.Spec.Owner.JwtInfo.Sub).To(Equal(ownerSub))
+				Expect(w.Labels).To(HaveKeyWithValue(workspacesv1alpha1.LabelOrphaned, "true"))
+			})
+
+			It("always deletes the home workspace", func() {
+				// given
+				workspace.Spec.DisplayName = workspacesv1alpha1.DisplayNameDefaultWorkspace
+				workspace.Spec.OwnerDeletionPolicy = workspacesv1alpha1.OwnerDeletionPolicyOrphan
+
+				// when
+				_, w, _ := reconcile(workspacesv1alpha1.OwnerDeletionPolicyArchive)
+
+				// then
+				Expect(w.DeletionTimestamp).NotTo(BeNil())
+			})
+
+			When("the owner is found again", func() {
+				BeforeEach(func() {
+					workspace.Labels = map[string]string{
+						workspacesv1alpha1.LabelOrphaned: "true",
+						workspacesv1alpha1.LabelArchived: "true",
+					}
+					clientBuilder = clientBuilder.WithObjects(&owner)
+				})
+
+				It("removes the owner deletion flags", func() {
+					// when
+					_, w, _ := reconcile(workspacesv1alpha1.OwnerDeletionPolicyArchive)
+
+					// then
+					Expect(w.Labels).NotTo(HaveKey(workspacesv1alpha1.LabelOrphaned))
+					Expect(w.Labels).NotTo(HaveKey(workspacesv1alpha1.LabelArchived))
+				})
+			})
+		})
+	})
+
+	Context("Workspace is reconciled", func() {
+		BeforeEach(func() {
+			clientBuilder = clientBuilder.
+				WithObjects(&workspace, &owner, &space).
+				WithStatusSubresource(&workspace)
+		})
+
+		It("adds the clean up finalizer", func() {
+			// given
+			r = buildReconciler()
+			key := client.ObjectKeyFromObject(&workspace)
+
+			// when
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+			// then
+			Expect(err).ToNot(HaveOccurred())
+			w := workspacesv1alpha1.InternalWorkspace{}
+			Expect(r.Get(ctx, key, &w)).To(Succeed())
+			Expect(w.Finalizers).To(ContainElement(workspacesv1alpha1.FinalizerCleanUp))
+		})
+	})
+
+	Context("Workspace is being deleted", func() {
+		var ownerSpaceBinding, communitySpaceBinding, sharingSpaceBinding, unrelatedSpaceBinding toolchainv1alpha1.SpaceBinding
+
+		buildSpaceBinding := func(name, space, mur string) toolchainv1alpha1.SpaceBinding {
+			return toolchainv1alpha1.SpaceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: kubesawNamespace,
+					Labels: map[string]string{
+						toolchainv1alpha1.SpaceBindingSpaceLabelKey:            space,
+						toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: mur,
+					},
+				},
+				Spec: toolchainv1alpha1.SpaceBindingSpec{
+					Space:            space,
+					MasterUserRecord: mur,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			now := metav1.Now()
+			workspace.DeletionTimestamp = &now
+			workspace.Finalizers = []string{workspacesv1alpha1.FinalizerCleanUp}
+			workspace.Status.Owner.Username = owner.Status.CompliantUsername
+
+			ownerSpaceBinding = buildSpaceBinding(fmt.Sprintf("%s-owner", workspaceName), workspaceName, owner.Status.CompliantUsername)
+			communitySpaceBinding = buildSpaceBinding(fmt.Sprintf("%s-community", workspaceName), workspaceName, workspacesv1alpha1.PublicViewerName)
+			sharingSpaceBinding = buildSpaceBinding(fmt.Sprintf("%s-member-viewer", workspaceName), workspaceName, "viewer")
+			sharingSpaceBinding.Labels[workspacesv1alpha1.LabelWorkspaceMember] = "true"
+			unrelatedSpaceBinding = buildSpaceBinding("unrelated", "unrelated", owner.Status.CompliantUsername)
+		})
+
+		JustBeforeEach(func() {
+			clientBuilder = clientBuilder.
+				WithObjects(&workspace, &owner, &space, &ownerSpaceBinding, &communitySpaceBinding, &sharingSpaceBinding, &unrelatedSpaceBinding).
+				WithStatusSubresource(&workspace)
+			r = buildReconciler()
+		})
+
+		expectNotFound := func(obj client.Object) {
+			err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+			Expect(err).To(MatchError(kerrors.IsNotFound, "IsNotFound error expected"))
+		}
+
+		expectFound := func(obj client.Object) {
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		}
+
+		When("the Workspace is not the home one", func() {
+			BeforeEach(func() {
+				workspace.Spec.DisplayName = "my-workspace"
+			})
+
+			It("deletes the Space and all its SpaceBindings", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				expectNotFound(&workspacesv1alpha1.InternalWorkspace{ObjectMeta: workspace.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.Space{ObjectMeta: space.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: ownerSpaceBinding.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: communitySpaceBinding.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: sharingSpaceBinding.ObjectMeta})
+				expectFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: unrelatedSpaceBinding.ObjectMeta})
+			})
+		})
+
+		When("the Workspace is the home one", func() {
+			BeforeEach(func() {
+				// the owner SpaceBinding of home Spaces is created by KubeSaw
+				ownerSpaceBinding = buildSpaceBinding(fmt.Sprintf("%s-x7k2p", owner.Status.CompliantUsername), workspaceName, owner.Status.CompliantUsername)
+			})
+
+			It("deletes the community and sharing SpaceBindings only", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res).To(BeZero())
+
+				expectNotFound(&workspacesv1alpha1.InternalWorkspace{ObjectMeta: workspace.ObjectMeta})
+				expectFound(&toolchainv1alpha1.Space{ObjectMeta: space.ObjectMeta})
+				expectFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: ownerSpaceBinding.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: communitySpaceBinding.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: sharingSpaceBinding.ObjectMeta})
+				expectFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: unrelatedSpaceBinding.ObjectMeta})
+			})
+		})
+
+		When("the owner of the home Workspace is not resolved", func() {
+			BeforeEach(func() {
+				workspace.Status.Owner = workspacesv1alpha1.UserInfoStatus{}
+				ownerSpaceBinding = buildSpaceBinding(fmt.Sprintf("%s-x7k2p", owner.Status.CompliantUsername), workspaceName, owner.Status.CompliantUsername)
+			})
+
+			It("keeps the SpaceBindings not created by the operator", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				expectNotFound(&workspacesv1alpha1.InternalWorkspace{ObjectMeta: workspace.ObjectMeta})
+				expectFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: ownerSpaceBinding.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: communitySpaceBinding.ObjectMeta})
+				expectNotFound(&toolchainv1alpha1.SpaceBinding{ObjectMeta: sharingSpaceBinding.ObjectMeta})
+			})
+		})
+
+		When("deleting the Space fails", func() {
+			errDeleteSpace := fmt.Errorf("unexpected error deleting Space")
+
+			BeforeEach(func() {
+				workspace.Spec.DisplayName = "my-workspace"
+				clientBuilder = clientBuilder.WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if _, ok := obj.(*toolchainv1alpha1.Space); ok {
+							return errDeleteSpace
+						}
+						return client.Delete(ctx, obj, opts...)
+					},
+				})
+			})
+
+			It("keeps the finalizer and forwards the error", func() {
+				// given
+				key := client.ObjectKeyFromObject(&workspace)
+
+				// when
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+
+				// then
+				Expect(err).To(MatchError(errDeleteSpace))
+
+				w := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, key, &w)).To(Succeed())
+				Expect(w.Finalizers).To(ContainElement(workspacesv1alpha1.FinalizerCleanUp))
+			})
+		})
 	})
 })
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalworkspace
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/config"
+)
+
+// DefaultOwnerDeletionGracePeriod the time waited by default before applying the owner deletion policy,
+// so that InternalWorkspaces survive transient deletions of their owner's UserSignup
+const DefaultOwnerDeletionGracePeriod time.Duration = time.Hour
+
+// ensureOwnerDeletionIsHandled applies the owner deletion policy to InternalWorkspaces
+// whose owner's UserSignup has not been found for longer than the grace period.
+// It returns the result to reconcile the InternalWorkspace with, and whether the
+// reconciliation has to stop because the InternalWorkspace was deleted or transferred.
+func (r *WorkspaceReconciler) ensureOwnerDeletionIsHandled(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) (ctrl.Result, bool, error) {
+	c := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeOwnerResolved)
+	if c == nil || c.Reason != workspacesv1alpha1.ConditionReasonOwnerNotFound {
+		// the owner exists, so previously applied flags are removed
+		return ctrl.Result{}, false, r.ensureOwnerDeletionLabelsAreRemoved(ctx, w)
+	}
+
+	// wait for the grace period to elapse, the owner's UserSignup may be restored
+	if remaining := r.OwnerDeletionGracePeriod - time.Since(c.LastTransitionTime.Time); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, false, nil
+	}
+
+	l := log.FromContext(ctx).WithValues("sub", w.Spec.Owner.JwtInfo.Sub)
+	p := r.ownerDeletionPolicy(w)
+	switch p {
+	case workspacesv1alpha1.OwnerDeletionPolicyDelete:
+		l.Info("owner not found, deleting InternalWorkspace", "policy", p)
+		return ctrl.Result{}, true, client.IgnoreNotFound(r.Delete(ctx, w))
+
+	case workspacesv1alpha1.OwnerDeletionPolicyTransfer:
+		ok, err := r.transferToFallbackOwner(ctx, w)
+		if err != nil || ok {
+			return ctrl.Result{}, ok, err
+		}
+		l.Info("fallback owner not available, orphaning InternalWorkspace", "policy", p, "fallback-owner", r.FallbackOwner)
+		return ctrl.Result{}, false, r.ensureOwnerDeletionLabel(ctx, w, workspacesv1alpha1.LabelOrphaned)
+
+	case workspacesv1alpha1.OwnerDeletionPolicyArchive:
+		l.Info("owner not found, archiving InternalWorkspace", "policy", p)
+		return ctrl.Result{}, false, r.ensureOwnerDeletionLabel(ctx, w, workspacesv1alpha1.LabelArchived)
+
+	default:
+		l.Info("owner not found, orphaning InternalWorkspace", "policy", p)
+		return ctrl.Result{}, false, r.ensureOwnerDeletionLabel(ctx, w, workspacesv1alpha1.LabelOrphaned)
+	}
+}
+
+// ownerDeletionPolicy returns the owner deletion policy applying to the InternalWorkspace.
+// Home InternalWorkspaces are always deleted, as their Space is deleted by KubeSaw together with the UserSignup.
+func (r *WorkspaceReconciler) ownerDeletionPolicy(w *workspacesv1alpha1.InternalWorkspace) workspacesv1alpha1.OwnerDeletionPolicy {
+	switch {
+	case isHomeWorkspace(w):
+		return workspacesv1alpha1.OwnerDeletionPolicyDelete
+	case w.Spec.OwnerDeletionPolicy != "":
+		return w.Spec.OwnerDeletionPolicy
+	case r.OwnerDeletionPolicy != "":
+		return r.OwnerDeletionPolicy
+	default:
+		return workspacesv1alpha1.OwnerDeletionPolicyOrphan
+	}
+}
+
+// transferToFallbackOwner transfers the InternalWorkspace to the fallback owner.
+// It returns false if no fallback owner is configured or its UserSignup is not found.
+func (r *WorkspaceReconciler) transferToFallbackOwner(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) (bool, error) {
+	if r.FallbackOwner == "" {
+		return false, nil
+	}
+
+	uu := toolchainv1alpha1.UserSignupList{}
+	if err := r.List(ctx, &uu,
+		client.InNamespace(r.KubesawNamespace),
+		client.MatchingFields{IndexKeyUserSignupCompliantUsername: r.FallbackOwner},
+	); err != nil {
+		return false, err
+	}
+	if len(uu.Items) == 0 {
+		return false, nil
+	}
+	u := uu.Items[0]
+
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		return false, err
+	}
+
+	log.FromContext(ctx).Info("owner not found, transferring InternalWorkspace to fallback owner",
+		"sub", w.Spec.Owner.JwtInfo.Sub, "fallback-owner", r.FallbackOwner)
+	w.Spec.Owner = workspacesv1alpha1.UserInfo{JwtInfo: JwtInfoForUserSignup(&u, c.Identity)}
+	w.Spec.Members = slices.DeleteFunc(w.Spec.Members, func(m workspacesv1alpha1.InternalWorkspaceMember) bool {
+		return m.Username == u.Status.CompliantUsername
+	})
+	if w.Annotations == nil {
+		w.Annotations = map[string]string{}
+	}
+	w.Annotations[workspacesv1alpha1.AnnotationOwnerTransfer] = u.Spec.IdentityClaims.Sub
+	delete(w.Labels, workspacesv1alpha1.LabelOrphaned)
+	delete(w.Labels, workspacesv1alpha1.LabelArchived)
+	return true, r.Update(ctx, w)
+}
+
+// ensureOwnerDeletionLabel flags the InternalWorkspace with the given label
+func (r *WorkspaceReconciler) ensureOwnerDeletionLabel(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace, label string) error {
+	if w.Labels[label] == "true" {
+		return nil
+	}
+
+	if w.Labels == nil {
+		w.Labels = map[string]string{}
+	}
+	w.Labels[label] = "true"
+	return r.Update(ctx, w)
+}
+
+// ensureOwnerDeletionLabelsAreRemoved removes the flags set by the owner deletion policy
+func (r *WorkspaceReconciler) ensureOwnerDeletionLabelsAreRemoved(ctx context.Context, w *workspacesv1alpha1.InternalWorkspace) error {
+	_, o := w.Labels[workspacesv1alpha1.LabelOrphaned]
+	_, a := w.Labels[workspacesv1alpha1.LabelArchived]
+	if !o && !a {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("owner found, removing owner deletion flags", "owner", w.Status.Owner.Username)
+	delete(w.Labels, workspacesv1alpha1.LabelOrphaned)
+	delete(w.Labels, workspacesv1alpha1.LabelArchived)
+	return r.Update(ctx, w)
+}
+
+// isArchived checks whether the InternalWorkspace was archived because its owner was deleted
+func isArchived(w *workspacesv1alpha1.InternalWorkspace) bool {
+	return w.Labels[workspacesv1alpha1.LabelArchived] == "true"
+}
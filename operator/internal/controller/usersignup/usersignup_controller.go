@@ -18,7 +18,6 @@ package usersignup
 
 import (
 	"context"
-	"slices"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,11 +26,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/config"
 )
 
 // UserSignupReconciler reconciles a Workspace object
@@ -41,7 +43,7 @@ type UserSignupReconciler struct {
 	WorkspacesNamespace string
 }
 
-//+kubebuilder:rbac:groups=toolchain.dev.openshift.com,resources=usersignups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=toolchain.dev.openshift.com,resources=usersignups,verbs=get;list;watch;patch
 //+kubebuilder:rbac:groups=workspaces.konflux-ci.dev,resources=internalworkspaces,verbs=get;list;watch;create;update;patch;delete;deletecollection
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -54,18 +56,32 @@ func (r *UserSignupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	u := toolchainv1alpha1.UserSignup{}
 	if err := r.Client.Get(ctx, req.NamespacedName, &u); err != nil {
 		if kerrors.IsNotFound(err) {
+			// the InternalWorkspaces owned by deleted users are handled by the InternalWorkspace
+			// reconciler, according to the owner deletion policy
 			l.V(6).Info("UserSignup not found")
-			if err := r.ensureWorkspaceIsDeleted(ctx, req.Name); err != nil {
-				l.Error(err, "can not delete workspace", "user", req.Name)
-				return ctrl.Result{}, err
-			}
 			return ctrl.Result{}, nil
 		}
 		l.Error(err, "error retrieving UserSignup")
 		return ctrl.Result{}, err
 	}
 
-	if err := r.ensureWorkspaceIsPresentForHomeSpace(ctx, u); err != nil {
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		l.Error(err, "error retrieving WorkspacesConfig")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.ensureIssuerIsRecorded(ctx, &u, c.Identity); err != nil {
+		l.Error(err, "error recording the issuer on UserSignup")
+		return ctrl.Result{}, err
+	}
+
+	if u.Status.HomeSpace == "" {
+		l.V(6).Info("UserSignup has no HomeSpace yet")
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.ensureWorkspaceIsPresentForHomeSpace(ctx, u, c.Identity); err != nil {
 		l.Error(err, "error ensuring InternalWorkspace is present for user's HomeSpace")
 		return ctrl.Result{}, err
 	}
@@ -74,7 +90,23 @@ func (r *UserSignupReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
-func (r *UserSignupReconciler) ensureWorkspaceIsPresentForHomeSpace(ctx context.Context, u toolchainv1alpha1.UserSignup) error {
+// ensureIssuerIsRecorded records the default issuer on UserSignups lacking the issuer,
+// so that they keep matching their users once other identity providers are federated
+func (r *UserSignupReconciler) ensureIssuerIsRecorded(ctx context.Context, u *toolchainv1alpha1.UserSignup, identity workspacesv1alpha1.WorkspacesConfigIdentity) error {
+	if identity.DefaultIssuer == "" || hasIssuer(u) {
+		return nil
+	}
+
+	p := client.MergeFrom(u.DeepCopy())
+	if u.Annotations == nil {
+		u.Annotations = map[string]string{}
+	}
+	u.Annotations[workspacesv1alpha1.AnnotationUserSignupIssuer] = identity.DefaultIssuer
+	log.FromContext(ctx).Info("recording default issuer on UserSignup", "issuer", identity.DefaultIssuer)
+	return r.Client.Patch(ctx, u, p)
+}
+
+func (r *UserSignupReconciler) ensureWorkspaceIsPresentForHomeSpace(ctx context.Context, u toolchainv1alpha1.UserSignup, identity workspacesv1alpha1.WorkspacesConfigIdentity) error {
 	w := &workspacesv1alpha1.InternalWorkspace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      u.Status.HomeSpace,
@@ -83,7 +115,7 @@ func (r *UserSignupReconciler) ensureWorkspaceIsPresentForHomeSpace(ctx context.
 	}
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, w, func() error {
 		if w.ObjectMeta.CreationTimestamp.IsZero() {
-			w.Spec.DisplayName = "default"
+			w.Spec.DisplayName = workspacesv1alpha1.DisplayNameDefaultWorkspace
 			w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
 		}
 
@@ -92,6 +124,7 @@ func (r *UserSignupReconciler) ensureWorkspaceIsPresentForHomeSpace(ctx context.
 				Sub:    u.Spec.IdentityClaims.Sub,
 				Email:  u.Spec.IdentityClaims.Email,
 				UserId: u.Spec.IdentityClaims.UserID,
+				Issuer: identity.IssuerOrDefault(u.GetAnnotations()[workspacesv1alpha1.AnnotationUserSignupIssuer]),
 			},
 		}
 
@@ -106,31 +139,41 @@ func (r *UserSignupReconciler) ensureWorkspaceIsPresentForHomeSpace(ctx context.
 	return nil
 }
 
-func (r *UserSignupReconciler) ensureWorkspaceIsDeleted(ctx context.Context, name string) error {
-	// retrieve all InternalWorkspaces
-	ww := workspacesv1alpha1.InternalWorkspaceList{}
-	if err := r.List(ctx, &ww); err != nil {
-		return err
-	}
-
-	// look for user's home InternalWorkspace
-	if i := slices.IndexFunc(ww.Items, func(w workspacesv1alpha1.InternalWorkspace) bool {
-		return w.Status.Space.IsHome && w.Status.Owner.Username == name
-	}); i != -1 {
-		// delete the user's Home InternalWorkspace
-		return r.Delete(ctx, &ww.Items[i])
-	}
-
-	// workspace not found, nothing to delete
-	return nil
-}
-
 // SetupWithManager sets up the controller with the Manager.
 func (r *UserSignupReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&toolchainv1alpha1.UserSignup{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
 			u, ok := object.(*toolchainv1alpha1.UserSignup)
-			return ok && u.Status.HomeSpace != ""
+			return ok && (u.Status.HomeSpace != "" || !hasIssuer(u))
 		}))).
+		Watches(&workspacesv1alpha1.WorkspacesConfig{}, handler.EnqueueRequestsFromMapFunc(r.mapWorkspacesConfigToUserSignup)).
 		Complete(r)
 }
+
+// mapWorkspacesConfigToUserSignup enqueues the UserSignups lacking the issuer,
+// so that the default issuer is recorded on them as soon as it is configured
+func (r *UserSignupReconciler) mapWorkspacesConfigToUserSignup(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetName() != workspacesv1alpha1.WorkspacesConfigName {
+		return nil
+	}
+
+	uu := toolchainv1alpha1.UserSignupList{}
+	if err := r.List(ctx, &uu); err != nil {
+		log.FromContext(ctx).Error(err, "error listing UserSignups")
+		return nil
+	}
+
+	rr := []reconcile.Request{}
+	for _, u := range uu.Items {
+		if !hasIssuer(&u) {
+			rr = append(rr, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&u)})
+		}
+	}
+	return rr
+}
+
+// hasIssuer returns true if the issuer of the UserSignup's identity provider is recorded
+func hasIssuer(u *toolchainv1alpha1.UserSignup) bool {
+	_, ok := u.GetAnnotations()[workspacesv1alpha1.AnnotationUserSignupIssuer]
+	return ok
+}
@@ -11,7 +11,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
-	"k8s.io/apimachinery/pkg/api/errors"
 	corev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -99,57 +98,114 @@ var _ = Describe("InternalWorkspaceController", func() {
 	})
 
 	Context("UserSignup is not found", func() {
-		When("InternalWorkspace exists for user's home space", func() {
+		BeforeEach(func() {
+			clientBuilder = clientBuilder.WithObjects(&aliceInternalWorkspace)
+		})
+
+		It("leaves the InternalWorkspace to the owner deletion policy", func() {
+			// when
+			r, res, err := reconcile(&aliceUserSignup)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(BeZero())
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceInternalWorkspace), &workspacesv1alpha1.InternalWorkspace{})).To(Succeed())
+		})
+	})
+
+	Context("UserSignup is found", func() {
+		BeforeEach(func() {
+			clientBuilder = clientBuilder.WithObjects(&aliceUserSignup)
+		})
+
+		When("a default issuer is configured", func() {
 			BeforeEach(func() {
-				clientBuilder = clientBuilder.WithObjects(&aliceInternalWorkspace)
+				c := workspacesv1alpha1.WorkspacesConfig{
+					ObjectMeta: corev1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+					Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+						Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+					},
+				}
+				clientBuilder = clientBuilder.WithObjects(&c)
 			})
 
-			It("deletes the InternalWorkspace", func() {
+			It("records the default issuer on the UserSignup lacking it", func() {
 				// when
 				r, res, err := reconcile(&aliceUserSignup)
 
 				// then
 				Expect(err).NotTo(HaveOccurred())
 				Expect(res).To(BeZero())
-				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceInternalWorkspace), &workspacesv1alpha1.InternalWorkspace{})).
-					To(MatchError(errors.IsNotFound, "expected NotFound error"))
+
+				u := toolchainv1alpha1.UserSignup{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceUserSignup), &u)).To(Succeed())
+				Expect(u.Annotations).To(HaveKeyWithValue(workspacesv1alpha1.AnnotationUserSignupIssuer, "https://default-issuer"))
+			})
+
+			It("does not change the issuer recorded on the UserSignup", func() {
+				// given
+				aliceUserSignup.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: "https://issuer"}
+
+				// when
+				r, _, err := reconcile(&aliceUserSignup)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				u := toolchainv1alpha1.UserSignup{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceUserSignup), &u)).To(Succeed())
+				Expect(u.Annotations).To(HaveKeyWithValue(workspacesv1alpha1.AnnotationUserSignupIssuer, "https://issuer"))
+			})
+
+			It("records the default issuer on the UserSignup without a HomeSpace", func() {
+				// given
+				aliceUserSignup.Status.HomeSpace = ""
+
+				// when
+				r, _, err := reconcile(&aliceUserSignup)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				u := toolchainv1alpha1.UserSignup{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceUserSignup), &u)).To(Succeed())
+				Expect(u.Annotations).To(HaveKeyWithValue(workspacesv1alpha1.AnnotationUserSignupIssuer, "https://default-issuer"))
+
+				ww := workspacesv1alpha1.InternalWorkspaceList{}
+				Expect(r.List(ctx, &ww)).To(Succeed())
+				Expect(ww.Items).To(BeEmpty())
 			})
 
-			It("forwards the error if deletion was not successful", func() {
+			It("forwards the error if the issuer could not be recorded", func() {
 				// given
-				deleteErr := fmt.Errorf("test delete error")
-				clientBuilder = clientBuilder.WithInterceptorFuncs(
-					interceptor.Funcs{
-						Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
-							return deleteErr
-						},
+				patchErr := fmt.Errorf("test patch error")
+				clientBuilder = clientBuilder.WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, client client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						return patchErr
 					},
-				)
+				})
 
 				// when
 				_, res, err := reconcile(&aliceUserSignup)
 
 				// then
-				Expect(err).To(MatchError(deleteErr))
+				Expect(err).To(MatchError(patchErr))
 				Expect(res).To(BeZero())
 			})
 		})
 
-		When("InternalWorkspace doesn't exist for user's home space", func() {
-			It("returns without error", func() {
+		When("no default issuer is configured", func() {
+			It("does not record any issuer on the UserSignup", func() {
 				// when
-				_, res, err := reconcile(&aliceUserSignup)
+				r, _, err := reconcile(&aliceUserSignup)
 
 				// then
 				Expect(err).NotTo(HaveOccurred())
-				Expect(res).To(BeZero())
-			})
-		})
-	})
 
-	Context("UserSignup is found", func() {
-		BeforeEach(func() {
-			clientBuilder = clientBuilder.WithObjects(&aliceUserSignup)
+				u := toolchainv1alpha1.UserSignup{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceUserSignup), &u)).To(Succeed())
+				Expect(u.Annotations).NotTo(HaveKey(workspacesv1alpha1.AnnotationUserSignupIssuer))
+			})
 		})
 
 		When("an InternalWorkspace already exists", func() {
@@ -213,6 +269,42 @@ var _ = Describe("InternalWorkspaceController", func() {
 				Expect(iw.Spec.Visibility).To(Equal(workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
 			})
 
+			It("sets the owner's issuer from the UserSignup", func() {
+				// given
+				aliceUserSignup.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: "https://issuer"}
+
+				// when
+				r, _, err := reconcile(&aliceUserSignup)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				iw := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceInternalWorkspace), &iw)).To(Succeed())
+				Expect(iw.Spec.Owner.JwtInfo.Issuer).To(Equal("https://issuer"))
+			})
+
+			It("sets the default issuer if the UserSignup lacks it", func() {
+				// given
+				c := workspacesv1alpha1.WorkspacesConfig{
+					ObjectMeta: corev1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+					Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+						Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+					},
+				}
+				clientBuilder = clientBuilder.WithObjects(&c)
+
+				// when
+				r, _, err := reconcile(&aliceUserSignup)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+
+				iw := workspacesv1alpha1.InternalWorkspace{}
+				Expect(r.Get(ctx, client.ObjectKeyFromObject(&aliceInternalWorkspace), &iw)).To(Succeed())
+				Expect(iw.Spec.Owner.JwtInfo.Issuer).To(Equal("https://default-issuer"))
+			})
+
 			It("forwards the error if creation was not successful", func() {
 				// given
 				createErr := fmt.Errorf("test create error")
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspacesconfig
+
+import (
+	"context"
+
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/config"
+)
+
+// WorkspacesConfigReconciler reconciles the WorkspacesConfig.
+// Most of the configuration is read by the other reconcilers and by the webhooks whenever needed,
+// but the namespaces are fixed when the operator starts: this reconciler detects their changes
+// so that the operator can be restarted.
+type WorkspacesConfigReconciler struct {
+	client.Client
+
+	// Namespaces the namespaces the operator has been started with
+	Namespaces workspacesv1alpha1.WorkspacesConfigNamespaces
+	// OnNamespacesChanged is called when the configured namespaces
+	// differ from the ones the operator has been started with
+	OnNamespacesChanged func()
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.1/pkg/reconcile
+func (r *WorkspacesConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.FromContext(ctx).WithValues("request", req)
+
+	c, err := config.Get(ctx, r.Client)
+	if err != nil {
+		l.Error(err, "error retrieving WorkspacesConfig")
+		return ctrl.Result{}, err
+	}
+
+	if c.Namespaces != r.Namespaces {
+		l.Info("configured namespaces changed, restarting",
+			"workspaces-namespace", c.Namespaces.Workspaces,
+			"kubesaw-namespace", c.Namespaces.Kubesaw)
+		r.OnNamespacesChanged()
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The controller does not need leader election, as every replica has to be restarted.
+func (r *WorkspacesConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&workspacesv1alpha1.WorkspacesConfig{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == workspacesv1alpha1.WorkspacesConfigName
+		}))).
+		WithOptions(controller.Options{NeedLeaderElection: ptr.To(false)}).
+		Complete(r)
+}
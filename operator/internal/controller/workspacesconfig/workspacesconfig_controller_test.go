@@ -0,0 +1,120 @@
+package workspacesconfig_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/konflux-workspaces/workspaces/operator/internal/controller/workspacesconfig"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+var _ = Describe("WorkspacesConfigController", func() {
+	var clientBuilder *fake.ClientBuilder
+	var ctx context.Context
+	var restarted bool
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: workspacesv1alpha1.WorkspacesConfigName}}
+
+	buildReconciler := func() workspacesconfig.WorkspacesConfigReconciler {
+		return workspacesconfig.WorkspacesConfigReconciler{
+			Client: clientBuilder.Build(),
+			Namespaces: workspacesv1alpha1.WorkspacesConfigNamespaces{
+				Workspaces: "workspaces",
+				Kubesaw:    "kubesaw",
+			},
+			OnNamespacesChanged: func() { restarted = true },
+		}
+	}
+
+	buildConfig := func(workspacesNamespace, kubesawNamespace string) *workspacesv1alpha1.WorkspacesConfig {
+		return &workspacesv1alpha1.WorkspacesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+			Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+				Namespaces: workspacesv1alpha1.WorkspacesConfigNamespaces{
+					Workspaces: workspacesNamespace,
+					Kubesaw:    kubesawNamespace,
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		restarted = false
+
+		scheme := runtime.NewScheme()
+		Expect(workspacesv1alpha1.AddToScheme(scheme)).To(Succeed())
+		clientBuilder = fake.NewClientBuilder().WithScheme(scheme)
+	})
+
+	It("does not restart if the namespaces did not change", func() {
+		// given
+		clientBuilder = clientBuilder.WithObjects(buildConfig("workspaces", "kubesaw"))
+		r := buildReconciler()
+
+		// when
+		_, err := r.Reconcile(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restarted).To(BeFalse())
+	})
+
+	DescribeTable("restarts if the namespaces changed",
+		func(workspacesNamespace, kubesawNamespace string) {
+			// given
+			clientBuilder = clientBuilder.WithObjects(buildConfig(workspacesNamespace, kubesawNamespace))
+			r := buildReconciler()
+
+			// when
+			_, err := r.Reconcile(ctx, request)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restarted).To(BeTrue())
+		},
+		Entry("workspaces namespace", "other", "kubesaw"),
+		Entry("kubesaw namespace", "workspaces", "other"),
+	)
+
+	It("restarts if the WorkspacesConfig is deleted and the namespaces are not the default ones", func() {
+		// given
+		r := buildReconciler()
+
+		// when
+		_, err := r.Reconcile(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restarted).To(BeTrue())
+	})
+
+	It("returns the error if the WorkspacesConfig can not be retrieved", func() {
+		// given
+		clientBuilder = clientBuilder.WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, client client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return fmt.Errorf("unexpected error retrieving WorkspacesConfig")
+			},
+		})
+		r := buildReconciler()
+
+		// when
+		_, err := r.Reconcile(ctx, request)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(restarted).To(BeFalse())
+	})
+})
@@ -0,0 +1,13 @@
+package workspacesconfig_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestWorkspacesconfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Workspacesconfig Suite")
+}
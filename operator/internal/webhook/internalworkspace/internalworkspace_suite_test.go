@@ -0,0 +1,13 @@
+package internalworkspace_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInternalworkspaceWebhook(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Internalworkspace Webhook Suite")
+}
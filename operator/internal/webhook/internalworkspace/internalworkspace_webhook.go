@@ -0,0 +1,400 @@
+/*
+Copyright 2024 The Workspaces Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internalworkspace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/config"
+	"github.com/konflux-workspaces/workspaces/operator/internal/controller/internalworkspace"
+)
+
+var (
+	_ admission.CustomDefaulter = &Defaulter{}
+	_ admission.CustomValidator = &Validator{}
+)
+
+//+kubebuilder:webhook:path=/mutate-workspaces-konflux-ci-dev-v1alpha1-internalworkspace,mutating=true,failurePolicy=fail,sideEffects=None,groups=workspaces.konflux-ci.dev,resources=internalworkspaces,verbs=create;update,versions=v1alpha1,name=minternalworkspace.workspaces.konflux-ci.dev,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-workspaces-konflux-ci-dev-v1alpha1-internalworkspace,mutating=false,failurePolicy=fail,sideEffects=None,groups=workspaces.konflux-ci.dev,resources=internalworkspaces,verbs=create;update,versions=v1alpha1,name=vinternalworkspace.workspaces.konflux-ci.dev,admissionReviewVersions=v1
+
+// SetupWebhookWithManager registers the InternalWorkspace's defaulting and validating webhooks.
+// The validating webhook looks up InternalWorkspaces and UserSignups by owner through the indexes registered
+// by the InternalWorkspace reconciler, so the latter needs to be set up too.
+func SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&workspacesv1alpha1.InternalWorkspace{}).
+		WithDefaulter(&Defaulter{Client: mgr.GetClient()}).
+		WithValidator(&Validator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// Defaulter sets the default values of InternalWorkspaces
+type Defaulter struct {
+	Client client.Reader
+}
+
+// Default sets the InternalWorkspace's visibility to private if not set,
+// and the owner's issuer to the configured default issuer if not set
+func (d *Defaulter) Default(ctx context.Context, obj runtime.Object) error {
+	w, ok := obj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return kerrors.NewBadRequest(fmt.Sprintf("expected an InternalWorkspace but got a %T", obj))
+	}
+
+	if w.Spec.Visibility == "" {
+		w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+	}
+	if w.Spec.Owner.JwtInfo.Issuer == "" {
+		c, err := getConfig(ctx, d.Client)
+		if err != nil {
+			return err
+		}
+		w.Spec.Owner.JwtInfo.Issuer = c.Identity.DefaultIssuer
+	}
+	return nil
+}
+
+// Validator validates InternalWorkspaces according to the WorkspacesConfig
+type Validator struct {
+	Client client.Reader
+}
+
+// ValidateCreate validates the DisplayName of the new InternalWorkspace,
+// ensures it is unique among the ones of the same owner,
+// and ensures the owner does not exceed their quota
+func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	w, ok := obj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("expected an InternalWorkspace but got a %T", obj))
+	}
+
+	c, err := v.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ee := append(validateCreationIsEnabled(w, c), validateDisplayName(w, c)...)
+	ee = append(ee, validateVisibility(nil, w, c)...)
+	if len(ee) == 0 {
+		ww, err := v.listOwnerWorkspaces(ctx, w, c)
+		if err != nil {
+			return nil, err
+		}
+		qq, err := v.validateQuota(ctx, w, ww, c)
+		if err != nil {
+			return nil, err
+		}
+		ee = append(validateUniqueness(w, ww), qq...)
+	}
+	return nil, toInvalid(w, ee)
+}
+
+// ValidateUpdate validates the DisplayName of the updated InternalWorkspace, ensures the home one is not renamed,
+// ensures its owner is changed only through a transfer,
+// and ensures it is still unique among the ones of the same owner
+func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	o, ok := oldObj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("expected an InternalWorkspace but got a %T", oldObj))
+	}
+	w, ok := newObj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("expected an InternalWorkspace but got a %T", newObj))
+	}
+
+	c, err := v.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the InternalWorkspace is validated only when the owner, the DisplayName or the visibility change,
+	// so that InternalWorkspaces can always be updated for other reasons, e.g. finalizers removal
+	ownerChanged := isOwnerChanged(o, w, c)
+	if !ownerChanged && o.Spec.DisplayName == w.Spec.DisplayName && o.Spec.Visibility == w.Spec.Visibility {
+		return nil, nil
+	}
+
+	ee := validateVisibility(o, w, c)
+	if !ownerChanged && o.Spec.DisplayName == w.Spec.DisplayName {
+		return nil, toInvalid(w, ee)
+	}
+
+	if o.Spec.DisplayName != w.Spec.DisplayName {
+		ee = append(ee, validateDisplayNameChange(o, w, c)...)
+	}
+	if ownerChanged {
+		ee = append(ee, validateOwnerChange(o, w, c)...)
+		ee = append(ee, validateOwnerChangeRequester(ctx, o)...)
+	}
+	if len(ee) == 0 {
+		ww, err := v.listOwnerWorkspaces(ctx, w, c)
+		if err != nil {
+			return nil, err
+		}
+		ee = validateUniqueness(w, ww)
+		if ownerChanged {
+			qq, err := v.validateQuota(ctx, w, ww, c)
+			if err != nil {
+				return nil, err
+			}
+			ee = append(ee, qq...)
+		}
+	}
+	return nil, toInvalid(w, ee)
+}
+
+// ValidateDelete allows all deletions
+func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// config retrieves the WorkspacesConfig, so that its changes are applied without restarting the webhook
+func (v *Validator) config(ctx context.Context) (*workspacesv1alpha1.WorkspacesConfigSpec, error) {
+	return getConfig(ctx, v.Client)
+}
+
+// getConfig retrieves the WorkspacesConfig, wrapping errors in an InternalError
+func getConfig(ctx context.Context, r client.Reader) (*workspacesv1alpha1.WorkspacesConfigSpec, error) {
+	c, err := config.Get(ctx, r)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error retrieving WorkspacesConfig")
+		return nil, kerrors.NewInternalError(err)
+	}
+	return c, nil
+}
+
+// validateCreationIsEnabled ensures InternalWorkspaces other than the home one
+// are created only if the workspace creation is enabled
+func validateCreationIsEnabled(w *workspacesv1alpha1.InternalWorkspace, c *workspacesv1alpha1.WorkspacesConfigSpec) field.ErrorList {
+	if c.Features.IsWorkspaceCreationEnabled() || isHomeWorkspace(w) {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("metadata", "name"), "workspace creation is disabled")}
+}
+
+// validateDisplayName ensures the DisplayName is a DNS-1123 label complying with the configured name rules.
+// The home InternalWorkspace's DisplayName is never reserved.
+func validateDisplayName(w *workspacesv1alpha1.InternalWorkspace, c *workspacesv1alpha1.WorkspacesConfigSpec) field.ErrorList {
+	p := field.NewPath("spec", "displayName")
+	ee := field.ErrorList{}
+	for _, m := range validation.IsDNS1123Label(w.Spec.DisplayName) {
+		ee = append(ee, field.Invalid(p, w.Spec.DisplayName, m))
+	}
+	// DNS-1123 labels are never longer than 63 characters, so only stricter limits are checked
+	if m := int(c.NameRules.MaxLength); m < validation.DNS1123LabelMaxLength && len(w.Spec.DisplayName) > m {
+		ee = append(ee, field.TooLong(p, w.Spec.DisplayName, m))
+	}
+	if !isHomeWorkspace(w) && slices.Contains(c.NameRules.ReservedNames, w.Spec.DisplayName) {
+		ee = append(ee, field.Forbidden(p, fmt.Sprintf("%s is a reserved name", w.Spec.DisplayName)))
+	}
+	return ee
+}
+
+// validateDisplayNameChange validates the new DisplayName and ensures the home InternalWorkspace is not renamed,
+// as the permissions granted on InternalWorkspaces depend on whether they are the home one
+func validateDisplayNameChange(o, w *workspacesv1alpha1.InternalWorkspace, c *workspacesv1alpha1.WorkspacesConfigSpec) field.ErrorList {
+	if o.Status.Space.IsHome || isHomeWorkspace(o) {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec", "displayName"), "the home workspace can not be renamed")}
+	}
+	return validateDisplayName(w, c)
+}
+
+// validateVisibility ensures InternalWorkspaces are made visible to the community
+// only if the community visibility is enabled
+func validateVisibility(o, w *workspacesv1alpha1.InternalWorkspace, c *workspacesv1alpha1.WorkspacesConfigSpec) field.ErrorList {
+	if w.Spec.Visibility != workspacesv1alpha1.InternalWorkspaceVisibilityCommunity ||
+		(o != nil && o.Spec.Visibility == w.Spec.Visibility) ||
+		c.Features.IsCommunityVisibilityEnabled() {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "visibility"), "community visibility is disabled")}
+}
+
+// isOwnerChanged returns true if the owner of the InternalWorkspace changed.
+// Setting the default issuer on owners lacking it does not change them.
+func isOwnerChanged(o, w *workspacesv1alpha1.InternalWorkspace, c *workspacesv1alpha1.WorkspacesConfigSpec) bool {
+	oo, wo := o.Spec.Owner, w.Spec.Owner
+	oo.JwtInfo.Issuer = c.Identity.IssuerOrDefault(oo.JwtInfo.Issuer)
+	wo.JwtInfo.Issuer = c.Identity.IssuerOrDefault(wo.JwtInfo.Issuer)
+	return oo != wo
+}
+
+// validateOwnerChange ensures the owner is changed only if the InternalWorkspace
+// is annotated for being transferred to the new owner, and is not a home workspace
+func validateOwnerChange(o, w *workspacesv1alpha1.InternalWorkspace, c *workspacesv1alpha1.WorkspacesConfigSpec) field.ErrorList {
+	p := field.NewPath("spec", "owner")
+	if !c.Features.IsOwnershipTransferEnabled() {
+		return field.ErrorList{field.Forbidden(p, "ownership transfer is disabled")}
+	}
+	if o.Status.Space.IsHome || isHomeWorkspace(o) {
+		return field.ErrorList{field.Forbidden(p, "the home workspace can not be transferred")}
+	}
+	if t := w.GetAnnotations()[workspacesv1alpha1.AnnotationOwnerTransfer]; t == "" || t != w.Spec.Owner.JwtInfo.Sub {
+		return field.ErrorList{
+			field.Forbidden(p, fmt.Sprintf("owner can only be changed by transferring the workspace through the %s annotation", workspacesv1alpha1.AnnotationOwnerTransfer)),
+		}
+	}
+	return nil
+}
+
+// validateOwnerChangeRequester ensures that KubeSaw users can transfer only the InternalWorkspaces they own,
+// as the REST API Server performs transfers impersonating the requesting user.
+// Other requesters, like the operator applying the owner deletion policy, are authorized by RBAC only.
+func validateOwnerChangeRequester(ctx context.Context, o *workspacesv1alpha1.InternalWorkspace) field.ErrorList {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+
+	u := req.UserInfo
+	if !slices.Contains(u.Groups, toolchainv1alpha1.KubesawAuthenticatedUsername) || u.Username == o.Status.Owner.Username {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "owner"), "only the owner can transfer the workspace")}
+}
+
+// listOwnerWorkspaces lists the InternalWorkspaces owned by the InternalWorkspace's owner
+func (v *Validator) listOwnerWorkspaces(
+	ctx context.Context,
+	w *workspacesv1alpha1.InternalWorkspace,
+	c *workspacesv1alpha1.WorkspacesConfigSpec,
+) ([]workspacesv1alpha1.InternalWorkspace, error) {
+	o := w.Spec.Owner.JwtInfo
+	ww, err := internalworkspace.ListInternalWorkspacesByOwner(ctx, v.Client, w.Namespace, c.Identity, o.Issuer, o.Sub)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error listing owner's InternalWorkspaces", "issuer", o.Issuer, "sub", o.Sub)
+		return nil, kerrors.NewInternalError(err)
+	}
+	return ww, nil
+}
+
+// validateUniqueness ensures no other InternalWorkspace of the same owner has the same DisplayName.
+// Being the home InternalWorkspace identified by its DisplayName, this also ensures
+// that each user has only one home InternalWorkspace.
+func validateUniqueness(w *workspacesv1alpha1.InternalWorkspace, ww []workspacesv1alpha1.InternalWorkspace) field.ErrorList {
+	for _, e := range ww {
+		if e.Name == w.Name || e.Spec.DisplayName != w.Spec.DisplayName {
+			continue
+		}
+
+		p := field.NewPath("spec", "displayName")
+		if isHomeWorkspace(w) {
+			return field.ErrorList{field.Forbidden(p, "the owner already has a home workspace")}
+		}
+		return field.ErrorList{field.Duplicate(p, w.Spec.DisplayName)}
+	}
+	return nil
+}
+
+// validateQuota ensures the owner does not own more InternalWorkspaces than allowed to them.
+// The home InternalWorkspace is always allowed.
+func (v *Validator) validateQuota(
+	ctx context.Context,
+	w *workspacesv1alpha1.InternalWorkspace,
+	ww []workspacesv1alpha1.InternalWorkspace,
+	c *workspacesv1alpha1.WorkspacesConfigSpec,
+) (field.ErrorList, error) {
+	if isHomeWorkspace(w) {
+		return nil, nil
+	}
+
+	limit, err := v.ownerQuota(ctx, w, ww, c)
+	if err != nil {
+		return nil, err
+	}
+	if limit == nil {
+		return nil, nil
+	}
+
+	n := 0
+	for _, e := range ww {
+		if e.Name != w.Name {
+			n++
+		}
+	}
+	if n < int(*limit) {
+		return nil, nil
+	}
+	return field.ErrorList{
+		field.Forbidden(field.NewPath("spec", "owner"), fmt.Sprintf("the owner already owns %d workspaces out of the %d allowed", n, *limit)),
+	}, nil
+}
+
+// ownerQuota returns the maximum number of InternalWorkspaces the owner can own, or nil if unlimited.
+// Overrides are matched against the owner's UserSignup labels and the tier of the owner's home InternalWorkspace.
+func (v *Validator) ownerQuota(
+	ctx context.Context,
+	w *workspacesv1alpha1.InternalWorkspace,
+	ww []workspacesv1alpha1.InternalWorkspace,
+	c *workspacesv1alpha1.WorkspacesConfigSpec,
+) (*int32, error) {
+	if len(c.Quotas.Overrides) == 0 {
+		return c.Quotas.MaxWorkspacesPerUser, nil
+	}
+
+	o := w.Spec.Owner.JwtInfo
+	uu, err := internalworkspace.ListUserSignupsByIdentity(ctx, v.Client, c.Namespaces.Kubesaw, c.Identity, o.Issuer, o.Sub)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error retrieving owner's UserSignup", "issuer", o.Issuer, "sub", o.Sub)
+		return nil, kerrors.NewInternalError(err)
+	}
+	var ll map[string]string
+	if len(uu) > 0 {
+		ll = uu[0].Labels
+	}
+
+	tier := ""
+	for _, e := range ww {
+		if e.Status.Space.IsHome || isHomeWorkspace(&e) {
+			tier = e.Status.Space.Tier
+			break
+		}
+	}
+
+	limit, err := c.Quotas.MaxWorkspacesFor(ll, tier)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "error evaluating quota overrides")
+		return nil, kerrors.NewInternalError(err)
+	}
+	return limit, nil
+}
+
+// isHomeWorkspace returns true if the InternalWorkspace is a home one
+func isHomeWorkspace(w *workspacesv1alpha1.InternalWorkspace) bool {
+	return w.Spec.DisplayName == workspacesv1alpha1.DisplayNameDefaultWorkspace
+}
+
+// toInvalid wraps the validation errors in an Invalid error, or returns nil if there are none
+func toInvalid(w *workspacesv1alpha1.InternalWorkspace, ee field.ErrorList) error {
+	if len(ee) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(workspacesv1alpha1.GroupVersion.WithKind("InternalWorkspace").GroupKind(), w.Name, ee)
+}
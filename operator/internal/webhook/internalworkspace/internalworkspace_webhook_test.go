@@ -0,0 +1,777 @@
+package internalworkspace_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/operator/internal/controller/internalworkspace"
+	webhookinternalworkspace "github.com/konflux-workspaces/workspaces/operator/internal/webhook/internalworkspace"
+)
+
+var _ = Describe("InternalWorkspace Webhook", func() {
+	var ctx context.Context
+	var scheme *runtime.Scheme
+	var clientBuilder *fake.ClientBuilder
+
+	workspacesNamespace := "workspaces-system"
+	ownerSub := "owner-sub"
+
+	buildWorkspace := func(name, displayName, sub string) workspacesv1alpha1.InternalWorkspace {
+		return workspacesv1alpha1.InternalWorkspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: workspacesNamespace,
+			},
+			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+				DisplayName: displayName,
+				Visibility:  workspacesv1alpha1.InternalWorkspaceVisibilityPrivate,
+				Owner: workspacesv1alpha1.UserInfo{
+					JwtInfo: workspacesv1alpha1.JwtInfo{
+						Sub:    sub,
+						Email:  sub + "@email.com",
+						UserId: sub,
+					},
+				},
+			},
+		}
+	}
+
+	buildValidator := func() *webhookinternalworkspace.Validator {
+		return &webhookinternalworkspace.Validator{Client: clientBuilder.Build()}
+	}
+
+	withConfig := func(spec workspacesv1alpha1.WorkspacesConfigSpec) {
+		c := workspacesv1alpha1.WorkspacesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+			Spec:       spec,
+		}
+		clientBuilder = clientBuilder.WithObjects(&c)
+	}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+
+		scheme = runtime.NewScheme()
+		Expect(workspacesv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(toolchainv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		clientBuilder = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithIndex(&workspacesv1alpha1.InternalWorkspace{}, internalworkspace.IndexKeyInternalWorkspaceOwnerIdentity, internalworkspace.InternalWorkspaceOwnerIdentityIndexer).
+			WithIndex(&toolchainv1alpha1.UserSignup{}, internalworkspace.IndexKeyUserSignupIdentity, internalworkspace.UserSignupIdentityIndexer)
+	})
+
+	Describe("Defaulter", func() {
+		It("sets the visibility to private if not set", func() {
+			// given
+			w := buildWorkspace("workspace", "workspace", ownerSub)
+			w.Spec.Visibility = ""
+
+			// when
+			err := (&webhookinternalworkspace.Defaulter{Client: clientBuilder.Build()}).Default(ctx, &w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Spec.Visibility).To(Equal(workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+		})
+
+		It("does not change the visibility if set", func() {
+			// given
+			w := buildWorkspace("workspace", "workspace", ownerSub)
+			w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+
+			// when
+			err := (&webhookinternalworkspace.Defaulter{Client: clientBuilder.Build()}).Default(ctx, &w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Spec.Visibility).To(Equal(workspacesv1alpha1.InternalWorkspaceVisibilityCommunity))
+		})
+
+		When("a default issuer is configured", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+				})
+			})
+
+			It("sets the owner's issuer if not set", func() {
+				// given
+				w := buildWorkspace("workspace", "workspace", ownerSub)
+
+				// when
+				err := (&webhookinternalworkspace.Defaulter{Client: clientBuilder.Build()}).Default(ctx, &w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.Spec.Owner.JwtInfo.Issuer).To(Equal("https://default-issuer"))
+			})
+
+			It("does not change the owner's issuer if set", func() {
+				// given
+				w := buildWorkspace("workspace", "workspace", ownerSub)
+				w.Spec.Owner.JwtInfo.Issuer = "https://other-issuer"
+
+				// when
+				err := (&webhookinternalworkspace.Defaulter{Client: clientBuilder.Build()}).Default(ctx, &w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.Spec.Owner.JwtInfo.Issuer).To(Equal("https://other-issuer"))
+			})
+		})
+	})
+
+	Describe("Validator on create", func() {
+		DescribeTable("validates the display name",
+			func(displayName string, valid bool) {
+				// given
+				w := buildWorkspace("workspace", displayName, ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				if valid {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+			},
+			Entry("lowercase alphanumeric", "my-workspace-1", true),
+			Entry("home", workspacesv1alpha1.DisplayNameDefaultWorkspace, true),
+			Entry("maximum length", strings.Repeat("a", int(workspacesv1alpha1.DefaultMaxNameLength)), true),
+			Entry("empty", "", false),
+			Entry("too long", strings.Repeat("a", int(workspacesv1alpha1.DefaultMaxNameLength)+1), false),
+			Entry("uppercase", "My-Workspace", false),
+			Entry("dots", "my.workspace", false),
+			Entry("leading dash", "-workspace", false),
+		)
+
+		When("the owner already has a workspace with the same display name", func() {
+			BeforeEach(func() {
+				e := buildWorkspace("existing", "my-workspace", ownerSub)
+				clientBuilder = clientBuilder.WithObjects(&e)
+			})
+
+			It("rejects the workspace", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+			})
+
+			It("allows the same display name for another owner", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", "other-sub")
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("allows the same display name for the same sub from another issuer", func() {
+				// given
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+				})
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+				w.Spec.Owner.JwtInfo.Issuer = "https://other-issuer"
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects the same display name for the same sub from any issuer if no default issuer is configured", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+				w.Spec.Owner.JwtInfo.Issuer = "https://other-issuer"
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+			})
+
+			When("the existing workspace lacks the issuer", func() {
+				BeforeEach(func() {
+					withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+						Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+					})
+				})
+
+				It("rejects the workspace of the same owner from the default issuer", func() {
+					// given
+					w := buildWorkspace("workspace", "my-workspace", ownerSub)
+					w.Spec.Owner.JwtInfo.Issuer = "https://default-issuer"
+
+					// when
+					_, err := buildValidator().ValidateCreate(ctx, &w)
+
+					// then
+					Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+					Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+				})
+			})
+		})
+
+		When("the owner already has a home workspace", func() {
+			BeforeEach(func() {
+				e := buildWorkspace("existing", workspacesv1alpha1.DisplayNameDefaultWorkspace, ownerSub)
+				clientBuilder = clientBuilder.WithObjects(&e)
+			})
+
+			It("rejects another home workspace", func() {
+				// given
+				w := buildWorkspace("workspace", workspacesv1alpha1.DisplayNameDefaultWorkspace, ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("already has a home workspace"))
+			})
+		})
+
+		When("the name rules are configured", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					NameRules: workspacesv1alpha1.WorkspacesConfigNameRules{
+						MaxLength:     10,
+						ReservedNames: []string{"reserved"},
+					},
+				})
+			})
+
+			DescribeTable("validates the display name against them",
+				func(displayName string, valid bool) {
+					// given
+					w := buildWorkspace("workspace", displayName, ownerSub)
+
+					// when
+					_, err := buildValidator().ValidateCreate(ctx, &w)
+
+					// then
+					if valid {
+						Expect(err).NotTo(HaveOccurred())
+						return
+					}
+					Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				},
+				Entry("maximum length", strings.Repeat("a", 10), true),
+				Entry("too long", strings.Repeat("a", 11), false),
+				Entry("reserved", "reserved", false),
+				Entry("home", workspacesv1alpha1.DisplayNameDefaultWorkspace, true),
+			)
+		})
+
+		When("the workspace creation is disabled", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					Features: workspacesv1alpha1.WorkspacesConfigFeatures{WorkspaceCreation: ptr.To(false)},
+				})
+			})
+
+			It("rejects the workspace", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("workspace creation is disabled"))
+			})
+
+			It("allows the home workspace", func() {
+				// given
+				w := buildWorkspace("workspace", workspacesv1alpha1.DisplayNameDefaultWorkspace, ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the community visibility is disabled", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					Features: workspacesv1alpha1.WorkspacesConfigFeatures{CommunityVisibility: ptr.To(false)},
+				})
+			})
+
+			It("rejects community workspaces", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+				w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("community visibility is disabled"))
+			})
+		})
+
+		When("the owner reached the maximum number of workspaces", func() {
+			var quotas workspacesv1alpha1.WorkspacesConfigQuotas
+			var ownerLabels map[string]string
+
+			BeforeEach(func() {
+				quotas = workspacesv1alpha1.WorkspacesConfigQuotas{MaxWorkspacesPerUser: ptr.To[int32](2)}
+				ownerLabels = nil
+			})
+
+			JustBeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{Quotas: quotas})
+				h := buildWorkspace("home", workspacesv1alpha1.DisplayNameDefaultWorkspace, ownerSub)
+				h.Status.Space = workspacesv1alpha1.SpaceInfo{IsHome: true, Name: "home", Tier: "base"}
+				e := buildWorkspace("existing", "existing", ownerSub)
+				u := toolchainv1alpha1.UserSignup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "owner",
+						Namespace: workspacesv1alpha1.DefaultKubesawNamespace,
+						Labels:    ownerLabels,
+					},
+					Spec: toolchainv1alpha1.UserSignupSpec{
+						IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+							PropagatedClaims: toolchainv1alpha1.PropagatedClaims{Sub: ownerSub},
+						},
+					},
+				}
+				clientBuilder = clientBuilder.WithObjects(&h, &e, &u)
+			})
+
+			When("an override matches the owner's UserSignup labels", func() {
+				BeforeEach(func() {
+					ownerLabels = map[string]string{"plan": "premium"}
+					quotas.Overrides = []workspacesv1alpha1.WorkspacesConfigQuotaOverride{
+						{
+							UserSignupSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"plan": "premium"}},
+							MaxWorkspacesPerUser: ptr.To[int32](3),
+						},
+					}
+				})
+
+				It("allows the workspace", func() {
+					// given
+					w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+					// when
+					_, err := buildValidator().ValidateCreate(ctx, &w)
+
+					// then
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("an override matches the owner's tier", func() {
+				BeforeEach(func() {
+					quotas.Overrides = []workspacesv1alpha1.WorkspacesConfigQuotaOverride{
+						{Tier: "other", MaxWorkspacesPerUser: ptr.To[int32](5)},
+						{Tier: "base", MaxWorkspacesPerUser: ptr.To[int32](1)},
+					}
+				})
+
+				It("applies the override's limit", func() {
+					// given
+					w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+					// when
+					_, err := buildValidator().ValidateCreate(ctx, &w)
+
+					// then
+					Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+					Expect(err.Error()).To(ContainSubstring("already owns 2 workspaces out of the 1 allowed"))
+				})
+			})
+
+			When("no override matches the owner", func() {
+				BeforeEach(func() {
+					quotas.Overrides = []workspacesv1alpha1.WorkspacesConfigQuotaOverride{
+						{
+							UserSignupSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"plan": "premium"}},
+							Tier:                 "base",
+							MaxWorkspacesPerUser: ptr.To[int32](3),
+						},
+					}
+				})
+
+				It("applies the default limit", func() {
+					// given
+					w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+					// when
+					_, err := buildValidator().ValidateCreate(ctx, &w)
+
+					// then
+					Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+					Expect(err.Error()).To(ContainSubstring("already owns 2 workspaces out of the 2 allowed"))
+				})
+			})
+
+			It("rejects the workspace", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("already owns 2 workspaces out of the 2 allowed"))
+			})
+
+			It("allows workspaces of other owners", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", "other-sub")
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the owner's workspaces can not be listed", func() {
+			BeforeEach(func() {
+				clientBuilder = clientBuilder.WithInterceptorFuncs(interceptor.Funcs{
+					List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+						return fmt.Errorf("unexpected error listing InternalWorkspaces")
+					},
+				})
+			})
+
+			It("returns an internal error", func() {
+				// given
+				w := buildWorkspace("workspace", "my-workspace", ownerSub)
+
+				// when
+				_, err := buildValidator().ValidateCreate(ctx, &w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInternalError, "IsInternalError"))
+			})
+		})
+	})
+
+	Describe("Validator on update", func() {
+		var old workspacesv1alpha1.InternalWorkspace
+
+		BeforeEach(func() {
+			old = buildWorkspace("workspace", "my-workspace", ownerSub)
+			clientBuilder = clientBuilder.WithObjects(&old)
+		})
+
+		It("allows updates not changing the owner nor the display name", func() {
+			// given
+			w := old.DeepCopy()
+			w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+			w.Finalizers = nil
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("allows setting the default issuer on the owner", func() {
+			// given
+			withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+				Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+			})
+			w := old.DeepCopy()
+			w.Spec.Owner.JwtInfo.Issuer = "https://default-issuer"
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects changes of the owner's issuer without a transfer", func() {
+			// given
+			w := old.DeepCopy()
+			w.Spec.Owner.JwtInfo.Issuer = "https://other-issuer"
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+			Expect(err.Error()).To(ContainSubstring("spec.owner: Forbidden"))
+		})
+
+		It("rejects changes of the owner without a transfer", func() {
+			// given
+			w := old.DeepCopy()
+			w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+			Expect(err.Error()).To(ContainSubstring("spec.owner: Forbidden"))
+		})
+
+		It("rejects changes of the owner transferring to someone else", func() {
+			// given
+			w := old.DeepCopy()
+			w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+			w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "another-sub"}
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+		})
+
+		It("allows changes of the owner through a transfer", func() {
+			// given
+			w := old.DeepCopy()
+			w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+			w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "new-owner-sub"}
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the transfer is requested by a KubeSaw user", func() {
+			withRequester := func(username string) context.Context {
+				return admission.NewContextWithRequest(ctx, admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						UserInfo: authenticationv1.UserInfo{
+							Username: username,
+							Groups:   []string{"system:authenticated", toolchainv1alpha1.KubesawAuthenticatedUsername},
+						},
+					},
+				})
+			}
+
+			BeforeEach(func() {
+				old.Status.Owner.Username = "owner"
+			})
+
+			It("allows the owner to transfer the workspace", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+				w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "new-owner-sub"}
+
+				// when
+				_, err := buildValidator().ValidateUpdate(withRequester("owner"), &old, w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects transfers requested by other users", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+				w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "new-owner-sub"}
+
+				// when
+				_, err := buildValidator().ValidateUpdate(withRequester("admin"), &old, w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("only the owner can transfer the workspace"))
+			})
+		})
+
+		It("rejects transfers of the home workspace", func() {
+			// given
+			home := buildWorkspace("home", workspacesv1alpha1.DisplayNameDefaultWorkspace, ownerSub)
+			w := home.DeepCopy()
+			w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+			w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "new-owner-sub"}
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &home, w)
+
+			// then
+			Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+			Expect(err.Error()).To(ContainSubstring("the home workspace can not be transferred"))
+		})
+
+		When("the new owner already has a workspace with the same display name", func() {
+			BeforeEach(func() {
+				e := buildWorkspace("existing", "my-workspace", "new-owner-sub")
+				clientBuilder = clientBuilder.WithObjects(&e)
+			})
+
+			It("rejects the transfer", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+				w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "new-owner-sub"}
+
+				// when
+				_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+			})
+		})
+
+		When("the ownership transfer is disabled", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					Features: workspacesv1alpha1.WorkspacesConfigFeatures{OwnershipTransfer: ptr.To(false)},
+				})
+			})
+
+			It("rejects the transfer", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.Owner.JwtInfo.Sub = "new-owner-sub"
+				w.Annotations = map[string]string{workspacesv1alpha1.AnnotationOwnerTransfer: "new-owner-sub"}
+
+				// when
+				_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("ownership transfer is disabled"))
+			})
+		})
+
+		When("the community visibility is disabled", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					Features: workspacesv1alpha1.WorkspacesConfigFeatures{CommunityVisibility: ptr.To(false)},
+				})
+			})
+
+			It("rejects making the workspace community", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+
+				// when
+				_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("community visibility is disabled"))
+			})
+
+			It("allows other updates of community workspaces", func() {
+				// given
+				o := old.DeepCopy()
+				o.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+				w := o.DeepCopy()
+				w.Spec.DisplayName = "new-name"
+
+				// when
+				_, err := buildValidator().ValidateUpdate(ctx, o, w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the display name is reserved", func() {
+			BeforeEach(func() {
+				withConfig(workspacesv1alpha1.WorkspacesConfigSpec{
+					NameRules: workspacesv1alpha1.WorkspacesConfigNameRules{ReservedNames: []string{"my-workspace", "reserved"}},
+				})
+			})
+
+			It("allows updates of existing workspaces", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+
+				// when
+				_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects renaming workspaces to it", func() {
+				// given
+				w := old.DeepCopy()
+				w.Spec.DisplayName = "reserved"
+
+				// when
+				_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+				// then
+				Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+				Expect(err.Error()).To(ContainSubstring("reserved is a reserved name"))
+			})
+		})
+
+		It("rejects invalid display names", func() {
+			// given
+			w := old.DeepCopy()
+			w.Spec.DisplayName = "Not Valid"
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &old, w)
+
+			// then
+			Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+		})
+
+		It("rejects renaming the home workspace", func() {
+			// given
+			home := buildWorkspace("home", workspacesv1alpha1.DisplayNameDefaultWorkspace, ownerSub)
+			w := home.DeepCopy()
+			w.Spec.DisplayName = "not-home"
+
+			// when
+			_, err := buildValidator().ValidateUpdate(ctx, &home, w)
+
+			// then
+			Expect(err).To(MatchError(kerrors.IsInvalid, "IsInvalid"))
+			Expect(err.Error()).To(ContainSubstring("the home workspace can not be renamed"))
+		})
+	})
+})
@@ -23,17 +23,31 @@ import (
 
 type WorkspaceVisibility string
 
+type WorkspaceRole string
+
 const (
 	// WorkspaceVisibilityCommunity Community value for Workspaces visibility
 	WorkspaceVisibilityCommunity WorkspaceVisibility = "community"
 	// WorkspaceVisibilityPrivate Private value for Workspaces visibility
 	WorkspaceVisibilityPrivate WorkspaceVisibility = "private"
 
+	// WorkspaceRoleViewer grants read-only access to the Workspace
+	WorkspaceRoleViewer WorkspaceRole = "viewer"
+	// WorkspaceRoleContributor grants contributor access to the Workspace
+	WorkspaceRoleContributor WorkspaceRole = "contributor"
+	// WorkspaceRoleMaintainer grants maintainer access to the Workspace
+	WorkspaceRoleMaintainer WorkspaceRole = "maintainer"
+	// WorkspaceRoleAdmin grants admin access to the Workspace
+	WorkspaceRoleAdmin WorkspaceRole = "admin"
+
 	// LabelIsOwner if the requesting user is the owner of the workspace
 	LabelIsOwner string = workspacesv1alpha1.LabelInternalDomain + "is-owner"
 	// LabelHasDirectAccess if the requesting user has access to the workspace
 	// via a direct grant or via public-viewer
 	LabelHasDirectAccess string = workspacesv1alpha1.LabelInternalDomain + "has-direct-access"
+
+	// AnnotationManagedFields stores on the InternalWorkspace the managed fields of the Workspace
+	AnnotationManagedFields string = workspacesv1alpha1.LabelInternalDomain + "managed-fields"
 )
 
 // WorkspaceSpec defines the desired state of Workspace
@@ -43,6 +57,20 @@ type WorkspaceSpec struct {
 	Visibility WorkspaceVisibility `json:"visibility"`
 }
 
+// SpacePhase the provisioning phase of the Space backing a Workspace
+type SpacePhase string
+
+const (
+	// SpacePhaseProvisioning the Space is being provisioned
+	SpacePhaseProvisioning SpacePhase = "Provisioning"
+	// SpacePhaseProvisioned the Space is provisioned and ready
+	SpacePhaseProvisioned SpacePhase = "Provisioned"
+	// SpacePhaseProvisioningFailed the Space's provisioning failed
+	SpacePhaseProvisioningFailed SpacePhase = "ProvisioningFailed"
+	// SpacePhaseTerminating the Space is being deleted
+	SpacePhaseTerminating SpacePhase = "Terminating"
+)
+
 // SpaceInfo Information about a Space
 type SpaceInfo struct {
 	//+required
@@ -51,6 +79,33 @@ type SpaceInfo struct {
 	// TargetCluster contains the URL to the cluster where the workspace's namespaces live
 	//+optional
 	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Tier contains the name of the tier the Space is provisioned with
+	//+optional
+	Tier string `json:"tier,omitempty"`
+
+	// Phase contains the provisioning phase of the Space
+	//+optional
+	//+kubebuilder:validation:Enum:=Provisioning;Provisioned;ProvisioningFailed;Terminating
+	Phase SpacePhase `json:"phase,omitempty"`
+
+	// Message contains the reason why the Space's provisioning failed
+	//+optional
+	Message string `json:"message,omitempty"`
+
+	// Namespaces contains the namespaces provisioned for the workspace
+	//+optional
+	Namespaces []SpaceNamespace `json:"namespaces,omitempty"`
+}
+
+// SpaceNamespace a namespace provisioned for a workspace
+type SpaceNamespace struct {
+	//+required
+	Name string `json:"name"`
+
+	// Type the type of the namespace, e.g. default
+	//+optional
+	Type string `json:"type,omitempty"`
 }
 
 // UserInfoStatus User info stored in the status
@@ -59,14 +114,48 @@ type UserInfoStatus struct {
 	Email string `json:"email"`
 }
 
+// WorkspaceMember a user granted access to a Workspace
+type WorkspaceMember struct {
+	// Username the compliant username of the user
+	//+required
+	Username string `json:"username"`
+	// Role the role granted to the user
+	//+required
+	//+kubebuilder:validation:Enum:=viewer;contributor;maintainer;admin
+	Role WorkspaceRole `json:"role"`
+}
+
+// WorkspaceMemberList contains a list of WorkspaceMember
+type WorkspaceMemberList struct {
+	Items []WorkspaceMember `json:"items"`
+}
+
+// WorkspaceTransfer a request to transfer a Workspace to a new owner
+type WorkspaceTransfer struct {
+	// NewOwner the compliant username of the user the Workspace is transferred to
+	//+required
+	NewOwner string `json:"newOwner"`
+	// PreviousOwnerRole the role granted to the previous owner after the transfer.
+	// If not set, the previous owner loses access to the Workspace.
+	//+optional
+	//+kubebuilder:validation:Enum:=viewer;contributor;maintainer;admin
+	PreviousOwnerRole WorkspaceRole `json:"previousOwnerRole,omitempty"`
+}
+
 // WorkspaceStatus defines the observed state of Workspace
 type WorkspaceStatus struct {
 	//+optional
 	Space *SpaceInfo `json:"space,omitempty"`
 	//+optional
 	Owner *UserInfoStatus `json:"owner,omitempty"`
+	// Members contains the users granted access to the Workspace
+	//+optional
+	Members []WorkspaceMember `json:"members,omitempty"`
 	//+optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the most recent generation observed by the controller
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //+kubebuilder:object:root=true
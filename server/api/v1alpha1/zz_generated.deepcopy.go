@@ -28,6 +28,11 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceInfo) DeepCopyInto(out *SpaceInfo) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]SpaceNamespace, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceInfo.
@@ -40,6 +45,21 @@ func (in *SpaceInfo) DeepCopy() *SpaceInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceNamespace) DeepCopyInto(out *SpaceNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceNamespace.
+func (in *SpaceNamespace) DeepCopy() *SpaceNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfoStatus) DeepCopyInto(out *UserInfoStatus) {
 	*out = *in
@@ -114,6 +134,41 @@ func (in *WorkspaceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceMember) DeepCopyInto(out *WorkspaceMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceMember.
+func (in *WorkspaceMember) DeepCopy() *WorkspaceMember {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceMemberList) DeepCopyInto(out *WorkspaceMemberList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceMember, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceMemberList.
+func (in *WorkspaceMemberList) DeepCopy() *WorkspaceMemberList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceMemberList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 	*out = *in
@@ -135,13 +190,18 @@ func (in *WorkspaceStatus) DeepCopyInto(out *WorkspaceStatus) {
 	if in.Space != nil {
 		in, out := &in.Space, &out.Space
 		*out = new(SpaceInfo)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Owner != nil {
 		in, out := &in.Owner, &out.Owner
 		*out = new(UserInfoStatus)
 		**out = **in
 	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]WorkspaceMember, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -160,3 +220,18 @@ func (in *WorkspaceStatus) DeepCopy() *WorkspaceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceTransfer) DeepCopyInto(out *WorkspaceTransfer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceTransfer.
+func (in *WorkspaceTransfer) DeepCopy() *WorkspaceTransfer {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceTransfer)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -4,5 +4,8 @@ type ServerContextKey string
 
 const (
 	UserSubKey                 ServerContextKey = "user-sub"
+	UserIssuerKey              ServerContextKey = "user-issuer"
+	UserEmailKey               ServerContextKey = "user-email"
+	UserGroupsKey              ServerContextKey = "user-groups"
 	UserSignupComplaintNameKey ServerContextKey = "usersignup-complaintname"
 )
@@ -3,5 +3,6 @@ package core
 import "fmt"
 
 var (
-	ErrNotFound error = fmt.Errorf("resource not found")
+	ErrNotFound        error = fmt.Errorf("resource not found")
+	ErrUnauthenticated error = fmt.Errorf("unauthenticated request")
 )
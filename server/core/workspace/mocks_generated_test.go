@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/konflux-workspaces/workspaces/server/core/workspace (interfaces: WorkspaceUpdater,WorkspaceReader,WorkspaceLister,WorkspaceCreator)
+// Source: github.com/konflux-workspaces/workspaces/server/core/workspace (interfaces: WorkspaceUpdater,WorkspaceReader,WorkspaceLister,WorkspaceCreator,WorkspaceDeleter,WorkspaceMembersLister,WorkspaceMemberSetter,WorkspaceMemberRevoker,WorkspaceTransferrer,WorkspaceWatcher)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks_generated_test.go -package=workspace_test . WorkspaceUpdater,WorkspaceReader,WorkspaceLister,WorkspaceCreator
+//	mockgen -destination=mocks_generated_test.go -package=workspace_test . WorkspaceUpdater,WorkspaceReader,WorkspaceLister,WorkspaceCreator,WorkspaceDeleter,WorkspaceMembersLister,WorkspaceMemberSetter,WorkspaceMemberRevoker,WorkspaceTransferrer,WorkspaceWatcher
 //
 
 // Package workspace_test is a generated GoMock package.
@@ -15,6 +15,7 @@ import (
 
 	v1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 	gomock "go.uber.org/mock/gomock"
+	watch "k8s.io/apimachinery/pkg/watch"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -185,3 +186,236 @@ func (mr *MockWorkspaceCreatorMockRecorder) CreateUserWorkspace(arg0, arg1, arg2
 	varargs := append([]any{arg0, arg1, arg2}, arg3...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWorkspace", reflect.TypeOf((*MockWorkspaceCreator)(nil).CreateUserWorkspace), varargs...)
 }
+
+// MockWorkspaceDeleter is a mock of WorkspaceDeleter interface.
+type MockWorkspaceDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceDeleterMockRecorder
+}
+
+// MockWorkspaceDeleterMockRecorder is the mock recorder for MockWorkspaceDeleter.
+type MockWorkspaceDeleterMockRecorder struct {
+	mock *MockWorkspaceDeleter
+}
+
+// NewMockWorkspaceDeleter creates a new mock instance.
+func NewMockWorkspaceDeleter(ctrl *gomock.Controller) *MockWorkspaceDeleter {
+	mock := &MockWorkspaceDeleter{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceDeleter) EXPECT() *MockWorkspaceDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserWorkspace mocks base method.
+func (m *MockWorkspaceDeleter) DeleteUserWorkspace(arg0 context.Context, arg1 string, arg2 *v1alpha1.Workspace, arg3 ...client.DeleteOption) error {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUserWorkspace", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserWorkspace indicates an expected call of DeleteUserWorkspace.
+func (mr *MockWorkspaceDeleterMockRecorder) DeleteUserWorkspace(arg0, arg1, arg2 any, arg3 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserWorkspace", reflect.TypeOf((*MockWorkspaceDeleter)(nil).DeleteUserWorkspace), varargs...)
+}
+
+// MockWorkspaceMembersLister is a mock of WorkspaceMembersLister interface.
+type MockWorkspaceMembersLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMembersListerMockRecorder
+}
+
+// MockWorkspaceMembersListerMockRecorder is the mock recorder for MockWorkspaceMembersLister.
+type MockWorkspaceMembersListerMockRecorder struct {
+	mock *MockWorkspaceMembersLister
+}
+
+// NewMockWorkspaceMembersLister creates a new mock instance.
+func NewMockWorkspaceMembersLister(ctrl *gomock.Controller) *MockWorkspaceMembersLister {
+	mock := &MockWorkspaceMembersLister{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMembersListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceMembersLister) EXPECT() *MockWorkspaceMembersListerMockRecorder {
+	return m.recorder
+}
+
+// ListUserWorkspaceMembers mocks base method.
+func (m *MockWorkspaceMembersLister) ListUserWorkspaceMembers(arg0 context.Context, arg1, arg2, arg3 string, arg4 *v1alpha1.WorkspaceMemberList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserWorkspaceMembers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListUserWorkspaceMembers indicates an expected call of ListUserWorkspaceMembers.
+func (mr *MockWorkspaceMembersListerMockRecorder) ListUserWorkspaceMembers(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserWorkspaceMembers", reflect.TypeOf((*MockWorkspaceMembersLister)(nil).ListUserWorkspaceMembers), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockWorkspaceMemberSetter is a mock of WorkspaceMemberSetter interface.
+type MockWorkspaceMemberSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMemberSetterMockRecorder
+}
+
+// MockWorkspaceMemberSetterMockRecorder is the mock recorder for MockWorkspaceMemberSetter.
+type MockWorkspaceMemberSetterMockRecorder struct {
+	mock *MockWorkspaceMemberSetter
+}
+
+// NewMockWorkspaceMemberSetter creates a new mock instance.
+func NewMockWorkspaceMemberSetter(ctrl *gomock.Controller) *MockWorkspaceMemberSetter {
+	mock := &MockWorkspaceMemberSetter{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMemberSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceMemberSetter) EXPECT() *MockWorkspaceMemberSetterMockRecorder {
+	return m.recorder
+}
+
+// SetUserWorkspaceMember mocks base method.
+func (m *MockWorkspaceMemberSetter) SetUserWorkspaceMember(arg0 context.Context, arg1, arg2, arg3 string, arg4 *v1alpha1.WorkspaceMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserWorkspaceMember", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserWorkspaceMember indicates an expected call of SetUserWorkspaceMember.
+func (mr *MockWorkspaceMemberSetterMockRecorder) SetUserWorkspaceMember(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserWorkspaceMember", reflect.TypeOf((*MockWorkspaceMemberSetter)(nil).SetUserWorkspaceMember), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockWorkspaceMemberRevoker is a mock of WorkspaceMemberRevoker interface.
+type MockWorkspaceMemberRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceMemberRevokerMockRecorder
+}
+
+// MockWorkspaceMemberRevokerMockRecorder is the mock recorder for MockWorkspaceMemberRevoker.
+type MockWorkspaceMemberRevokerMockRecorder struct {
+	mock *MockWorkspaceMemberRevoker
+}
+
+// NewMockWorkspaceMemberRevoker creates a new mock instance.
+func NewMockWorkspaceMemberRevoker(ctrl *gomock.Controller) *MockWorkspaceMemberRevoker {
+	mock := &MockWorkspaceMemberRevoker{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceMemberRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceMemberRevoker) EXPECT() *MockWorkspaceMemberRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeUserWorkspaceMember mocks base method.
+func (m *MockWorkspaceMemberRevoker) RevokeUserWorkspaceMember(arg0 context.Context, arg1, arg2, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserWorkspaceMember", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserWorkspaceMember indicates an expected call of RevokeUserWorkspaceMember.
+func (mr *MockWorkspaceMemberRevokerMockRecorder) RevokeUserWorkspaceMember(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserWorkspaceMember", reflect.TypeOf((*MockWorkspaceMemberRevoker)(nil).RevokeUserWorkspaceMember), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockWorkspaceTransferrer is a mock of WorkspaceTransferrer interface.
+type MockWorkspaceTransferrer struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceTransferrerMockRecorder
+}
+
+// MockWorkspaceTransferrerMockRecorder is the mock recorder for MockWorkspaceTransferrer.
+type MockWorkspaceTransferrerMockRecorder struct {
+	mock *MockWorkspaceTransferrer
+}
+
+// NewMockWorkspaceTransferrer creates a new mock instance.
+func NewMockWorkspaceTransferrer(ctrl *gomock.Controller) *MockWorkspaceTransferrer {
+	mock := &MockWorkspaceTransferrer{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceTransferrerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceTransferrer) EXPECT() *MockWorkspaceTransferrerMockRecorder {
+	return m.recorder
+}
+
+// TransferUserWorkspace mocks base method.
+func (m *MockWorkspaceTransferrer) TransferUserWorkspace(arg0 context.Context, arg1, arg2, arg3 string, arg4 *v1alpha1.WorkspaceTransfer, arg5 *v1alpha1.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferUserWorkspace", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferUserWorkspace indicates an expected call of TransferUserWorkspace.
+func (mr *MockWorkspaceTransferrerMockRecorder) TransferUserWorkspace(arg0, arg1, arg2, arg3, arg4, arg5 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferUserWorkspace", reflect.TypeOf((*MockWorkspaceTransferrer)(nil).TransferUserWorkspace), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// MockWorkspaceWatcher is a mock of WorkspaceWatcher interface.
+type MockWorkspaceWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceWatcherMockRecorder
+}
+
+// MockWorkspaceWatcherMockRecorder is the mock recorder for MockWorkspaceWatcher.
+type MockWorkspaceWatcherMockRecorder struct {
+	mock *MockWorkspaceWatcher
+}
+
+// NewMockWorkspaceWatcher creates a new mock instance.
+func NewMockWorkspaceWatcher(ctrl *gomock.Controller) *MockWorkspaceWatcher {
+	mock := &MockWorkspaceWatcher{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceWatcher) EXPECT() *MockWorkspaceWatcherMockRecorder {
+	return m.recorder
+}
+
+// WatchUserWorkspaces mocks base method.
+func (m *MockWorkspaceWatcher) WatchUserWorkspaces(arg0 context.Context, arg1 string, arg2 ...client.ListOption) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchUserWorkspaces", varargs...)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchUserWorkspaces indicates an expected call of WatchUserWorkspaces.
+func (mr *MockWorkspaceWatcherMockRecorder) WatchUserWorkspaces(arg0, arg1 any, arg2 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUserWorkspaces", reflect.TypeOf((*MockWorkspaceWatcher)(nil).WatchUserWorkspaces), varargs...)
+}
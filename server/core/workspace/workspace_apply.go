@@ -0,0 +1,96 @@
+package workspace
+
+import (
+	"fmt"
+	"sync"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/yaml"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+// workspaceFieldManager tracks the managers of Workspaces' fields and merges apply patches.
+// The status can not be applied, as it is not managed by users.
+var workspaceFieldManager = sync.OnceValues(func() (*managedfields.FieldManager, error) {
+	s := runtime.NewScheme()
+	if err := restworkspacesv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+
+	gv := restworkspacesv1alpha1.GroupVersion
+	resetFields := map[fieldpath.APIVersion]*fieldpath.Set{
+		fieldpath.APIVersion(gv.String()): fieldpath.NewSet(fieldpath.MakePathOrDie("status")),
+	}
+	return managedfields.NewDefaultFieldManager(
+		managedfields.NewDeducedTypeConverter(), s, s, s, gv.WithKind("Workspace"), gv, "", resetFields)
+})
+
+// applyApplyPatch merges the apply configuration into the workspace,
+// taking the ownership of the applied fields for the command's field manager
+func (h *PatchWorkspaceHandler) applyApplyPatch(w *restworkspacesv1alpha1.Workspace, command PatchWorkspaceCommand) (*restworkspacesv1alpha1.Workspace, error) {
+	fm, err := workspaceFieldManager()
+	if err != nil {
+		return nil, kerrors.NewInternalError(err)
+	}
+
+	// decode apply configuration
+	ac := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(command.Patch, &ac.Object); err != nil {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error decoding apply patch: %v", err))
+	}
+	if ac.GetName() != w.Name || ac.GetNamespace() != w.Namespace {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf(
+			"the name of the applied object (%s/%s) does not match the name on the URL (%s/%s)",
+			ac.GetNamespace(), ac.GetName(), w.Namespace, w.Name))
+	}
+
+	// merge apply configuration
+	o, err := fm.Apply(withTypeMeta(w), ac, command.FieldManager, command.Force)
+	if err != nil {
+		return nil, err
+	}
+	pw, ok := o.(*restworkspacesv1alpha1.Workspace)
+	if !ok {
+		return nil, kerrors.NewInternalError(fmt.Errorf("unexpected object of type %T applying patch", o))
+	}
+
+	// the status is not affected by apply patches
+	w.Status.DeepCopyInto(&pw.Status)
+	return pw, nil
+}
+
+// trackUpdate records the fields changed by the manager updating the workspace.
+// Fields are tracked only for Workspaces that have already been applied.
+func trackUpdate(w, pw *restworkspacesv1alpha1.Workspace, manager string) (*restworkspacesv1alpha1.Workspace, error) {
+	if len(w.ManagedFields) == 0 {
+		return pw, nil
+	}
+
+	fm, err := workspaceFieldManager()
+	if err != nil {
+		return nil, kerrors.NewInternalError(err)
+	}
+
+	o, err := fm.Update(withTypeMeta(w), withTypeMeta(pw), manager)
+	if err != nil {
+		return nil, err
+	}
+	uw, ok := o.(*restworkspacesv1alpha1.Workspace)
+	if !ok {
+		return nil, kerrors.NewInternalError(fmt.Errorf("unexpected object of type %T tracking update", o))
+	}
+	return uw, nil
+}
+
+// withTypeMeta returns a copy of the workspace with the Workspace's TypeMeta set,
+// as the field manager relies on it to identify the object's version
+func withTypeMeta(w *restworkspacesv1alpha1.Workspace) *restworkspacesv1alpha1.Workspace {
+	cw := w.DeepCopy()
+	cw.SetGroupVersionKind(restworkspacesv1alpha1.GroupVersion.WithKind("Workspace"))
+	return cw
+}
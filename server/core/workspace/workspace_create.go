@@ -4,9 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // CreateWorkspaceCommand contains the information needed to create a new workspace
@@ -34,10 +39,21 @@ func NewCreateWorkspaceHandler(creator WorkspaceCreator) *CreateWorkspaceHandler
 func (h *CreateWorkspaceHandler) Handle(ctx context.Context, request CreateWorkspaceCommand) (*CreateWorkspaceResponse, error) {
 	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
 	if !ok {
-		return nil, fmt.Errorf("unauthenticated request")
+		return nil, core.ErrUnauthenticated
+	}
+
+	// users can create workspaces only in their own namespace
+	if request.Workspace.Namespace != u {
+		return nil, kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			request.Workspace.Name,
+			fmt.Errorf("workspaces can only be created in the requesting user's namespace"))
 	}
 
-	// TODO: validate the workspace; maybe punt to a webhook down the line?
+	// validate the workspace
+	if err := validateCreateWorkspace(&request.Workspace); err != nil {
+		return nil, err
+	}
 
 	// write the workspace
 	workspace := request.Workspace.DeepCopy()
@@ -51,3 +67,37 @@ func (h *CreateWorkspaceHandler) Handle(ctx context.Context, request CreateWorks
 	}
 	return response, nil
 }
+
+// validateCreateWorkspace checks that the workspace requested for creation is well-formed
+func validateCreateWorkspace(w *restworkspacesv1alpha1.Workspace) error {
+	errs := field.ErrorList{}
+
+	// the name is used as display name and as prefix for the backing resources' names
+	if w.Name == "" {
+		errs = append(errs, field.Required(field.NewPath("metadata", "name"), "name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(w.Name) {
+			errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), w.Name, msg))
+		}
+	}
+
+	switch w.Spec.Visibility {
+	case restworkspacesv1alpha1.WorkspaceVisibilityCommunity, restworkspacesv1alpha1.WorkspaceVisibilityPrivate:
+	default:
+		errs = append(errs, field.NotSupported(
+			field.NewPath("spec", "visibility"),
+			w.Spec.Visibility,
+			[]string{
+				string(restworkspacesv1alpha1.WorkspaceVisibilityCommunity),
+				string(restworkspacesv1alpha1.WorkspaceVisibilityPrivate),
+			}))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(
+		restworkspacesv1alpha1.GroupVersion.WithKind("Workspace").GroupKind(),
+		w.Name,
+		errs)
+}
@@ -8,6 +8,8 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
@@ -29,7 +31,17 @@ var _ = Describe("", func() {
 		ctrl = gomock.NewController(GinkgoT())
 		ctx = context.Background()
 		creator = NewMockWorkspaceCreator(ctrl)
-		request = workspace.CreateWorkspaceCommand{Workspace: restworkspacesv1alpha1.Workspace{}}
+		request = workspace.CreateWorkspaceCommand{
+			Workspace: restworkspacesv1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "workspace",
+					Namespace: "foo",
+				},
+				Spec: restworkspacesv1alpha1.WorkspaceSpec{
+					Visibility: restworkspacesv1alpha1.WorkspaceVisibilityPrivate,
+				},
+			},
+		}
 		handler = *workspace.NewCreateWorkspaceHandler(creator)
 	})
 
@@ -63,6 +75,39 @@ var _ = Describe("", func() {
 		}))
 	})
 
+	It("should not allow creation in other users' namespaces", func() {
+		// given
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, "bar")
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(kerrors.IsForbidden(err)).To(BeTrue())
+	})
+
+	DescribeTable("should reject invalid workspaces",
+		func(mutateFunc func(*restworkspacesv1alpha1.Workspace)) {
+			// given
+			ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, "foo")
+			mutateFunc(&request.Workspace)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+		},
+		Entry("empty name", func(w *restworkspacesv1alpha1.Workspace) { w.Name = "" }),
+		Entry("name is not a DNS label", func(w *restworkspacesv1alpha1.Workspace) { w.Name = "Not_Valid" }),
+		Entry("empty visibility", func(w *restworkspacesv1alpha1.Workspace) { w.Spec.Visibility = "" }),
+		Entry("unknown visibility", func(w *restworkspacesv1alpha1.Workspace) { w.Spec.Visibility = "public" }),
+	)
+
 	It("should forward errors from the workspace creator", func() {
 		// given
 		username := "foo"
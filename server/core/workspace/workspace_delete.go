@@ -0,0 +1,62 @@
+package workspace
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+)
+
+// DeleteWorkspaceCommand contains the information needed to delete a Workspace the user owns
+type DeleteWorkspaceCommand struct {
+	Name  string
+	Owner string
+}
+
+// DeleteWorkspaceResponse contains the outcome of the deletion
+type DeleteWorkspaceResponse struct{}
+
+// WorkspaceDeleter is the interface the data source needs to implement to allow the DeleteWorkspaceHandler to delete data from it
+type WorkspaceDeleter interface {
+	DeleteUserWorkspace(ctx context.Context, user string, obj *restworkspacesv1alpha1.Workspace, opts ...client.DeleteOption) error
+}
+
+// DeleteWorkspaceHandler processes DeleteWorkspaceCommand and returns DeleteWorkspaceResponse deleting data from a WorkspaceDeleter
+type DeleteWorkspaceHandler struct {
+	deleter WorkspaceDeleter
+}
+
+// NewDeleteWorkspaceHandler creates a new DeleteWorkspaceHandler that uses a specified WorkspaceDeleter
+func NewDeleteWorkspaceHandler(deleter WorkspaceDeleter) *DeleteWorkspaceHandler {
+	return &DeleteWorkspaceHandler{deleter: deleter}
+}
+
+// Handle handles a DeleteWorkspaceCommand and returns a DeleteWorkspaceResponse or an error
+func (h *DeleteWorkspaceHandler) Handle(ctx context.Context, command DeleteWorkspaceCommand) (*DeleteWorkspaceResponse, error) {
+	// authorization
+	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
+	if !ok {
+		return nil, core.ErrUnauthenticated
+	}
+
+	// data access
+	w := restworkspacesv1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      command.Name,
+			Namespace: command.Owner,
+		},
+	}
+	log.FromContext(ctx).Debug("deleting workspace", "workspace", w)
+	opts := &client.DeleteOptions{}
+	if err := h.deleter.DeleteUserWorkspace(ctx, u, &w, opts); err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &DeleteWorkspaceResponse{}, nil
+}
@@ -0,0 +1,87 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Delete", func() {
+	var (
+		ctrl              *gomock.Controller
+		ctx               context.Context
+		deleter           *MockWorkspaceDeleter
+		request           workspace.DeleteWorkspaceCommand
+		handler           workspace.DeleteWorkspaceHandler
+		expectedWorkspace *restworkspacesv1alpha1.Workspace
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		deleter = NewMockWorkspaceDeleter(ctrl)
+		request = workspace.DeleteWorkspaceCommand{Name: "workspace", Owner: "foo"}
+		handler = *workspace.NewDeleteWorkspaceHandler(deleter)
+		expectedWorkspace = &restworkspacesv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "workspace", Namespace: "foo"},
+		}
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	It("should not allow unauthenticated requests", func() {
+		// don't set the "user" value within ctx
+
+		response, err := handler.Handle(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(fmt.Errorf("unauthenticated request")))
+		Expect(response).To(BeNil())
+	})
+
+	It("should allow authenticated requests", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		opts := &client.DeleteOptions{}
+		deleter.EXPECT().
+			DeleteUserWorkspace(ctx, username, expectedWorkspace, opts).
+			Return(nil)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(Equal(&workspace.DeleteWorkspaceResponse{}))
+	})
+
+	It("should forward errors from the workspace deleter", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		opts := &client.DeleteOptions{}
+		error := fmt.Errorf("Failed to delete workspace!")
+		deleter.EXPECT().
+			DeleteUserWorkspace(ctx, username, expectedWorkspace, opts).
+			Return(error)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(error))
+	})
+})
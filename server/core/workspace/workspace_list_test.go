@@ -7,8 +7,11 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
@@ -64,6 +67,45 @@ var _ = Describe("WorkspaceList", func() {
 		}))
 	})
 
+	It("should forward pagination options to the workspace lister", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		request.Limit = 10
+		request.Continue = "token"
+		lister.EXPECT().
+			ListUserWorkspaces(ctx, username, &restworkspacesv1alpha1.WorkspaceList{}, &client.ListOptions{Limit: 10, Continue: "token"}).
+			Return(nil)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).NotTo(BeNil())
+	})
+
+	It("should forward selectors to the workspace lister", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		request.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "foo"})
+		request.FieldSelector = fields.OneTermEqualSelector("spec.visibility", "community")
+		lister.EXPECT().
+			ListUserWorkspaces(ctx, username, &restworkspacesv1alpha1.WorkspaceList{}, &client.ListOptions{
+				LabelSelector: request.LabelSelector,
+				FieldSelector: request.FieldSelector,
+			}).
+			Return(nil)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).NotTo(BeNil())
+	})
+
 	It("should forward errors from the workspace reader", func() {
 		// given
 		username := "foo"
@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"context"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+)
+
+// ListWorkspaceMembersQuery contains the information needed to retrieve the members of a Workspace
+type ListWorkspaceMembersQuery struct {
+	Name  string
+	Owner string
+}
+
+// ListWorkspaceMembersResponse contains the members of the requested Workspace
+type ListWorkspaceMembersResponse struct {
+	Members *restworkspacesv1alpha1.WorkspaceMemberList
+}
+
+// WorkspaceMembersLister is the interface the data source needs to implement to allow the ListWorkspaceMembersHandler to fetch data from it
+type WorkspaceMembersLister interface {
+	ListUserWorkspaceMembers(ctx context.Context, user, owner, space string, members *restworkspacesv1alpha1.WorkspaceMemberList) error
+}
+
+// ListWorkspaceMembersHandler processes ListWorkspaceMembersQuery and returns ListWorkspaceMembersResponse fetching data from a WorkspaceMembersLister
+type ListWorkspaceMembersHandler struct {
+	lister WorkspaceMembersLister
+}
+
+// NewListWorkspaceMembersHandler creates a new ListWorkspaceMembersHandler that uses a specified WorkspaceMembersLister
+func NewListWorkspaceMembersHandler(lister WorkspaceMembersLister) *ListWorkspaceMembersHandler {
+	return &ListWorkspaceMembersHandler{lister: lister}
+}
+
+// Handle handles a ListWorkspaceMembersQuery and returns a ListWorkspaceMembersResponse or an error
+func (h *ListWorkspaceMembersHandler) Handle(ctx context.Context, query ListWorkspaceMembersQuery) (*ListWorkspaceMembersResponse, error) {
+	// authorization
+	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
+	if !ok {
+		return nil, core.ErrUnauthenticated
+	}
+
+	// data access
+	mm := restworkspacesv1alpha1.WorkspaceMemberList{}
+	if err := h.lister.ListUserWorkspaceMembers(ctx, u, query.Owner, query.Name, &mm); err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &ListWorkspaceMembersResponse{
+		Members: &mm,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("ListMembers", func() {
+	var (
+		ctrl    *gomock.Controller
+		ctx     context.Context
+		lister  *MockWorkspaceMembersLister
+		request workspace.ListWorkspaceMembersQuery
+		handler workspace.ListWorkspaceMembersHandler
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		lister = NewMockWorkspaceMembersLister(ctrl)
+		request = workspace.ListWorkspaceMembersQuery{Name: "workspace", Owner: "foo"}
+		handler = *workspace.NewListWorkspaceMembersHandler(lister)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	It("should not allow unauthenticated requests", func() {
+		// don't set the "user" value within ctx
+
+		response, err := handler.Handle(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(fmt.Errorf("unauthenticated request")))
+		Expect(response).To(BeNil())
+	})
+
+	It("should allow authenticated requests", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		expectedMembers := restworkspacesv1alpha1.WorkspaceMemberList{
+			Items: []restworkspacesv1alpha1.WorkspaceMember{
+				{Username: "bar", Role: restworkspacesv1alpha1.WorkspaceRoleViewer},
+			},
+		}
+		lister.EXPECT().
+			ListUserWorkspaceMembers(ctx, username, request.Owner, request.Name, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ string, mm *restworkspacesv1alpha1.WorkspaceMemberList) error {
+				expectedMembers.DeepCopyInto(mm)
+				return nil
+			})
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).NotTo(BeNil())
+		Expect(*response.Members).To(Equal(expectedMembers))
+	})
+
+	It("should forward errors from the members lister", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		error := fmt.Errorf("Failed to list members!")
+		lister.EXPECT().
+			ListUserWorkspaceMembers(ctx, username, request.Owner, request.Name, gomock.Any()).
+			Return(error)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(error))
+	})
+})
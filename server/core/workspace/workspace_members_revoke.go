@@ -0,0 +1,52 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/konflux-workspaces/workspaces/server/core"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+)
+
+// RevokeWorkspaceMemberCommand contains the information needed to revoke a user's access to a Workspace
+type RevokeWorkspaceMemberCommand struct {
+	Name     string
+	Owner    string
+	Username string
+}
+
+// RevokeWorkspaceMemberResponse contains the outcome of the revocation
+type RevokeWorkspaceMemberResponse struct{}
+
+// WorkspaceMemberRevoker is the interface the data source needs to implement to allow the RevokeWorkspaceMemberHandler to remove data from it
+type WorkspaceMemberRevoker interface {
+	RevokeUserWorkspaceMember(ctx context.Context, user, owner, space, username string) error
+}
+
+// RevokeWorkspaceMemberHandler processes RevokeWorkspaceMemberCommand and returns RevokeWorkspaceMemberResponse removing data from a WorkspaceMemberRevoker
+type RevokeWorkspaceMemberHandler struct {
+	revoker WorkspaceMemberRevoker
+}
+
+// NewRevokeWorkspaceMemberHandler creates a new RevokeWorkspaceMemberHandler that uses a specified WorkspaceMemberRevoker
+func NewRevokeWorkspaceMemberHandler(revoker WorkspaceMemberRevoker) *RevokeWorkspaceMemberHandler {
+	return &RevokeWorkspaceMemberHandler{revoker: revoker}
+}
+
+// Handle handles a RevokeWorkspaceMemberCommand and returns a RevokeWorkspaceMemberResponse or an error
+func (h *RevokeWorkspaceMemberHandler) Handle(ctx context.Context, command RevokeWorkspaceMemberCommand) (*RevokeWorkspaceMemberResponse, error) {
+	// authorization
+	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
+	if !ok {
+		return nil, core.ErrUnauthenticated
+	}
+
+	// data access
+	log.FromContext(ctx).Debug("revoking workspace member", "username", command.Username)
+	if err := h.revoker.RevokeUserWorkspaceMember(ctx, u, command.Owner, command.Name, command.Username); err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &RevokeWorkspaceMemberResponse{}, nil
+}
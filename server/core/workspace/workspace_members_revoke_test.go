@@ -0,0 +1,76 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+)
+
+var _ = Describe("RevokeMember", func() {
+	var (
+		ctrl    *gomock.Controller
+		ctx     context.Context
+		revoker *MockWorkspaceMemberRevoker
+		request workspace.RevokeWorkspaceMemberCommand
+		handler workspace.RevokeWorkspaceMemberHandler
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		revoker = NewMockWorkspaceMemberRevoker(ctrl)
+		request = workspace.RevokeWorkspaceMemberCommand{Name: "workspace", Owner: "foo", Username: "bar"}
+		handler = *workspace.NewRevokeWorkspaceMemberHandler(revoker)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	It("should not allow unauthenticated requests", func() {
+		// don't set the "user" value within ctx
+
+		response, err := handler.Handle(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(fmt.Errorf("unauthenticated request")))
+		Expect(response).To(BeNil())
+	})
+
+	It("should allow authenticated requests", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		revoker.EXPECT().
+			RevokeUserWorkspaceMember(ctx, username, request.Owner, request.Name, request.Username).
+			Return(nil)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(Equal(&workspace.RevokeWorkspaceMemberResponse{}))
+	})
+
+	It("should forward errors from the member revoker", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		error := fmt.Errorf("Failed to revoke member!")
+		revoker.EXPECT().
+			RevokeUserWorkspaceMember(ctx, username, request.Owner, request.Name, request.Username).
+			Return(error)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(error))
+	})
+})
@@ -0,0 +1,106 @@
+package workspace
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+)
+
+// SupportedWorkspaceRoles the roles that can be granted to a Workspace's members
+var SupportedWorkspaceRoles = []string{
+	string(restworkspacesv1alpha1.WorkspaceRoleViewer),
+	string(restworkspacesv1alpha1.WorkspaceRoleContributor),
+	string(restworkspacesv1alpha1.WorkspaceRoleMaintainer),
+	string(restworkspacesv1alpha1.WorkspaceRoleAdmin),
+}
+
+// SetWorkspaceMemberCommand contains the information needed to grant a user access to a Workspace,
+// or to change the role already granted to the user
+type SetWorkspaceMemberCommand struct {
+	Name   string
+	Owner  string
+	Member restworkspacesv1alpha1.WorkspaceMember
+}
+
+// SetWorkspaceMemberResponse contains the member as stored in the data source
+type SetWorkspaceMemberResponse struct {
+	Member *restworkspacesv1alpha1.WorkspaceMember
+}
+
+// WorkspaceMemberSetter is the interface the data source needs to implement to allow the SetWorkspaceMemberHandler to store data into it
+type WorkspaceMemberSetter interface {
+	SetUserWorkspaceMember(ctx context.Context, user, owner, space string, member *restworkspacesv1alpha1.WorkspaceMember) error
+}
+
+// SetWorkspaceMemberHandler processes SetWorkspaceMemberCommand and returns SetWorkspaceMemberResponse storing data into a WorkspaceMemberSetter
+type SetWorkspaceMemberHandler struct {
+	setter WorkspaceMemberSetter
+}
+
+// NewSetWorkspaceMemberHandler creates a new SetWorkspaceMemberHandler that uses a specified WorkspaceMemberSetter
+func NewSetWorkspaceMemberHandler(setter WorkspaceMemberSetter) *SetWorkspaceMemberHandler {
+	return &SetWorkspaceMemberHandler{setter: setter}
+}
+
+// Handle handles a SetWorkspaceMemberCommand and returns a SetWorkspaceMemberResponse or an error
+func (h *SetWorkspaceMemberHandler) Handle(ctx context.Context, command SetWorkspaceMemberCommand) (*SetWorkspaceMemberResponse, error) {
+	// authorization
+	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
+	if !ok {
+		return nil, core.ErrUnauthenticated
+	}
+
+	// validate command
+	if err := validateWorkspaceMember(command.Name, &command.Member); err != nil {
+		return nil, err
+	}
+
+	// data access
+	m := command.Member
+	log.FromContext(ctx).Debug("setting workspace member", "member", m)
+	if err := h.setter.SetUserWorkspaceMember(ctx, u, command.Owner, command.Name, &m); err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &SetWorkspaceMemberResponse{
+		Member: &m,
+	}, nil
+}
+
+// validateWorkspaceMember checks that the member to set is well-formed
+func validateWorkspaceMember(workspace string, m *restworkspacesv1alpha1.WorkspaceMember) error {
+	errs := field.ErrorList{}
+
+	if m.Username == "" {
+		errs = append(errs, field.Required(field.NewPath("username"), "username is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(m.Username) {
+			errs = append(errs, field.Invalid(field.NewPath("username"), m.Username, msg))
+		}
+	}
+
+	switch m.Role {
+	case restworkspacesv1alpha1.WorkspaceRoleViewer,
+		restworkspacesv1alpha1.WorkspaceRoleContributor,
+		restworkspacesv1alpha1.WorkspaceRoleMaintainer,
+		restworkspacesv1alpha1.WorkspaceRoleAdmin:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("role"), m.Role, SupportedWorkspaceRoles))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(
+		restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceMember").GroupKind(),
+		workspace,
+		errs)
+}
@@ -0,0 +1,106 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("SetMember", func() {
+	var (
+		ctrl    *gomock.Controller
+		ctx     context.Context
+		setter  *MockWorkspaceMemberSetter
+		request workspace.SetWorkspaceMemberCommand
+		handler workspace.SetWorkspaceMemberHandler
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		setter = NewMockWorkspaceMemberSetter(ctrl)
+		request = workspace.SetWorkspaceMemberCommand{
+			Name:  "workspace",
+			Owner: "foo",
+			Member: restworkspacesv1alpha1.WorkspaceMember{
+				Username: "bar",
+				Role:     restworkspacesv1alpha1.WorkspaceRoleContributor,
+			},
+		}
+		handler = *workspace.NewSetWorkspaceMemberHandler(setter)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	It("should not allow unauthenticated requests", func() {
+		// don't set the "user" value within ctx
+
+		response, err := handler.Handle(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(fmt.Errorf("unauthenticated request")))
+		Expect(response).To(BeNil())
+	})
+
+	It("should allow authenticated requests", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		setter.EXPECT().
+			SetUserWorkspaceMember(ctx, username, request.Owner, request.Name, &request.Member).
+			Return(nil)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(Equal(&workspace.SetWorkspaceMemberResponse{Member: &request.Member}))
+	})
+
+	DescribeTable("should reject invalid members", func(member restworkspacesv1alpha1.WorkspaceMember) {
+		// given
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, "foo")
+		request.Member = member
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(kerrors.IsInvalid(err)).To(BeTrue())
+	},
+		Entry("empty username", restworkspacesv1alpha1.WorkspaceMember{Role: restworkspacesv1alpha1.WorkspaceRoleViewer}),
+		Entry("invalid username", restworkspacesv1alpha1.WorkspaceMember{Username: "Not_Valid", Role: restworkspacesv1alpha1.WorkspaceRoleViewer}),
+		Entry("empty role", restworkspacesv1alpha1.WorkspaceMember{Username: "bar"}),
+		Entry("unsupported role", restworkspacesv1alpha1.WorkspaceMember{Username: "bar", Role: "owner"}),
+	)
+
+	It("should forward errors from the member setter", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		error := fmt.Errorf("Failed to set member!")
+		setter.EXPECT().
+			SetUserWorkspaceMember(ctx, username, request.Owner, request.Name, &request.Member).
+			Return(error)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(error))
+	})
+})
@@ -3,13 +3,17 @@ package workspace
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/konflux-workspaces/workspaces/server/core"
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
 	"github.com/konflux-workspaces/workspaces/server/log"
 
@@ -23,24 +27,36 @@ type PatchWorkspaceCommand struct {
 	Workspace string
 	Patch     []byte
 	PatchType types.PatchType
+
+	// FieldManager is the name of the actor applying the patch.
+	// It is required for apply patches.
+	FieldManager string
+	// Force makes an apply patch take the ownership of fields owned by other managers.
+	// It can be set only for apply patches.
+	Force bool
 }
 
 // PatchWorkspaceResponse contains the workspace the user requested
 type PatchWorkspaceResponse struct {
 	Workspace *restworkspacesv1alpha1.Workspace
+	// Created is true if the workspace has been created by an apply patch
+	Created bool
 }
 
 // PatchWorkspaceHandler processes PatchWorkspaceCommand and returns PatchWorkspaceResponse fetching data from a WorkspacePatcher
 type PatchWorkspaceHandler struct {
 	reader  WorkspaceReader
 	updater WorkspaceUpdater
+	creator WorkspaceCreator
 }
 
-// NewPatchWorkspaceHandler creates a new PatchWorkspaceHandler that uses a specified WorkspacePatcher
-func NewPatchWorkspaceHandler(reader WorkspaceReader, updater WorkspaceUpdater) *PatchWorkspaceHandler {
+// NewPatchWorkspaceHandler creates a new PatchWorkspaceHandler that uses a specified WorkspacePatcher.
+// The creator is used to create the workspaces that do not exist yet when an apply patch is requested.
+func NewPatchWorkspaceHandler(reader WorkspaceReader, updater WorkspaceUpdater, creator WorkspaceCreator) *PatchWorkspaceHandler {
 	return &PatchWorkspaceHandler{
 		reader:  reader,
 		updater: updater,
+		creator: creator,
 	}
 }
 
@@ -50,22 +66,29 @@ func (h *PatchWorkspaceHandler) Handle(ctx context.Context, command PatchWorkspa
 	// If required, implement here complex logic like multiple-domains filtering, etc
 	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
 	if !ok {
-		return nil, fmt.Errorf("unauthenticated request")
+		return nil, core.ErrUnauthenticated
 	}
 
 	// validate query
 	// TODO: sanitize input, block reserved labels, etc
+	if err := validatePatchWorkspace(command); err != nil {
+		return nil, err
+	}
 
 	// retrieve workspace
 	w := workspacesv1alpha1.Workspace{}
 	if err := h.reader.ReadUserWorkspace(ctx, u, command.Owner, command.Workspace, &w); err != nil {
+		// apply patches create the workspaces that do not exist yet
+		if command.PatchType == types.ApplyPatchType && kerrors.IsNotFound(err) {
+			return h.create(ctx, u, command)
+		}
 		return nil, err
 	}
 
 	// apply patch
 	pw, err := h.applyPatch(&w, command)
 	if err != nil {
-		return nil, fmt.Errorf("error patching Workspace %s/%s: %w", command.Owner, command.Workspace, err)
+		return nil, err
 	}
 
 	log.FromContext(ctx).Debug("updating workspace", "workspace", pw)
@@ -81,14 +104,82 @@ func (h *PatchWorkspaceHandler) Handle(ctx context.Context, command PatchWorkspa
 }
 
 func (h *PatchWorkspaceHandler) applyPatch(w *workspacesv1alpha1.Workspace, command PatchWorkspaceCommand) (*workspacesv1alpha1.Workspace, error) {
+	var pw *workspacesv1alpha1.Workspace
+	var err error
 	switch command.PatchType {
 	case types.MergePatchType:
-		return h.applyMergePatch(w, command.Patch)
+		pw, err = h.applyMergePatch(w, command.Patch)
 	case types.StrategicMergePatchType:
-		return h.applyStrategicMergePatch(w, command.Patch)
+		pw, err = h.applyStrategicMergePatch(w, command.Patch)
+	case types.JSONPatchType:
+		pw, err = h.applyJSONPatch(w, command.Patch)
+	case types.ApplyPatchType:
+		return h.applyApplyPatch(w, command)
 	default:
 		return nil, fmt.Errorf("unsupported patch type: %s", command.PatchType)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the name and the namespace identify the workspace and can not be patched
+	if pw.Name != w.Name || pw.Namespace != w.Namespace {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf(
+			"the name of the patched object (%s/%s) does not match the name on the URL (%s/%s)",
+			pw.Namespace, pw.Name, w.Namespace, w.Name))
+	}
+
+	// record the fields changed by the manager
+	return trackUpdate(w, pw, command.FieldManager)
+}
+
+// create creates the workspace from the apply patch, as users can create workspaces only in their own namespace
+func (h *PatchWorkspaceHandler) create(ctx context.Context, user string, command PatchWorkspaceCommand) (*PatchWorkspaceResponse, error) {
+	if command.Owner != user {
+		return nil, kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			command.Workspace,
+			fmt.Errorf("workspaces can only be created in the requesting user's namespace"))
+	}
+
+	// apply the configuration to an empty workspace
+	w := workspacesv1alpha1.Workspace{}
+	w.SetName(command.Workspace)
+	w.SetNamespace(command.Owner)
+	pw, err := h.applyApplyPatch(&w, command)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCreateWorkspace(pw); err != nil {
+		return nil, err
+	}
+
+	log.FromContext(ctx).Debug("creating workspace", "workspace", pw)
+	opts := &client.CreateOptions{}
+	if err := h.creator.CreateUserWorkspace(ctx, user, pw, opts); err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &PatchWorkspaceResponse{
+		Workspace: pw,
+		Created:   true,
+	}, nil
+}
+
+// validatePatchWorkspace checks that the patch options are consistent with the patch type
+func validatePatchWorkspace(command PatchWorkspaceCommand) error {
+	if command.PatchType == types.ApplyPatchType {
+		if command.FieldManager == "" {
+			return kerrors.NewBadRequest("fieldManager is required for apply patch")
+		}
+		return nil
+	}
+
+	if command.Force {
+		return kerrors.NewBadRequest("force may not be specified for non-apply patch")
+	}
+	return nil
 }
 
 func (h *PatchWorkspaceHandler) applyMergePatch(w *workspacesv1alpha1.Workspace, patch []byte) (*workspacesv1alpha1.Workspace, error) {
@@ -101,13 +192,13 @@ func (h *PatchWorkspaceHandler) applyMergePatch(w *workspacesv1alpha1.Workspace,
 	// apply jsonpatch
 	pwj, err := jsonpatch.MergePatch(wj, patch)
 	if err != nil {
-		return nil, err
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error applying merge patch: %v", err))
 	}
 
 	// unmarshal json to struct
 	pw := workspacesv1alpha1.Workspace{}
 	if err := json.Unmarshal(pwj, &pw); err != nil {
-		return nil, err
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error decoding patched workspace: %v", err))
 	}
 
 	return &pw, nil
@@ -123,14 +214,48 @@ func (h *PatchWorkspaceHandler) applyStrategicMergePatch(w *workspacesv1alpha1.W
 	// apply jsonpatch
 	pwj, err := strategicpatch.StrategicMergePatch(wj, patch, *w)
 	if err != nil {
-		return nil, err
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error applying strategic merge patch: %v", err))
 	}
 
 	// unmarshal json to struct
 	pw := workspacesv1alpha1.Workspace{}
 	if err := json.Unmarshal(pwj, &pw); err != nil {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error decoding patched workspace: %v", err))
+	}
+
+	return &pw, nil
+}
+
+func (h *PatchWorkspaceHandler) applyJSONPatch(w *workspacesv1alpha1.Workspace, patch []byte) (*workspacesv1alpha1.Workspace, error) {
+	// decode patch
+	p, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error decoding json patch: %v", err))
+	}
+
+	// marshal workspace as json
+	wj, err := json.Marshal(w)
+	if err != nil {
 		return nil, err
 	}
 
+	// apply jsonpatch
+	pwj, err := p.Apply(wj)
+	if err != nil {
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			// a failed test operation is a failed precondition
+			return nil, kerrors.NewGenericServerResponse(
+				http.StatusUnprocessableEntity, "patch",
+				restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), w.Name, err.Error(), 0, false)
+		}
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error applying json patch: %v", err))
+	}
+
+	// unmarshal json to struct
+	pw := workspacesv1alpha1.Workspace{}
+	if err := json.Unmarshal(pwj, &pw); err != nil {
+		return nil, kerrors.NewBadRequest(fmt.Sprintf("error decoding patched workspace: %v", err))
+	}
+
 	return &pw, nil
 }
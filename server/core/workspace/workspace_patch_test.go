@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -24,6 +25,7 @@ var _ = Describe("", func() {
 		ctx     context.Context
 		reader  *MockWorkspaceReader
 		updater *MockWorkspaceUpdater
+		creator *MockWorkspaceCreator
 		request workspace.PatchWorkspaceCommand
 		handler workspace.PatchWorkspaceHandler
 		w       workspacesv1alpha1.Workspace
@@ -43,11 +45,12 @@ var _ = Describe("", func() {
 		}
 		updater = NewMockWorkspaceUpdater(ctrl)
 		reader = NewMockWorkspaceReader(ctrl)
+		creator = NewMockWorkspaceCreator(ctrl)
 		request = workspace.PatchWorkspaceCommand{
 			Workspace: w.Name,
 			Owner:     w.Namespace,
 		}
-		handler = *workspace.NewPatchWorkspaceHandler(reader, updater)
+		handler = *workspace.NewPatchWorkspaceHandler(reader, updater, creator)
 	})
 
 	AfterEach(func() { ctrl.Finish() })
@@ -119,6 +122,65 @@ var _ = Describe("", func() {
 		})
 	})
 
+	DescribeTable("Patches changing the name or the namespace are rejected",
+		func(patchType types.PatchType, patch string) {
+			// given
+			username := "foo"
+			ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+			request.PatchType = patchType
+			request.Patch = []byte(patch)
+			reader.EXPECT().
+				ReadUserWorkspace(ctx, username, w.Namespace, w.Name, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, user, owner, workspace string, rw *workspacesv1alpha1.Workspace, opts ...client.GetOption) error {
+					w.DeepCopyInto(rw)
+					return nil
+				})
+			updater.EXPECT().
+				UpdateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		},
+		Entry("merge patch changing the name", types.MergePatchType, `{"metadata":{"name":"other"}}`),
+		Entry("merge patch changing the namespace", types.MergePatchType, `{"metadata":{"namespace":"other"}}`),
+		Entry("strategic merge patch changing the name", types.StrategicMergePatchType, `{"metadata":{"name":"other"}}`),
+		Entry("json patch changing the namespace", types.JSONPatchType, `[{"op":"replace","path":"/metadata/namespace","value":"other"}]`),
+	)
+
+	DescribeTable("Malformed patches are rejected",
+		func(patchType types.PatchType, patch string) {
+			// given
+			username := "foo"
+			ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+			request.PatchType = patchType
+			request.Patch = []byte(patch)
+			reader.EXPECT().
+				ReadUserWorkspace(ctx, username, w.Namespace, w.Name, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, user, owner, workspace string, rw *workspacesv1alpha1.Workspace, opts ...client.GetOption) error {
+					w.DeepCopyInto(rw)
+					return nil
+				})
+			updater.EXPECT().
+				UpdateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		},
+		Entry("invalid merge patch", types.MergePatchType, `{"spec":`),
+		Entry("merge patch with wrong types", types.MergePatchType, `{"spec":{"visibility":1}}`),
+		Entry("invalid strategic merge patch", types.StrategicMergePatchType, `{"spec":`),
+	)
+
 	DescribeTable("Unsupported patch types are rejected",
 		func(patchType types.PatchType) {
 			// given
@@ -144,6 +206,302 @@ var _ = Describe("", func() {
 		},
 		Entry("empty patchType", types.PatchType("")),
 		Entry("invalid patchType", types.PatchType("bar")),
-		Entry("apply patch", types.ApplyPatchType),
 	)
+
+	Context("json patch", func() {
+		var username string
+
+		BeforeEach(func() {
+			username = "foo"
+			ctx = context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+			request.PatchType = types.JSONPatchType
+			reader.EXPECT().
+				ReadUserWorkspace(ctx, username, w.Namespace, w.Name, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, user, owner, workspace string, rw *workspacesv1alpha1.Workspace, opts ...client.GetOption) error {
+					w.DeepCopyInto(rw)
+					return nil
+				})
+		})
+
+		It("should apply the operations", func() {
+			// given
+			request.Patch = []byte(`[{"op":"replace","path":"/spec/visibility","value":"community"}]`)
+			updater.EXPECT().
+				UpdateUserWorkspace(ctx, username, gomock.Any(), &client.UpdateOptions{}).
+				Return(nil)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response).NotTo(BeNil())
+			expectedWorkspace := w.DeepCopy()
+			expectedWorkspace.Spec.Visibility = workspacesv1alpha1.WorkspaceVisibilityCommunity
+			Expect(response.Workspace).To(BeEquivalentTo(expectedWorkspace))
+		})
+
+		It("should apply the operations if test operations succeed", func() {
+			// given
+			request.Patch = []byte(`[
+				{"op":"test","path":"/spec/visibility","value":"private"},
+				{"op":"replace","path":"/spec/visibility","value":"community"}
+			]`)
+			updater.EXPECT().
+				UpdateUserWorkspace(ctx, username, gomock.Any(), &client.UpdateOptions{}).
+				Return(nil)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Workspace.Spec.Visibility).To(Equal(workspacesv1alpha1.WorkspaceVisibilityCommunity))
+		})
+
+		It("should not update the workspace if a test operation fails", func() {
+			// given
+			request.Patch = []byte(`[
+				{"op":"test","path":"/spec/visibility","value":"community"},
+				{"op":"replace","path":"/spec/visibility","value":"private"}
+			]`)
+			updater.EXPECT().
+				UpdateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("should reject malformed patches", func() {
+			// given
+			request.Patch = []byte(`{"op":"replace"}`)
+			updater.EXPECT().
+				UpdateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(0)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+	})
+
+	Context("apply patch", func() {
+		var username string
+
+		BeforeEach(func() {
+			username = "foo"
+			ctx = context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+			request.PatchType = types.ApplyPatchType
+			request.FieldManager = "kubectl"
+			request.Patch = []byte(`
+apiVersion: workspaces.konflux-ci.dev/v1alpha1
+kind: Workspace
+metadata:
+  name: default
+  namespace: user
+spec:
+  visibility: community
+`)
+		})
+
+		expectRead := func(rw *workspacesv1alpha1.Workspace) {
+			reader.EXPECT().
+				ReadUserWorkspace(ctx, username, w.Namespace, w.Name, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, user, owner, workspace string, ww *workspacesv1alpha1.Workspace, opts ...client.GetOption) error {
+					rw.DeepCopyInto(ww)
+					return nil
+				})
+		}
+
+		It("should require a field manager", func() {
+			// given
+			request.FieldManager = ""
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+
+		It("should reject apply configurations for other workspaces", func() {
+			// given
+			expectRead(&w)
+			request.Patch = []byte(`{"apiVersion":"workspaces.konflux-ci.dev/v1alpha1","kind":"Workspace","metadata":{"name":"other","namespace":"user"}}`)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(response).To(BeNil())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+
+		When("the workspace does not exist", func() {
+			BeforeEach(func() {
+				reader.EXPECT().
+					ReadUserWorkspace(gomock.Any(), gomock.Any(), w.Namespace, w.Name, gomock.Any(), gomock.Any()).
+					Return(kerrors.NewNotFound(workspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), w.Name))
+			})
+
+			It("should create the workspace in the user's namespace", func() {
+				// given
+				username = w.Namespace
+				ctx = context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+				creator.EXPECT().
+					CreateUserWorkspace(ctx, username, gomock.Any(), &client.CreateOptions{}).
+					Return(nil)
+				updater.EXPECT().
+					UpdateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				// when
+				response, err := handler.Handle(ctx, request)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Created).To(BeTrue())
+				Expect(response.Workspace.Name).To(Equal(w.Name))
+				Expect(response.Workspace.Namespace).To(Equal(w.Namespace))
+				Expect(response.Workspace.Spec.Visibility).To(Equal(workspacesv1alpha1.WorkspaceVisibilityCommunity))
+				Expect(response.Workspace.ManagedFields).To(ContainElement(HaveField("Manager", "kubectl")))
+			})
+
+			It("should not create workspaces in other users' namespace", func() {
+				// given
+				creator.EXPECT().
+					CreateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				// when
+				response, err := handler.Handle(ctx, request)
+
+				// then
+				Expect(response).To(BeNil())
+				Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			})
+
+			It("should not create invalid workspaces", func() {
+				// given
+				username = w.Namespace
+				ctx = context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+				request.Patch = []byte(`{"apiVersion":"workspaces.konflux-ci.dev/v1alpha1","kind":"Workspace","metadata":{"name":"default","namespace":"user"}}`)
+				creator.EXPECT().
+					CreateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				// when
+				response, err := handler.Handle(ctx, request)
+
+				// then
+				Expect(response).To(BeNil())
+				Expect(kerrors.IsInvalid(err)).To(BeTrue())
+			})
+		})
+
+		It("should apply the configuration and track the field manager", func() {
+			// given
+			expectRead(&w)
+			request.Force = true
+			updater.EXPECT().
+				UpdateUserWorkspace(ctx, username, gomock.Any(), &client.UpdateOptions{}).
+				Return(nil)
+
+			// when
+			response, err := handler.Handle(ctx, request)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Workspace.Spec.Visibility).To(Equal(workspacesv1alpha1.WorkspaceVisibilityCommunity))
+			Expect(response.Workspace.ManagedFields).To(HaveLen(1))
+			Expect(response.Workspace.ManagedFields[0].Manager).To(Equal("kubectl"))
+			Expect(response.Workspace.ManagedFields[0].Operation).To(Equal(v1.ManagedFieldsOperationApply))
+		})
+
+		When("the spec is managed by another manager", func() {
+			var managed *workspacesv1alpha1.Workspace
+
+			BeforeEach(func() {
+				// the workspace has been applied by kubectl, then updated by argocd
+				expectRead(&w)
+				request.Force = true
+				updater.EXPECT().
+					UpdateUserWorkspace(ctx, username, gomock.Any(), &client.UpdateOptions{}).
+					Return(nil)
+				response, err := handler.Handle(ctx, request)
+				Expect(err).NotTo(HaveOccurred())
+
+				mr := request
+				mr.PatchType = types.MergePatchType
+				mr.Patch = []byte(`{"spec":{"visibility":"private"}}`)
+				mr.FieldManager = "argocd"
+				mr.Force = false
+				expectRead(response.Workspace)
+				updater.EXPECT().
+					UpdateUserWorkspace(ctx, username, gomock.Any(), &client.UpdateOptions{}).
+					Return(nil)
+				response, err = handler.Handle(ctx, mr)
+				Expect(err).NotTo(HaveOccurred())
+				managed = response.Workspace
+				Expect(managed.ManagedFields).To(ContainElement(HaveField("Manager", "argocd")))
+				request.Force = false
+			})
+
+			It("should fail with Conflict", func() {
+				// given
+				expectRead(managed)
+				updater.EXPECT().
+					UpdateUserWorkspace(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+
+				// when
+				response, err := handler.Handle(ctx, request)
+
+				// then
+				Expect(response).To(BeNil())
+				Expect(kerrors.IsConflict(err)).To(BeTrue())
+			})
+
+			It("should take the ownership of the fields if forced", func() {
+				// given
+				expectRead(managed)
+				request.Force = true
+				updater.EXPECT().
+					UpdateUserWorkspace(ctx, username, gomock.Any(), &client.UpdateOptions{}).
+					Return(nil)
+
+				// when
+				response, err := handler.Handle(ctx, request)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Workspace.Spec.Visibility).To(Equal(workspacesv1alpha1.WorkspaceVisibilityCommunity))
+				Expect(response.Workspace.ManagedFields).NotTo(ContainElement(HaveField("Manager", "argocd")))
+			})
+		})
+	})
+
+	It("should not allow force on non-apply patches", func() {
+		// given
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, "foo")
+		request.PatchType = types.MergePatchType
+		request.Patch = []byte(`{"spec":{"visibility":"community"}}`)
+		request.Force = true
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+	})
 })
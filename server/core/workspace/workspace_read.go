@@ -2,11 +2,11 @@ package workspace
 
 import (
 	"context"
-	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
 )
 
@@ -42,7 +42,7 @@ func (h *ReadWorkspaceHandler) Handle(ctx context.Context, query ReadWorkspaceQu
 	// If required, implement here complex logic like multiple-domains filtering, etc
 	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
 	if !ok {
-		return nil, fmt.Errorf("unauthenticated request")
+		return nil, core.ErrUnauthenticated
 	}
 
 	// validate query
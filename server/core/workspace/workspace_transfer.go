@@ -0,0 +1,99 @@
+package workspace
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+)
+
+// TransferWorkspaceCommand contains the information needed to transfer a Workspace to a new owner
+type TransferWorkspaceCommand struct {
+	Name     string
+	Owner    string
+	Transfer restworkspacesv1alpha1.WorkspaceTransfer
+}
+
+// TransferWorkspaceResponse contains the transferred Workspace as stored in the data source
+type TransferWorkspaceResponse struct {
+	Workspace *restworkspacesv1alpha1.Workspace
+}
+
+// WorkspaceTransferrer is the interface the data source needs to implement to allow the TransferWorkspaceHandler to store data into it
+type WorkspaceTransferrer interface {
+	TransferUserWorkspace(ctx context.Context, user, owner, space string, transfer *restworkspacesv1alpha1.WorkspaceTransfer, workspace *restworkspacesv1alpha1.Workspace) error
+}
+
+// TransferWorkspaceHandler processes TransferWorkspaceCommand and returns TransferWorkspaceResponse storing data into a WorkspaceTransferrer
+type TransferWorkspaceHandler struct {
+	transferrer WorkspaceTransferrer
+}
+
+// NewTransferWorkspaceHandler creates a new TransferWorkspaceHandler that uses a specified WorkspaceTransferrer
+func NewTransferWorkspaceHandler(transferrer WorkspaceTransferrer) *TransferWorkspaceHandler {
+	return &TransferWorkspaceHandler{transferrer: transferrer}
+}
+
+// Handle handles a TransferWorkspaceCommand and returns a TransferWorkspaceResponse or an error
+func (h *TransferWorkspaceHandler) Handle(ctx context.Context, command TransferWorkspaceCommand) (*TransferWorkspaceResponse, error) {
+	// authorization
+	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
+	if !ok {
+		return nil, core.ErrUnauthenticated
+	}
+
+	// validate command
+	if err := validateWorkspaceTransfer(command.Name, &command.Transfer); err != nil {
+		return nil, err
+	}
+
+	// data access
+	t := command.Transfer
+	w := restworkspacesv1alpha1.Workspace{}
+	log.FromContext(ctx).Debug("transferring workspace", "transfer", t)
+	if err := h.transferrer.TransferUserWorkspace(ctx, u, command.Owner, command.Name, &t, &w); err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &TransferWorkspaceResponse{
+		Workspace: &w,
+	}, nil
+}
+
+// validateWorkspaceTransfer checks that the transfer request is well-formed
+func validateWorkspaceTransfer(workspace string, t *restworkspacesv1alpha1.WorkspaceTransfer) error {
+	errs := field.ErrorList{}
+
+	if t.NewOwner == "" {
+		errs = append(errs, field.Required(field.NewPath("newOwner"), "new owner is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(t.NewOwner) {
+			errs = append(errs, field.Invalid(field.NewPath("newOwner"), t.NewOwner, msg))
+		}
+	}
+
+	switch t.PreviousOwnerRole {
+	case "",
+		restworkspacesv1alpha1.WorkspaceRoleViewer,
+		restworkspacesv1alpha1.WorkspaceRoleContributor,
+		restworkspacesv1alpha1.WorkspaceRoleMaintainer,
+		restworkspacesv1alpha1.WorkspaceRoleAdmin:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("previousOwnerRole"), t.PreviousOwnerRole, SupportedWorkspaceRoles))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(
+		restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceTransfer").GroupKind(),
+		workspace,
+		errs)
+}
@@ -0,0 +1,112 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Transfer", func() {
+	var (
+		ctrl        *gomock.Controller
+		ctx         context.Context
+		transferrer *MockWorkspaceTransferrer
+		request     workspace.TransferWorkspaceCommand
+		handler     workspace.TransferWorkspaceHandler
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		transferrer = NewMockWorkspaceTransferrer(ctrl)
+		request = workspace.TransferWorkspaceCommand{
+			Name:  "workspace",
+			Owner: "foo",
+			Transfer: restworkspacesv1alpha1.WorkspaceTransfer{
+				NewOwner:          "bar",
+				PreviousOwnerRole: restworkspacesv1alpha1.WorkspaceRoleViewer,
+			},
+		}
+		handler = *workspace.NewTransferWorkspaceHandler(transferrer)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	It("should not allow unauthenticated requests", func() {
+		// don't set the "user" value within ctx
+
+		response, err := handler.Handle(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(fmt.Errorf("unauthenticated request")))
+		Expect(response).To(BeNil())
+	})
+
+	It("should allow authenticated requests", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		transferred := restworkspacesv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: request.Name, Namespace: request.Transfer.NewOwner},
+		}
+		transferrer.EXPECT().
+			TransferUserWorkspace(ctx, username, request.Owner, request.Name, &request.Transfer, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _ string, _ *restworkspacesv1alpha1.WorkspaceTransfer, w *restworkspacesv1alpha1.Workspace) error {
+				transferred.DeepCopyInto(w)
+				return nil
+			})
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(Equal(&workspace.TransferWorkspaceResponse{Workspace: &transferred}))
+	})
+
+	DescribeTable("should reject invalid transfers", func(transfer restworkspacesv1alpha1.WorkspaceTransfer) {
+		// given
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, "foo")
+		request.Transfer = transfer
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(kerrors.IsInvalid(err)).To(BeTrue())
+	},
+		Entry("empty new owner", restworkspacesv1alpha1.WorkspaceTransfer{PreviousOwnerRole: restworkspacesv1alpha1.WorkspaceRoleViewer}),
+		Entry("invalid new owner", restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: "Not_Valid"}),
+		Entry("unsupported previous owner role", restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: "bar", PreviousOwnerRole: "owner"}),
+	)
+
+	It("should forward errors from the transferrer", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		error := fmt.Errorf("Failed to transfer workspace!")
+		transferrer.EXPECT().
+			TransferUserWorkspace(ctx, username, request.Owner, request.Name, &request.Transfer, gomock.Any()).
+			Return(error)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(error))
+	})
+})
@@ -2,11 +2,11 @@ package workspace
 
 import (
 	"context"
-	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core"
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
 	"github.com/konflux-workspaces/workspaces/server/log"
 )
@@ -43,7 +43,7 @@ func (h *UpdateWorkspaceHandler) Handle(ctx context.Context, query UpdateWorkspa
 	// If required, implement here complex logic like multiple-domains filtering, etc
 	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
 	if !ok {
-		return nil, fmt.Errorf("unauthenticated request")
+		return nil, core.ErrUnauthenticated
 	}
 
 	// validate query
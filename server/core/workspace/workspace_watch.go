@@ -0,0 +1,85 @@
+package workspace
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-workspaces/workspaces/server/core"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+)
+
+// WatchWorkspaceQuery contains the information needed to watch the workspaces the user has access to
+type WatchWorkspaceQuery struct {
+	Namespace string
+	// Name restricts the watch to a single workspace
+	Name string
+	// LabelSelector restricts the watch to the workspaces matching it, if set
+	LabelSelector labels.Selector
+	// FieldSelector restricts the watch to the workspaces matching it, if set
+	FieldSelector fields.Selector
+	// ResourceVersion the version after which events are requested.
+	// If empty, the watch starts with the current state of the workspaces.
+	ResourceVersion string
+	// AllowWatchBookmarks requests periodic bookmark events
+	AllowWatchBookmarks bool
+}
+
+// WatchWorkspaceResponse contains the stream of events on the workspaces the user can access
+type WatchWorkspaceResponse struct {
+	Watch watch.Interface
+}
+
+// WorkspaceWatcher is the interface the data source needs to implement to allow the WatchWorkspaceHandler to watch data from it
+type WorkspaceWatcher interface {
+	WatchUserWorkspaces(ctx context.Context, user string, opts ...client.ListOption) (watch.Interface, error)
+}
+
+// WatchWorkspaceHandler processes WatchWorkspaceQuery and returns a WatchWorkspaceResponse watching data from a WorkspaceWatcher
+type WatchWorkspaceHandler struct {
+	watcher WorkspaceWatcher
+}
+
+// NewWatchWorkspaceHandler creates a new WatchWorkspaceHandler that uses a specified WorkspaceWatcher
+func NewWatchWorkspaceHandler(watcher WorkspaceWatcher) *WatchWorkspaceHandler {
+	return &WatchWorkspaceHandler{watcher: watcher}
+}
+
+// Handle handles a WatchWorkspaceQuery and returns a WatchWorkspaceResponse or an error
+func (h *WatchWorkspaceHandler) Handle(ctx context.Context, query WatchWorkspaceQuery) (*WatchWorkspaceResponse, error) {
+	// authorization
+	u, ok := ctx.Value(ccontext.UserSignupComplaintNameKey).(string)
+	if !ok {
+		return nil, core.ErrUnauthenticated
+	}
+
+	// data access
+	opts := &client.ListOptions{
+		Namespace:     query.Namespace,
+		LabelSelector: query.LabelSelector,
+		FieldSelector: query.FieldSelector,
+		Raw: &metav1.ListOptions{
+			Watch:               true,
+			ResourceVersion:     query.ResourceVersion,
+			AllowWatchBookmarks: query.AllowWatchBookmarks,
+		},
+	}
+	if query.Name != "" {
+		ns := fields.OneTermEqualSelector("metadata.name", query.Name)
+		if opts.FieldSelector != nil {
+			ns = fields.AndSelectors(opts.FieldSelector, ns)
+		}
+		opts.FieldSelector = ns
+	}
+	w, err := h.watcher.WatchUserWorkspaces(ctx, u, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// reply
+	return &WatchWorkspaceResponse{Watch: w}, nil
+}
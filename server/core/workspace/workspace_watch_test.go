@@ -0,0 +1,142 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		ctrl    *gomock.Controller
+		ctx     context.Context
+		watcher *MockWorkspaceWatcher
+		request workspace.WatchWorkspaceQuery
+		handler workspace.WatchWorkspaceHandler
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		ctx = context.Background()
+		watcher = NewMockWorkspaceWatcher(ctrl)
+		request = workspace.WatchWorkspaceQuery{Namespace: "foo", ResourceVersion: "42", AllowWatchBookmarks: true}
+		handler = *workspace.NewWatchWorkspaceHandler(watcher)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	It("should not allow unauthenticated requests", func() {
+		// don't set the "user" value within ctx
+
+		response, err := handler.Handle(ctx, request)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(fmt.Errorf("unauthenticated request")))
+		Expect(response).To(BeNil())
+	})
+
+	It("should allow authenticated requests", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		expectedWatch := watch.NewFake()
+		opts := &client.ListOptions{
+			Namespace: "foo",
+			Raw: &metav1.ListOptions{
+				Watch:               true,
+				ResourceVersion:     "42",
+				AllowWatchBookmarks: true,
+			},
+		}
+		watcher.EXPECT().
+			WatchUserWorkspaces(ctx, username, opts).
+			Return(expectedWatch, nil)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(Equal(&workspace.WatchWorkspaceResponse{Watch: expectedWatch}))
+	})
+
+	It("should restrict the watch to the requested workspace", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		request.Name = "workspace"
+		expectedWatch := watch.NewFake()
+		watcher.EXPECT().
+			WatchUserWorkspaces(ctx, username, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, opts ...client.ListOption) (watch.Interface, error) {
+				lo := client.ListOptions{}
+				lo.ApplyOptions(opts)
+				Expect(lo.FieldSelector).To(Equal(fields.OneTermEqualSelector("metadata.name", "workspace")))
+				return expectedWatch, nil
+			})
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Watch).To(Equal(expectedWatch))
+	})
+
+	It("should forward the selectors to the workspace watcher", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		request.Name = "workspace"
+		request.LabelSelector = labels.SelectorFromSet(labels.Set{"app": "foo"})
+		request.FieldSelector = fields.OneTermEqualSelector("spec.visibility", "community")
+		expectedWatch := watch.NewFake()
+		watcher.EXPECT().
+			WatchUserWorkspaces(ctx, username, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, opts ...client.ListOption) (watch.Interface, error) {
+				lo := client.ListOptions{}
+				lo.ApplyOptions(opts)
+				Expect(lo.LabelSelector).To(Equal(request.LabelSelector))
+				Expect(lo.FieldSelector.Matches(fields.Set{"metadata.name": "workspace", "spec.visibility": "community"})).To(BeTrue())
+				Expect(lo.FieldSelector.Matches(fields.Set{"metadata.name": "other", "spec.visibility": "community"})).To(BeFalse())
+				Expect(lo.FieldSelector.Matches(fields.Set{"metadata.name": "workspace", "spec.visibility": "private"})).To(BeFalse())
+				return expectedWatch, nil
+			})
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Watch).To(Equal(expectedWatch))
+	})
+
+	It("should forward errors from the workspace watcher", func() {
+		// given
+		username := "foo"
+		ctx := context.WithValue(ctx, ccontext.UserSignupComplaintNameKey, username)
+		error := fmt.Errorf("Failed to watch workspaces!")
+		watcher.EXPECT().
+			WatchUserWorkspaces(ctx, username, gomock.Any()).
+			Return(nil, error)
+
+		// when
+		response, err := handler.Handle(ctx, request)
+
+		// then
+		Expect(response).To(BeNil())
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(Equal(error))
+	})
+})
@@ -49,6 +49,14 @@ else
     --inplace "${f}/config/server/proxy-config/dynamic/config.yaml"
 fi
 
+# generate the key replicas sign continue tokens with.
+# Packaged manifests do not carry it, so that installations do not share it
+if [[ -z "${MANIFEST_TARBALL}" ]]; then
+  ${KUSTOMIZE} edit add secret rest-api-server-continue-token \
+    --disableNameSuffixHash \
+    --from-literal=key="${CONTINUE_TOKEN_KEY:-$(openssl rand -base64 32)}"
+fi
+
 # updating config locally
 ${KUSTOMIZE} edit set namespace "$1"
 ${KUSTOMIZE} edit add configmap rest-api-server-config \
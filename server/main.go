@@ -2,24 +2,60 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	restclient "k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
 	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
 	"github.com/konflux-workspaces/workspaces/server/persistence/readclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/watchclient"
 	"github.com/konflux-workspaces/workspaces/server/persistence/writeclient"
 	"github.com/konflux-workspaces/workspaces/server/rest"
+	"github.com/konflux-workspaces/workspaces/server/rest/auth"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const DefaultAddr string = ":8080"
 const EnvLogLevel = "LOG_LEVEL"
+const EnvContinueTokenKey = "CONTINUE_TOKEN_KEY"
+
+// authentication configuration
+const (
+	// EnvOIDCIssuerURL enables the authentication of bearer tokens issued by the given OIDC issuer
+	EnvOIDCIssuerURL = "OIDC_ISSUER_URL"
+	// EnvOIDCAudiences is the comma-separated list of accepted token audiences
+	EnvOIDCAudiences = "OIDC_AUDIENCES"
+	// EnvOIDCClockSkew is the tolerance applied when checking the tokens' expiry, e.g. 30s
+	EnvOIDCClockSkew = "OIDC_CLOCK_SKEW"
+	// EnvOIDCGroupsClaim is the claim the user's groups are read from
+	EnvOIDCGroupsClaim = "OIDC_GROUPS_CLAIM"
+	// EnvTrustedProxyCIDRs is the comma-separated list of networks trusted proxies send requests from
+	EnvTrustedProxyCIDRs = "TRUSTED_PROXY_CIDRS"
+	// EnvTrustedProxyClientCAFile is the CA bundle verifying the client certificates of trusted proxies
+	EnvTrustedProxyClientCAFile = "TRUSTED_PROXY_CLIENT_CA_FILE"
+	// EnvTLSCertFile is the certificate the server serves TLS with
+	EnvTLSCertFile = "TLS_CERT_FILE"
+	// EnvTLSKeyFile is the key of the certificate the server serves TLS with
+	EnvTLSKeyFile = "TLS_KEY_FILE"
+)
 
 func main() {
 	l := constructLog()
@@ -32,19 +68,6 @@ func main() {
 func run(l *slog.Logger) error {
 	log.SetLogger(logr.FromSlogHandler(l.Handler()))
 
-	// fetch configuration
-	wns, ok := os.LookupEnv("WORKSPACES_NAMESPACE")
-	if !ok {
-		return fmt.Errorf("required Environment Variable WORKSPACES_NAMESPACE not found")
-	}
-	l.Debug("retrieving configuration from env variables", "workspaces namespace", wns)
-
-	kns, ok := os.LookupEnv("KUBESAW_NAMESPACE")
-	if !ok {
-		return fmt.Errorf("required Environment Variable KUBESAW_NAMESPACE not found")
-	}
-	l.Debug("retrieving configuration from env variables", "kubesaw namespace", kns)
-
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return err
@@ -54,28 +77,79 @@ func run(l *slog.Logger) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// fetch configuration
+	wc, err := loadWorkspacesConfig(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("error retrieving WorkspacesConfig: %w", err)
+	}
+	wns, kns := wc.Namespaces.Workspaces, wc.Namespaces.Kubesaw
+	l.Debug("retrieving configuration from WorkspacesConfig", "workspaces namespace", wns, "kubesaw namespace", kns)
+
+	// continue tokens must be verifiable by every replica and across restarts
+	ck := os.Getenv(EnvContinueTokenKey)
+	if ck == "" {
+		return fmt.Errorf("environment variable %s is required", EnvContinueTokenKey)
+	}
+
 	// setup read model
 	l.Info("setting up cache")
 	c, crc, err := readclient.NewDefaultWithCache(ctx, cfg, wns, kns)
 	if err != nil {
 		return err
 	}
+	c.WithContinueKey([]byte(ck))
+
+	// the cache is bound to the namespaces, so a restart is needed to switch to new ones
+	var namespacesChanged atomic.Bool
+	if err := onNamespacesChange(ctx, crc, wc.Namespaces, func() {
+		l.Info("configured namespaces changed, restarting")
+		namespacesChanged.Store(true)
+		cancel()
+	}); err != nil {
+		return err
+	}
+
+	// setup watch model
+	watcher, err := watchclient.NewDefault(ctx, crc, c)
+	if err != nil {
+		return err
+	}
 
 	// setup write model
 	iwcli := iwclient.New(crc, wns, kns)
-	writer := writeclient.NewWithConfig(cfg, wns, iwcli)
+	writer, err := writeclient.NewWithConfig(cfg, wns, iwcli)
+	if err != nil {
+		return err
+	}
+
+	// setup authentication
+	authOpts, err := buildAuthenticationOptions(ctx, l)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := buildTLSConfig()
+	if err != nil {
+		return err
+	}
 
 	// setup REST over HTTP server
 	l.Info("setting up REST over HTTP server")
 	s := rest.New(
 		l,
 		DefaultAddr,
+		authOpts,
 		crc,
 		workspace.NewReadWorkspaceHandler(c).Handle,
 		workspace.NewListWorkspaceHandler(c).Handle,
 		workspace.NewCreateWorkspaceHandler(writer).Handle,
 		workspace.NewUpdateWorkspaceHandler(writer).Handle,
-		workspace.NewPatchWorkspaceHandler(c, writer).Handle,
+		workspace.NewPatchWorkspaceHandler(c, writer, writer).Handle,
+		workspace.NewDeleteWorkspaceHandler(writer).Handle,
+		workspace.NewListWorkspaceMembersHandler(writer).Handle,
+		workspace.NewSetWorkspaceMemberHandler(writer).Handle,
+		workspace.NewRevokeWorkspaceMemberHandler(writer).Handle,
+		workspace.NewTransferWorkspaceHandler(writer).Handle,
+		workspace.NewWatchWorkspaceHandler(watcher).Handle,
 	)
 
 	// HTTP Server graceful shutdown
@@ -108,14 +182,156 @@ func run(l *slog.Logger) error {
 	}
 
 	// start HTTP server
-	l.Info("starting HTTP server", "address", s.Addr)
-	if err := s.ListenAndServe(); err != nil {
+	l.Info("starting HTTP server", "address", s.Addr, "tls", tlsConfig != nil)
+	if tlsConfig != nil {
+		s.TLSConfig = tlsConfig
+		err = s.ListenAndServeTLS(os.Getenv(EnvTLSCertFile), os.Getenv(EnvTLSKeyFile))
+	} else {
+		err = s.ListenAndServe()
+	}
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) && namespacesChanged.Load() {
+			return fmt.Errorf("configured namespaces changed")
+		}
 		return fmt.Errorf("error running server: %w", err)
 	}
 
 	return nil
 }
 
+// buildAuthenticationOptions configures the authentication from the environment variables.
+// At least one authentication mode is required, so that the identity of users is never trusted blindly.
+func buildAuthenticationOptions(ctx context.Context, l *slog.Logger) (rest.AuthenticationOptions, error) {
+	opts := rest.AuthenticationOptions{}
+
+	if iu := os.Getenv(EnvOIDCIssuerURL); iu != "" {
+		skew := auth.DefaultClockSkew
+		if v := os.Getenv(EnvOIDCClockSkew); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return opts, fmt.Errorf("invalid %s: %w", EnvOIDCClockSkew, err)
+			}
+			skew = d
+		}
+
+		l.Info("setting up OIDC authentication", "issuer", iu)
+		a, err := auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{
+			IssuerURL:   iu,
+			Audiences:   splitList(os.Getenv(EnvOIDCAudiences)),
+			ClockSkew:   skew,
+			GroupsClaim: os.Getenv(EnvOIDCGroupsClaim),
+		})
+		if err != nil {
+			return opts, err
+		}
+		opts.TokenAuthenticator = a
+	}
+
+	cidrs, err := auth.ParseCIDRs(os.Getenv(EnvTrustedProxyCIDRs))
+	if err != nil {
+		return opts, fmt.Errorf("invalid %s: %w", EnvTrustedProxyCIDRs, err)
+	}
+	tp := &auth.TrustedProxies{CIDRs: cidrs, ClientCertificates: os.Getenv(EnvTrustedProxyClientCAFile) != ""}
+	if tp.IsEnabled() {
+		l.Info("trusting authentication headers from proxies", "cidrs", os.Getenv(EnvTrustedProxyCIDRs), "mtls", tp.ClientCertificates)
+		opts.TrustedProxies = tp
+	}
+
+	if opts.TokenAuthenticator == nil && opts.TrustedProxies == nil {
+		return opts, fmt.Errorf("no authentication configured: set %s, or trust proxies via %s or %s",
+			EnvOIDCIssuerURL, EnvTrustedProxyCIDRs, EnvTrustedProxyClientCAFile)
+	}
+	return opts, nil
+}
+
+// buildTLSConfig configures the TLS from the environment variables, or returns nil if TLS is not enabled.
+// Client certificates are requested only if the trusted proxies are authenticated via mutual TLS.
+func buildTLSConfig() (*tls.Config, error) {
+	cert, key, ca := os.Getenv(EnvTLSCertFile), os.Getenv(EnvTLSKeyFile), os.Getenv(EnvTrustedProxyClientCAFile)
+	switch {
+	case cert == "" && key == "" && ca == "":
+		return nil, nil
+	case cert == "" || key == "":
+		return nil, fmt.Errorf("both %s and %s are required for serving TLS", EnvTLSCertFile, EnvTLSKeyFile)
+	}
+
+	c := &tls.Config{MinVersion: tls.VersionTLS12}
+	if ca != "" {
+		b, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", EnvTrustedProxyClientCAFile, err)
+		}
+		p := x509.NewCertPool()
+		if !p.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificate found in %s", ca)
+		}
+		c.ClientCAs = p
+		c.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return c, nil
+}
+
+// splitList splits a comma-separated list, dropping empty elements
+func splitList(s string) []string {
+	ss := []string{}
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			ss = append(ss, e)
+		}
+	}
+	return ss
+}
+
+// loadWorkspacesConfig retrieves the WorkspacesConfig directly from the cluster,
+// as it is needed to configure the cache
+func loadWorkspacesConfig(ctx context.Context, cfg *restclient.Config) (*workspacesv1alpha1.WorkspacesConfigSpec, error) {
+	s := runtime.NewScheme()
+	if err := workspacesv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return nil, err
+	}
+	return iwclient.GetWorkspacesConfig(ctx, c)
+}
+
+// onNamespacesChange calls `f` when the WorkspacesConfig changes the namespaces to other than `namespaces`
+func onNamespacesChange(ctx context.Context, c cache.Cache, namespaces workspacesv1alpha1.WorkspacesConfigNamespaces, f func()) error {
+	i, err := c.GetInformer(ctx, &workspacesv1alpha1.WorkspacesConfig{})
+	if err != nil {
+		return err
+	}
+
+	check := func(obj interface{}, deleted bool) {
+		wc, ok := obj.(*workspacesv1alpha1.WorkspacesConfig)
+		if !ok || wc.Name != workspacesv1alpha1.WorkspacesConfigName {
+			return
+		}
+
+		// the default configuration applies once the WorkspacesConfig is deleted
+		s := workspacesv1alpha1.WorkspacesConfigSpec{}
+		if !deleted {
+			wc.Spec.DeepCopyInto(&s)
+		}
+		s.Default()
+		if s.Namespaces != namespaces {
+			f()
+		}
+	}
+	_, err = i.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { check(obj, false) },
+		UpdateFunc: func(_, obj interface{}) { check(obj, false) },
+		DeleteFunc: func(obj interface{}) {
+			if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = d.Obj
+			}
+			check(obj, true)
+		},
+	})
+	return err
+}
+
 // constructLog constructs a new instance of the logger
 func constructLog() *slog.Logger {
 	logLevel := getLogLevel()
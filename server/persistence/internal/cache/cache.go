@@ -17,7 +17,9 @@ import (
 )
 
 // NewCache creates a controller-runtime cache.Cache instance configured to monitor
-// spacebindings.toolchain.dev.openshift.com and workspaces.workspaces.io.
+// spacebindings.toolchain.dev.openshift.com, usersignups.toolchain.dev.openshift.com,
+// bannedusers.toolchain.dev.openshift.com, internalworkspaces.workspaces.konflux-ci.dev
+// and workspacesconfigs.workspaces.konflux-ci.dev.
 // IMPORTANT: returned cache needs to be started and initialized.
 func NewCache(ctx context.Context, cfg *rest.Config, workspacesNamespace, kubesawNamespace string) (cache.Cache, error) {
 	s, err := createScheme()
@@ -42,9 +44,15 @@ func NewCache(ctx context.Context, cfg *rest.Config, workspacesNamespace, kubesa
 	if _, err := c.GetInformer(ctx, &toolchainv1alpha1.UserSignup{}); err != nil {
 		return nil, err
 	}
+	if _, err := c.GetInformer(ctx, &toolchainv1alpha1.BannedUser{}); err != nil {
+		return nil, err
+	}
 	if _, err := c.GetInformer(ctx, &workspacesv1alpha1.InternalWorkspace{}); err != nil {
 		return nil, err
 	}
+	if _, err := c.GetInformer(ctx, &workspacesv1alpha1.WorkspacesConfig{}); err != nil {
+		return nil, err
+	}
 
 	// configure field indexers for filtering
 	for k, f := range UserSignupIndexers {
@@ -52,6 +60,11 @@ func NewCache(ctx context.Context, cfg *rest.Config, workspacesNamespace, kubesa
 			return nil, err
 		}
 	}
+	for k, f := range BannedUserIndexers {
+		if err := c.IndexField(ctx, &toolchainv1alpha1.BannedUser{}, k, f); err != nil {
+			return nil, err
+		}
+	}
 	for k, f := range InternalWorkspacesIndexers {
 		if err := c.IndexField(ctx, &workspacesv1alpha1.InternalWorkspace{}, k, f); err != nil {
 			return nil, err
@@ -68,6 +81,7 @@ func newCache(cfg *rest.Config, scheme *runtime.Scheme, mapper meta.RESTMapper,
 		ReaderFailOnMissingInformer: true,
 		ByObject: map[client.Object]cache.ByObject{
 			&toolchainv1alpha1.UserSignup{}:         {Namespaces: map[string]cache.Config{kubesawNamespace: {}}},
+			&toolchainv1alpha1.BannedUser{}:         {Namespaces: map[string]cache.Config{kubesawNamespace: {}}},
 			&toolchainv1alpha1.SpaceBinding{}:       {Namespaces: map[string]cache.Config{kubesawNamespace: {}}},
 			&workspacesv1alpha1.InternalWorkspace{}: {Namespaces: map[string]cache.Config{workspacesNamespace: {}}},
 		},
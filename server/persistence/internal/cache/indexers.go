@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"strings"
+
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,19 +17,35 @@ const (
 	IndexKeyInternalWorkspaceOwnerUsername string = "owner.username"
 	// IndexKeyInternalWorkspaceOwnerEmail key for InternalWorkspace's indexer on field for Owner's Email
 	IndexKeyInternalWorkspaceOwnerEmail string = "owner.email"
-	// IndexKeyInternalWorkspaceOwnerSub key for InternalWorkspace's indexer on field for Owner's Sub
-	IndexKeyInternalWorkspaceOwnerSub string = "owner.sub"
+	// IndexKeyInternalWorkspaceOwnerIdentity key for InternalWorkspace's indexer on the Owner's identity,
+	// i.e. the issuer and Sub of their JWT
+	IndexKeyInternalWorkspaceOwnerIdentity string = "owner.identity"
 	// IndexKeyInternalWorkspaceSpaceName key for InternalWorkspace's indexer on field for Space's name
 	IndexKeyInternalWorkspaceSpaceName string = "space.name"
 
 	// IndexKeyUserComplaintName key for InternalWorkspace's indexer on field for UserSignup's ComplaintName
 	IndexKeyUserComplaintName string = "status.complaintName"
+	// IndexKeyUserSignupIdentity key for UserSignup's indexer on the user's identity,
+	// i.e. the issuer and Sub claims of their JWT
+	IndexKeyUserSignupIdentity string = "identity"
+
+	// IndexKeyBannedUserEmail key for BannedUser's indexer on the lowercase banned email
+	IndexKeyBannedUserEmail string = "email"
 )
 
 var UserSignupIndexers = map[string]client.IndexerFunc{
 	IndexKeyUserComplaintName: newSingleFieldIndexer(func(u *toolchainv1alpha1.UserSignup) string {
 		return u.Status.CompliantUsername
 	}),
+	IndexKeyUserSignupIdentity: newSingleFieldIndexer(func(u *toolchainv1alpha1.UserSignup) string {
+		return workspacesv1alpha1.IdentityKey(u.GetAnnotations()[workspacesv1alpha1.AnnotationUserSignupIssuer], u.Spec.IdentityClaims.Sub)
+	}),
+}
+
+var BannedUserIndexers = map[string]client.IndexerFunc{
+	IndexKeyBannedUserEmail: newSingleFieldIndexer(func(b *toolchainv1alpha1.BannedUser) string {
+		return strings.ToLower(b.Spec.Email)
+	}),
 }
 
 var InternalWorkspacesIndexers = map[string]client.IndexerFunc{
@@ -46,8 +64,8 @@ var InternalWorkspacesIndexers = map[string]client.IndexerFunc{
 	IndexKeyInternalWorkspaceOwnerEmail: newSingleFieldIndexer(func(w *workspacesv1alpha1.InternalWorkspace) string {
 		return w.Spec.Owner.JwtInfo.Email
 	}),
-	IndexKeyInternalWorkspaceOwnerSub: newSingleFieldIndexer(func(w *workspacesv1alpha1.InternalWorkspace) string {
-		return w.Spec.Owner.JwtInfo.Sub
+	IndexKeyInternalWorkspaceOwnerIdentity: newSingleFieldIndexer(func(w *workspacesv1alpha1.InternalWorkspace) string {
+		return w.Spec.Owner.JwtInfo.IdentityKey()
 	}),
 }
 
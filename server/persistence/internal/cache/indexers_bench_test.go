@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BenchmarkUserSignupSubLookup compares looking up a UserSignup by the JWT's
+// Sub claim with a full scan of the store against using the
+// IndexKeyUserSignupIdentity indexer, as the informer cache does.
+func BenchmarkUserSignupSubLookup(b *testing.B) {
+	for _, n := range []int{100, 1_000, 10_000, 100_000} {
+		store := newUserSignupStore(b, n)
+		sub := userSignupSub(n / 2)
+
+		b.Run(fmt.Sprintf("scan/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var found *toolchainv1alpha1.UserSignup
+				for _, o := range store.List() {
+					if u := o.(*toolchainv1alpha1.UserSignup); u.Spec.IdentityClaims.Sub == sub {
+						found = u
+						break
+					}
+				}
+				if found == nil {
+					b.Fatalf("usersignup with sub %s not found", sub)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("index/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				oo, err := store.ByIndex(indexName(IndexKeyUserSignupIdentity), workspacesv1alpha1.IdentityKey("", sub))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(oo) != 1 {
+					b.Fatalf("expected 1 usersignup with sub %s, found %d", sub, len(oo))
+				}
+			}
+		})
+	}
+}
+
+// newUserSignupStore returns a store holding n UserSignups indexed with UserSignupIndexers
+func newUserSignupStore(b *testing.B, n int) toolscache.Indexer {
+	b.Helper()
+
+	ii := toolscache.Indexers{}
+	for k, f := range UserSignupIndexers {
+		ii[indexName(k)] = func(obj interface{}) ([]string, error) {
+			return f(obj.(client.Object)), nil
+		}
+	}
+
+	store := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, ii)
+	for i := 0; i < n; i++ {
+		u := &toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("user-%d", i),
+				Namespace: "toolchain-host-operator",
+			},
+			Spec: toolchainv1alpha1.UserSignupSpec{
+				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
+						Sub: userSignupSub(i),
+					},
+				},
+			},
+		}
+		if err := store.Add(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return store
+}
+
+func userSignupSub(i int) string {
+	return fmt.Sprintf("sub-%d", i)
+}
+
+// indexName mirrors the name controller-runtime gives to field indexes
+func indexName(field string) string {
+	return "field:" + field
+}
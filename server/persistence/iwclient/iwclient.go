@@ -2,6 +2,8 @@ package iwclient
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -43,3 +45,43 @@ func (c *Client) UserHasDirectAccess(ctx context.Context, user, space string) (b
 
 	return len(sbb.Items) > 0, nil
 }
+
+// UserHasRole checks whether `user` is granted `role` on `space` via a SpaceBinding
+func (c *Client) UserHasRole(ctx context.Context, user, space, role string) (bool, error) {
+	ml := client.MatchingLabels{
+		toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: user,
+		toolchainv1alpha1.SpaceBindingSpaceLabelKey:            space,
+	}
+	sbb := toolchainv1alpha1.SpaceBindingList{}
+	if err := c.backend.List(ctx, &sbb, ml); err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(sbb.Items, func(sb toolchainv1alpha1.SpaceBinding) bool {
+		return sb.Spec.SpaceRole == role
+	}), nil
+}
+
+// GetUserSignupByComplaintName retrieves the UserSignup whose CompliantUsername matches `complaintName`
+func (c *Client) GetUserSignupByComplaintName(ctx context.Context, complaintName string, userSignup *toolchainv1alpha1.UserSignup) error {
+	u, err := c.fetchUserSignupByComplaintName(ctx, complaintName)
+	if err != nil {
+		return err
+	}
+
+	u.DeepCopyInto(userSignup)
+	return nil
+}
+
+// WorkspaceExists checks whether a workspace identified by `key` exists, regardless of the access any user has to it
+func (c *Client) WorkspaceExists(ctx context.Context, key clientinterface.SpaceKey) (bool, error) {
+	_, err := c.fetchInternalWorkspace(ctx, key.Owner, key.Name)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrWorkspaceNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
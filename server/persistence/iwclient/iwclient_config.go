@@ -0,0 +1,27 @@
+package iwclient
+
+import (
+	"context"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+// GetWorkspacesConfig retrieves the configuration from the WorkspacesConfig, with defaults applied
+func (c *Client) GetWorkspacesConfig(ctx context.Context) (*workspacesv1alpha1.WorkspacesConfigSpec, error) {
+	return GetWorkspacesConfig(ctx, c.backend)
+}
+
+// GetWorkspacesConfig retrieves the configuration from the WorkspacesConfig using the provided reader,
+// with defaults applied. If the WorkspacesConfig does not exist, the default configuration is returned.
+func GetWorkspacesConfig(ctx context.Context, reader client.Reader) (*workspacesv1alpha1.WorkspacesConfigSpec, error) {
+	wc := workspacesv1alpha1.WorkspacesConfig{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, &wc); err != nil && !kerrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	wc.Spec.Default()
+	return &wc.Spec, nil
+}
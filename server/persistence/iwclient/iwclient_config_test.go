@@ -0,0 +1,58 @@
+package iwclient_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+var _ = Describe("GetWorkspacesConfig", func() {
+	ksns := "kubesaw-namespace"
+	wsns := "workspaces-namespace"
+
+	It("returns the default configuration if the WorkspacesConfig does not exist", func() {
+		// given
+		c := buildCache(wsns, ksns)
+
+		// when
+		cfg, err := c.GetWorkspacesConfig(context.Background())
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Namespaces.Workspaces).To(Equal(workspacesv1alpha1.DefaultWorkspacesNamespace))
+		Expect(cfg.Namespaces.Kubesaw).To(Equal(workspacesv1alpha1.DefaultKubesawNamespace))
+		Expect(cfg.Community.Role).To(Equal(workspacesv1alpha1.DefaultCommunityRole))
+		Expect(cfg.NameRules.MaxLength).To(Equal(workspacesv1alpha1.DefaultMaxNameLength))
+		Expect(cfg.Quotas.MaxWorkspacesPerUser).To(BeNil())
+		Expect(cfg.Features.IsWorkspaceCreationEnabled()).To(BeTrue())
+		Expect(cfg.Features.IsCommunityVisibilityEnabled()).To(BeTrue())
+		Expect(cfg.Features.IsOwnershipTransferEnabled()).To(BeTrue())
+	})
+
+	It("returns the WorkspacesConfig with defaults applied to unset fields", func() {
+		// given
+		c := buildCache(wsns, ksns, &workspacesv1alpha1.WorkspacesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+			Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+				Namespaces: workspacesv1alpha1.WorkspacesConfigNamespaces{Kubesaw: ksns},
+				Features:   workspacesv1alpha1.WorkspacesConfigFeatures{OwnershipTransfer: ptr.To(false)},
+			},
+		})
+
+		// when
+		cfg, err := c.GetWorkspacesConfig(context.Background())
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Namespaces.Workspaces).To(Equal(workspacesv1alpha1.DefaultWorkspacesNamespace))
+		Expect(cfg.Namespaces.Kubesaw).To(Equal(ksns))
+		Expect(cfg.Features.IsWorkspaceCreationEnabled()).To(BeTrue())
+		Expect(cfg.Features.IsOwnershipTransferEnabled()).To(BeFalse())
+	})
+})
@@ -87,3 +87,30 @@ func (c *Client) listCommunityWorkspaces(ctx context.Context, workspaces *worksp
 	}
 	return c.backend.List(ctx, workspaces, opt)
 }
+
+// ListOwnedByUserSignup lists the InternalWorkspaces owned by the user the UserSignup belongs to.
+// The user is identified by issuer and Sub: UserSignups and InternalWorkspaces lacking the issuer
+// match the users of the default issuer.
+func (c *Client) ListOwnedByUserSignup(ctx context.Context, userSignup *toolchainv1alpha1.UserSignup, workspaces *workspacesv1alpha1.InternalWorkspaceList) error {
+	cfg, err := c.GetWorkspacesConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	ww := workspacesv1alpha1.InternalWorkspaceList{}
+	issuer := userSignup.GetAnnotations()[workspacesv1alpha1.AnnotationUserSignupIssuer]
+	for _, k := range cfg.Identity.IdentityKeys(issuer, userSignup.Spec.IdentityClaims.Sub) {
+		l := workspacesv1alpha1.InternalWorkspaceList{}
+		opts := []client.ListOption{
+			client.InNamespace(c.workspacesNamespace),
+			client.MatchingFields{cache.IndexKeyInternalWorkspaceOwnerIdentity: k},
+		}
+		if err := c.backend.List(ctx, &l, opts...); err != nil {
+			return err
+		}
+		ww.Items = append(ww.Items, l.Items...)
+	}
+
+	ww.DeepCopyInto(workspaces)
+	return nil
+}
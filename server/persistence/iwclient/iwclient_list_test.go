@@ -443,3 +443,84 @@ var _ = Describe("List", func() {
 		})
 	})
 })
+
+var _ = Describe("ListOwnedByUserSignup", func() {
+	ctx := context.TODO()
+	wsns := "workspaces"
+	ksns := "kubesaw"
+
+	buildWorkspace := func(name, sub string) *workspacesv1alpha1.InternalWorkspace {
+		return &workspacesv1alpha1.InternalWorkspace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: wsns},
+			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+				DisplayName: name,
+				Owner: workspacesv1alpha1.UserInfo{
+					JwtInfo: workspacesv1alpha1.JwtInfo{Sub: sub},
+				},
+			},
+		}
+	}
+
+	It("returns only the workspaces owned by the user", func() {
+		// given
+		u := toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: ksns},
+			Spec: toolchainv1alpha1.UserSignupSpec{
+				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{Sub: "owner-sub"},
+				},
+			},
+		}
+		c := buildCache(wsns, ksns,
+			buildWorkspace("first", "owner-sub"),
+			buildWorkspace("second", "owner-sub"),
+			buildWorkspace("other", "other-sub"),
+		)
+
+		// when
+		var ww workspacesv1alpha1.InternalWorkspaceList
+		err := c.ListOwnedByUserSignup(ctx, &u, &ww)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ww.Items).To(HaveLen(2))
+		Expect([]string{ww.Items[0].Name, ww.Items[1].Name}).To(ConsistOf("first", "second"))
+	})
+
+	It("matches the owner by issuer, treating workspaces lacking it as owned by users of the default issuer", func() {
+		// given
+		defaultIssuer := "https://default-issuer"
+		u := toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "owner",
+				Namespace:   ksns,
+				Annotations: map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: defaultIssuer},
+			},
+			Spec: toolchainv1alpha1.UserSignupSpec{
+				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{Sub: "owner-sub"},
+				},
+			},
+		}
+		wc := &workspacesv1alpha1.WorkspacesConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+			Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+				Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: defaultIssuer},
+			},
+		}
+		current := buildWorkspace("current", "owner-sub")
+		current.Spec.Owner.JwtInfo.Issuer = defaultIssuer
+		other := buildWorkspace("other-issuer", "owner-sub")
+		other.Spec.Owner.JwtInfo.Issuer = "https://other-issuer"
+		c := buildCache(wsns, ksns, wc, current, other, buildWorkspace("legacy", "owner-sub"))
+
+		// when
+		var ww workspacesv1alpha1.InternalWorkspaceList
+		err := c.ListOwnedByUserSignup(ctx, &u, &ww)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ww.Items).To(HaveLen(2))
+		Expect([]string{ww.Items[0].Name, ww.Items[1].Name}).To(ConsistOf("current", "legacy"))
+	})
+})
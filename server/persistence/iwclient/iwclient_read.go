@@ -2,10 +2,12 @@ package iwclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/konflux-workspaces/workspaces/server/core"
 	"github.com/konflux-workspaces/workspaces/server/log"
 	"github.com/konflux-workspaces/workspaces/server/persistence/clientinterface"
 	"github.com/konflux-workspaces/workspaces/server/persistence/internal/cache"
@@ -14,10 +16,13 @@ import (
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 )
 
+// ErrWorkspaceNotFound and ErrUnauthorized wrap core.ErrNotFound,
+// so that the existence of workspaces the user can not access is not disclosed
 var (
-	ErrWorkspaceNotFound error = fmt.Errorf("workspace not found")
-	ErrUnauthorized      error = fmt.Errorf("user is not authorized to read the workspace")
-	ErrMoreThanOneFound  error = fmt.Errorf("more than one workspace found")
+	ErrWorkspaceNotFound  error = fmt.Errorf("workspace not found: %w", core.ErrNotFound)
+	ErrUnauthorized       error = fmt.Errorf("user is not authorized to read the workspace: %w", core.ErrNotFound)
+	ErrMoreThanOneFound   error = fmt.Errorf("more than one workspace found")
+	ErrUserSignupNotFound error = fmt.Errorf("usersignup not found: %w", core.ErrNotFound)
 )
 
 // GetAsUser retrieves the requested workspace if and only if it is community or `user` is allowed access to
@@ -64,8 +69,12 @@ func (c *Client) fetchInternalWorkspace(
 	space string,
 	_ ...client.GetOption,
 ) (*workspacesv1alpha1.InternalWorkspace, error) {
+	// workspaces of users that do not exist do not exist either
 	u, err := c.fetchUserSignupByComplaintName(ctx, owner)
 	if err != nil {
+		if errors.Is(err, ErrUserSignupNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
 		return nil, err
 	}
 
@@ -97,7 +106,7 @@ func (c *Client) fetchUserSignupByComplaintName(
 	}
 
 	if len(uu.Items) == 0 {
-		return nil, ErrWorkspaceNotFound
+		return nil, ErrUserSignupNotFound
 	}
 	return &uu.Items[0], nil
 }
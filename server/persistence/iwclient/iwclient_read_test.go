@@ -418,3 +418,17 @@ var _ = Describe("Read", func() {
 		})
 	})
 })
+
+var _ = Describe("GetUserSignupByComplaintName", func() {
+	It("returns ErrUserSignupNotFound if the user does not exist", func() {
+		// given
+		c := buildCache("workspaces-namespace", "kubesaw-namespace")
+
+		// when
+		u := toolchainv1alpha1.UserSignup{}
+		err := c.GetUserSignupByComplaintName(context.Background(), "not-existing", &u)
+
+		// then
+		Expect(err).To(MatchError(iwclient.ErrUserSignupNotFound))
+	})
+})
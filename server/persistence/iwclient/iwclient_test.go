@@ -1,8 +1,13 @@
 package iwclient_test
 
 import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -38,3 +43,47 @@ func buildCache(wsns, ksns string, objs ...client.Object) *iwclient.Client {
 func generateName(namePrefix string) string {
 	return namePrefix + "-jjdjk"
 }
+
+var _ = Describe("UserHasRole", func() {
+	ksns := "kubesaw-namespace"
+	wsns := "workspaces-namespace"
+
+	buildSpaceBinding := func(user, space, role string) *toolchainv1alpha1.SpaceBinding {
+		return &toolchainv1alpha1.SpaceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s", space, user),
+				Namespace: ksns,
+				Labels: map[string]string{
+					toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: user,
+					toolchainv1alpha1.SpaceBindingSpaceLabelKey:            space,
+				},
+			},
+			Spec: toolchainv1alpha1.SpaceBindingSpec{
+				MasterUserRecord: user,
+				Space:            space,
+				SpaceRole:        role,
+			},
+		}
+	}
+
+	DescribeTable("checks the role granted via SpaceBindings", func(user, role string, expected bool) {
+		// given
+		c := buildCache(wsns, ksns,
+			buildSpaceBinding("admin-user", "space", "admin"),
+			buildSpaceBinding("viewer-user", "space", "viewer"),
+			buildSpaceBinding("admin-user", "other-space", "viewer"),
+		)
+
+		// when
+		ok, err := c.UserHasRole(context.Background(), user, "space", role)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(Equal(expected))
+	},
+		Entry("admin user has admin role", "admin-user", "admin", true),
+		Entry("viewer user has not admin role", "viewer-user", "admin", false),
+		Entry("viewer user has viewer role", "viewer-user", "viewer", true),
+		Entry("user with no SpaceBinding has no role", "other-user", "viewer", false),
+	)
+})
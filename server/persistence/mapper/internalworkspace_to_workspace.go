@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +20,32 @@ func (m *Mapper) InternalWorkspaceToWorkspace(workspace *workspacesv1alpha1.Inte
 		}
 	}
 
+	// retrieve members
+	var mm []restworkspacesv1alpha1.WorkspaceMember
+	for _, m := range workspace.Status.Members {
+		mm = append(mm, restworkspacesv1alpha1.WorkspaceMember{
+			Username: m.Username,
+			Role:     restworkspacesv1alpha1.WorkspaceRole(m.Role),
+		})
+	}
+
+	// retrieve provisioned namespaces
+	var nn []restworkspacesv1alpha1.SpaceNamespace
+	for _, n := range workspace.Status.Space.Namespaces {
+		nn = append(nn, restworkspacesv1alpha1.SpaceNamespace{
+			Name: n.Name,
+			Type: n.Type,
+		})
+	}
+
+	// retrieve managed fields
+	var mf []metav1.ManagedFieldsEntry
+	if a, ok := workspace.GetAnnotations()[restworkspacesv1alpha1.AnnotationManagedFields]; ok {
+		if err := json.Unmarshal([]byte(a), &mf); err != nil {
+			return nil, fmt.Errorf("error unmarshaling managed fields: %w", err)
+		}
+	}
+
 	return &restworkspacesv1alpha1.Workspace{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Workspace",
@@ -29,6 +57,9 @@ func (m *Mapper) InternalWorkspaceToWorkspace(workspace *workspacesv1alpha1.Inte
 			CreationTimestamp: workspace.CreationTimestamp,
 			Labels:            wll,
 			Generation:        workspace.Generation,
+			ResourceVersion:   workspace.ResourceVersion,
+			UID:               workspace.UID,
+			ManagedFields:     mf,
 		},
 		Spec: restworkspacesv1alpha1.WorkspaceSpec{
 			Visibility: restworkspacesv1alpha1.WorkspaceVisibility(workspace.Spec.Visibility),
@@ -37,11 +68,26 @@ func (m *Mapper) InternalWorkspaceToWorkspace(workspace *workspacesv1alpha1.Inte
 			Space: &restworkspacesv1alpha1.SpaceInfo{
 				Name:          workspace.Status.Space.Name,
 				TargetCluster: workspace.Status.Space.TargetCluster,
+				Tier:          workspace.Status.Space.Tier,
+				Phase:         restworkspacesv1alpha1.SpacePhase(workspace.Status.Space.Phase),
+				Message:       workspace.Status.Space.Message,
+				Namespaces:    nn,
 			},
 			Owner: &restworkspacesv1alpha1.UserInfoStatus{
-				Email: workspace.Spec.Owner.JwtInfo.Email,
+				Email: ownerEmail(workspace),
 			},
-			Conditions: workspace.Status.Conditions,
+			Members:            mm,
+			Conditions:         workspace.Status.Conditions,
+			ObservedGeneration: workspace.Status.ObservedGeneration,
 		},
 	}, nil
 }
+
+// ownerEmail returns the owner's current email as reported by the operator,
+// falling back to the one the InternalWorkspace was created with
+func ownerEmail(workspace *workspacesv1alpha1.InternalWorkspace) string {
+	if e := workspace.Status.Owner.Email; e != "" {
+		return e
+	}
+	return workspace.Spec.Owner.JwtInfo.Email
+}
@@ -55,6 +55,74 @@ var _ = Describe("InternalworkspaceToWorkspace", func() {
 				Expect(w.Spec.Visibility).To(Equal(restworkspacesv1alpha1.WorkspaceVisibilityPrivate))
 			})
 		})
+
+		When("managed fields are stored", func() {
+			BeforeEach(func() {
+				internalWorkspace.Annotations = map[string]string{
+					restworkspacesv1alpha1.AnnotationManagedFields: `[{"manager":"kubectl","operation":"Apply","fieldsType":"FieldsV1","fieldsV1":{"f:spec":{"f:visibility":{}}}}]`,
+				}
+			})
+
+			It("restores the managed fields", func() {
+				// when
+				w, err := mapper.Default.InternalWorkspaceToWorkspace(&internalWorkspace)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				validateMappedWorkspace(w, internalWorkspace)
+				Expect(w.ManagedFields).To(HaveLen(1))
+				Expect(w.ManagedFields[0].Manager).To(Equal("kubectl"))
+				Expect(w.ManagedFields[0].Operation).To(Equal(metav1.ManagedFieldsOperationApply))
+				Expect(w.GetAnnotations()).To(BeEmpty())
+			})
+		})
+
+		When("the owner's email is reported in the status", func() {
+			BeforeEach(func() {
+				internalWorkspace.Spec.Owner.JwtInfo.Email = "old@email.com"
+				internalWorkspace.Status.Owner.Email = "new@email.com"
+			})
+
+			It("uses the email from the status", func() {
+				// when
+				w, err := mapper.Default.InternalWorkspaceToWorkspace(&internalWorkspace)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.Status.Owner.Email).To(Equal("new@email.com"))
+			})
+		})
+
+		When("the owner's email is not reported in the status", func() {
+			BeforeEach(func() {
+				internalWorkspace.Spec.Owner.JwtInfo.Email = "old@email.com"
+			})
+
+			It("uses the email from the spec", func() {
+				// when
+				w, err := mapper.Default.InternalWorkspaceToWorkspace(&internalWorkspace)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.Status.Owner.Email).To(Equal("old@email.com"))
+			})
+		})
+
+		When("stored managed fields are malformed", func() {
+			BeforeEach(func() {
+				internalWorkspace.Annotations = map[string]string{
+					restworkspacesv1alpha1.AnnotationManagedFields: "not-json",
+				}
+			})
+
+			It("returns an error", func() {
+				// when
+				_, err := mapper.Default.InternalWorkspaceToWorkspace(&internalWorkspace)
+
+				// then
+				Expect(err).To(HaveOccurred())
+			})
+		})
 	})
 })
 
@@ -68,12 +136,15 @@ func buildExampleValidInternalWorkspace(displayName, workspacesNamespace, ownerN
 				workspacesv1alpha1.LabelInternalDomain + "not-expected-label": "not-empty",
 			},
 			Generation:        1,
+			ResourceVersion:   "42",
+			UID:               "7b2a5c1e-3f4d-4a8b-9c6e-1d2f3a4b5c6d",
 			CreationTimestamp: metav1.Now(),
 		},
 		Spec: workspacesv1alpha1.InternalWorkspaceSpec{
 			DisplayName: displayName,
 		},
 		Status: workspacesv1alpha1.InternalWorkspaceStatus{
+			ObservedGeneration: 1,
 			Owner: workspacesv1alpha1.UserInfoStatus{
 				Username: ownerName,
 			},
@@ -81,6 +152,16 @@ func buildExampleValidInternalWorkspace(displayName, workspacesNamespace, ownerN
 				IsHome:        true,
 				Name:          displayName,
 				TargetCluster: "target-cluster",
+				Tier:          "appstudio",
+				Phase:         workspacesv1alpha1.SpacePhaseProvisioningFailed,
+				Message:       "tier not found",
+				Namespaces: []workspacesv1alpha1.SpaceNamespace{
+					{Name: ownerName + "-tenant", Type: "default"},
+				},
+			},
+			Members: []workspacesv1alpha1.InternalWorkspaceMember{
+				{Username: ownerName, Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+				{Username: "viewer", Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
 			},
 			Conditions: []metav1.Condition{
 				{Message: "test", Type: "test", Reason: "test", Status: metav1.ConditionTrue},
@@ -99,11 +180,27 @@ func validateMappedWorkspace(w *restworkspacesv1alpha1.Workspace, from workspace
 		Not(HaveKey(workspacesv1alpha1.LabelInternalDomain+"not-expected-label")),
 	))
 	Expect(w.Generation).To(Equal(int64(1)))
+	Expect(w.ResourceVersion).To(Equal(from.ResourceVersion))
+	Expect(w.UID).To(Equal(from.UID))
 	Expect(w.CreationTimestamp).To(Equal(from.CreationTimestamp))
 	Expect(w.Spec).ToNot(BeNil())
 	Expect(w.Status).ToNot(BeNil())
 	Expect(w.Status.Space).ToNot(BeNil())
 	Expect(w.Status.Space.Name).To(Equal(from.Status.Space.Name))
 	Expect(w.Status.Space.TargetCluster).To(Equal(from.Status.Space.TargetCluster))
+	Expect(w.Status.Space.Tier).To(Equal(from.Status.Space.Tier))
+	Expect(string(w.Status.Space.Phase)).To(Equal(string(from.Status.Space.Phase)))
+	Expect(w.Status.Space.Message).To(Equal(from.Status.Space.Message))
+	Expect(w.Status.Space.Namespaces).To(HaveLen(len(from.Status.Space.Namespaces)))
+	for i, n := range from.Status.Space.Namespaces {
+		Expect(w.Status.Space.Namespaces[i].Name).To(Equal(n.Name))
+		Expect(w.Status.Space.Namespaces[i].Type).To(Equal(n.Type))
+	}
 	Expect(w.Status.Conditions).To(Equal(from.Status.Conditions))
+	Expect(w.Status.ObservedGeneration).To(Equal(from.Status.ObservedGeneration))
+	Expect(w.Status.Members).To(HaveLen(len(from.Status.Members)))
+	for i, m := range from.Status.Members {
+		Expect(w.Status.Members[i].Username).To(Equal(m.Username))
+		Expect(string(w.Status.Members[i].Role)).To(Equal(string(m.Role)))
+	}
 }
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -25,8 +27,10 @@ func (m *Mapper) WorkspaceToInternalWorkspace(workspace *restworkspacesv1alpha1.
 			APIVersion: workspacesv1alpha1.GroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Labels:     ll,
-			Generation: workspace.Generation,
+			Labels:          ll,
+			Generation:      workspace.Generation,
+			ResourceVersion: workspace.ResourceVersion,
+			UID:             workspace.UID,
 		},
 		Spec: workspacesv1alpha1.InternalWorkspaceSpec{
 			DisplayName: workspace.Name,
@@ -45,6 +49,14 @@ func (m *Mapper) WorkspaceToInternalWorkspace(workspace *restworkspacesv1alpha1.
 		},
 	}
 
+	if mf := workspace.GetManagedFields(); len(mf) > 0 {
+		d, err := json.Marshal(mf)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling managed fields: %w", err)
+		}
+		iw.SetAnnotations(map[string]string{restworkspacesv1alpha1.AnnotationManagedFields: string(d)})
+	}
+
 	if o := workspace.Status.Owner; o != nil {
 		iw.Spec.Owner.JwtInfo.Email = o.Email
 	}
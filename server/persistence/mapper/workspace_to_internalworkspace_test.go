@@ -53,6 +53,29 @@ var _ = Describe("WorkspaceToInternalworkspace", func() {
 				Expect(iw.Spec.Visibility).To(Equal(workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
 			})
 		})
+
+		When("it has managed fields", func() {
+			BeforeEach(func() {
+				workspace.ManagedFields = []metav1.ManagedFieldsEntry{
+					{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply},
+				}
+			})
+
+			It("stores the managed fields in an annotation", func() {
+				// when
+				iw, err := mapper.Default.WorkspaceToInternalWorkspace(&workspace)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				validateMappedInternalWorkspace(iw, &workspace)
+				Expect(iw.ManagedFields).To(BeEmpty())
+				Expect(iw.GetAnnotations()).To(HaveKey(restworkspacesv1alpha1.AnnotationManagedFields))
+
+				w, err := mapper.Default.InternalWorkspaceToWorkspace(iw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.ManagedFields).To(Equal(workspace.ManagedFields))
+			})
+		})
 	})
 })
 
@@ -65,7 +88,9 @@ func buildExampleValidWorkspace(displayName, ownerName string) restworkspacesv1a
 				"expected-label": "not-empty",
 				workspacesv1alpha1.LabelInternalDomain + "not-expected-label": "not-empty",
 			},
-			Generation: 1,
+			Generation:      1,
+			ResourceVersion: "42",
+			UID:             "7b2a5c1e-3f4d-4a8b-9c6e-1d2f3a4b5c6d",
 		},
 		Spec: restworkspacesv1alpha1.WorkspaceSpec{
 			Visibility: restworkspacesv1alpha1.WorkspaceVisibilityCommunity,
@@ -84,6 +109,8 @@ func buildExampleValidWorkspace(displayName, ownerName string) restworkspacesv1a
 func validateMappedInternalWorkspace(w *workspacesv1alpha1.InternalWorkspace, from *restworkspacesv1alpha1.Workspace) {
 	Expect(w).ToNot(BeNil())
 	Expect(w.Generation).To(Equal(int64(1)))
+	Expect(w.ResourceVersion).To(Equal(from.ResourceVersion))
+	Expect(w.UID).To(Equal(from.UID))
 	Expect(w.GetName()).To(BeZero())
 	Expect(w.GetNamespace()).To(BeZero())
 	Expect(w.GetLabels()).To(HaveKey("expected-label"))
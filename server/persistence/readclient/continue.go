@@ -0,0 +1,99 @@
+package readclient
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// continueKeySize is the size of the randomly generated key used to sign continue tokens
+const continueKeySize = 32
+
+// DefaultContinueTTL is how long continue tokens are valid for, if not configured
+const DefaultContinueTTL = 15 * time.Minute
+
+// continueToken is the position in the list of workspaces visible to a user
+// the next page starts from
+type continueToken struct {
+	// Namespace of the last workspace returned
+	Namespace string `json:"ns"`
+	// Name of the last workspace returned
+	Name string `json:"n"`
+	// IssuedAt the Unix time the token has been issued at
+	IssuedAt int64 `json:"iat"`
+}
+
+// expired returns true if the token has been issued more than ttl ago
+func (t *continueToken) expired(ttl time.Duration) bool {
+	return time.Since(time.Unix(t.IssuedAt, 0)) > ttl
+}
+
+// after returns true if a workspace with the given namespace and name
+// sorts after the token's position
+func (t *continueToken) after(namespace, name string) bool {
+	if namespace != t.Namespace {
+		return namespace > t.Namespace
+	}
+	return name > t.Name
+}
+
+// newContinueKey generates a random key for signing continue tokens
+func newContinueKey() []byte {
+	k := make([]byte, continueKeySize)
+	if _, err := rand.Read(k); err != nil {
+		panic(fmt.Sprintf("error generating continue token key: %v", err))
+	}
+	return k
+}
+
+// encodeContinue builds an opaque token for the given position.
+// The token is signed and bound to the user, so that it can not be tampered with
+// or used by other users.
+func encodeContinue(key []byte, user string, t continueToken) (string, error) {
+	p, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	ep := base64.RawURLEncoding.EncodeToString(p)
+	s := base64.RawURLEncoding.EncodeToString(signContinue(key, user, ep))
+	return ep + "." + s, nil
+}
+
+// decodeContinue verifies the token's signature and returns the position it encodes
+func decodeContinue(key []byte, user, token string) (*continueToken, error) {
+	ep, es, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, errors.New("malformed continue token")
+	}
+
+	s, err := base64.RawURLEncoding.DecodeString(es)
+	if err != nil || !hmac.Equal(s, signContinue(key, user, ep)) {
+		return nil, errors.New("invalid continue token signature")
+	}
+
+	p, err := base64.RawURLEncoding.DecodeString(ep)
+	if err != nil {
+		return nil, fmt.Errorf("malformed continue token: %w", err)
+	}
+
+	t := continueToken{}
+	if err := json.Unmarshal(p, &t); err != nil {
+		return nil, fmt.Errorf("malformed continue token: %w", err)
+	}
+	return &t, nil
+}
+
+func signContinue(key []byte, user, payload string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(user))
+	m.Write([]byte{0})
+	m.Write([]byte(payload))
+	return m.Sum(nil)
+}
@@ -2,6 +2,7 @@ package readclient
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -17,6 +18,11 @@ import (
 type ReadClient struct {
 	internalClient clientinterface.InternalWorkspacesReadClient
 	mapper         clientinterface.InternalWorkspacesMapper
+
+	// continueKey is the key used to sign the continue tokens of paginated lists
+	continueKey []byte
+	// continueTTL is how long the continue tokens of paginated lists are valid for
+	continueTTL time.Duration
 }
 
 // NewDefaultWithCache creates a controller-runtime cache and use it as KubeReadClient's backend.
@@ -41,5 +47,24 @@ func New(internalClient clientinterface.InternalWorkspacesReadClient, mapper cli
 	return &ReadClient{
 		internalClient: internalClient,
 		mapper:         mapper,
+		continueKey:    newContinueKey(),
+		continueTTL:    DefaultContinueTTL,
+	}
+}
+
+// WithContinueKey sets the key used to sign the continue tokens of paginated lists.
+// By default, a random key is generated, so tokens are valid only for the ReadClient that issued them.
+// Replicas serving the same clients need to share the same key.
+func (c *ReadClient) WithContinueKey(key []byte) *ReadClient {
+	if len(key) > 0 {
+		c.continueKey = key
 	}
+	return c
+}
+
+// WithContinueTTL sets how long the continue tokens of paginated lists are valid for.
+// By default, they are valid for DefaultContinueTTL.
+func (c *ReadClient) WithContinueTTL(ttl time.Duration) *ReadClient {
+	c.continueTTL = ttl
+	return c
 }
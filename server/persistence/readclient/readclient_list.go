@@ -3,9 +3,12 @@ package readclient
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,7 +34,7 @@ func (c *ReadClient) ListUserWorkspaces(
 	}
 
 	// map list options
-	listOpts, err := mapListOptions(opts...)
+	listOpts, err := MapListOptions(opts...)
 	if err != nil {
 		return err
 	}
@@ -48,8 +51,14 @@ func (c *ReadClient) ListUserWorkspaces(
 		return kerrors.NewInternalError(fmt.Errorf("error retrieving the list of workspaces for user %v", user))
 	}
 
-	// filter by namespace
+	// filter by namespace and fields
 	filterByNamespace(ww, listOpts.Namespace)
+	filterByFields(ww, listOpts.FieldSelector)
+
+	// select the requested page
+	if err := c.paginate(user, ww, listOpts); err != nil {
+		return err
+	}
 
 	for i := range ww.Items {
 		// apply is-owner label
@@ -81,6 +90,81 @@ func filterByNamespace(ww *restworkspacesv1alpha1.WorkspaceList, namespace strin
 	ww.Items = fww
 }
 
+// paginate sorts the workspaces by namespace and name, and selects the page
+// starting after the position encoded in the continue token and made of at most limit items.
+// If more workspaces are available, the list's Continue and RemainingItemCount are set.
+// Invalid or expired continue tokens are rejected with a ResourceExpired error, so that clients list again.
+func (c *ReadClient) paginate(user string, ww *restworkspacesv1alpha1.WorkspaceList, listOpts *client.ListOptions) error {
+	slices.SortFunc(ww.Items, func(a, b restworkspacesv1alpha1.Workspace) int {
+		if n := strings.Compare(a.Namespace, b.Namespace); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if listOpts.Continue != "" {
+		t, err := decodeContinue(c.continueKey, user, listOpts.Continue)
+		if err != nil {
+			return kerrors.NewResourceExpired(fmt.Sprintf("invalid continue token, list again: %v", err))
+		}
+		if t.expired(c.continueTTL) {
+			return kerrors.NewResourceExpired("the continue token has expired, list again")
+		}
+
+		i := slices.IndexFunc(ww.Items, func(w restworkspacesv1alpha1.Workspace) bool {
+			return t.after(w.Namespace, w.Name)
+		})
+		if i == -1 {
+			i = len(ww.Items)
+		}
+		ww.Items = ww.Items[i:]
+	}
+
+	if listOpts.Limit <= 0 || int64(len(ww.Items)) <= listOpts.Limit {
+		return nil
+	}
+
+	ri := int64(len(ww.Items)) - listOpts.Limit
+	ww.Items = ww.Items[:listOpts.Limit]
+	l := ww.Items[len(ww.Items)-1]
+	ct, err := encodeContinue(c.continueKey, user, continueToken{Namespace: l.Namespace, Name: l.Name, IssuedAt: time.Now().Unix()})
+	if err != nil {
+		return kerrors.NewInternalError(fmt.Errorf("error building continue token: %w", err))
+	}
+	ww.Continue = ct
+	ww.RemainingItemCount = &ri
+	return nil
+}
+
+func filterByFields(ww *restworkspacesv1alpha1.WorkspaceList, selector fields.Selector) {
+	if selector == nil || selector.Empty() {
+		return
+	}
+
+	fww := []restworkspacesv1alpha1.Workspace{}
+	for _, w := range ww.Items {
+		if selector.Matches(workspaceFields(&w)) {
+			fww = append(fww, w)
+		}
+	}
+	ww.Items = fww
+}
+
+// workspaceFields returns the fields of a Workspace that can be used in field selectors
+func workspaceFields(w *restworkspacesv1alpha1.Workspace) fields.Set {
+	ff := fields.Set{
+		"metadata.name":      w.Name,
+		"metadata.namespace": w.Namespace,
+		"spec.visibility":    string(w.Spec.Visibility),
+	}
+	if s := w.Status.Space; s != nil {
+		ff["status.space.targetCluster"] = s.TargetCluster
+	} else {
+		ff["status.space.targetCluster"] = ""
+	}
+	return ff
+}
+
 func filterByLabels(ww *workspacesv1alpha1.InternalWorkspaceList, listOpts *client.ListOptions) (*workspacesv1alpha1.InternalWorkspaceList, error) {
 	rww := workspacesv1alpha1.InternalWorkspaceList{}
 	for _, w := range ww.Items {
@@ -94,10 +178,22 @@ func filterByLabels(ww *workspacesv1alpha1.InternalWorkspaceList, listOpts *clie
 	return &rww, nil
 }
 
-func mapListOptions(opts ...client.ListOption) (*client.ListOptions, error) {
+// MapListOptions applies the options and validates their selectors,
+// returning a BadRequest error if they use unsupported fields or reserved labels
+func MapListOptions(opts ...client.ListOption) (*client.ListOptions, error) {
 	listOpts := client.ListOptions{}
 	listOpts.ApplyOptions(opts)
 
+	if listOpts.FieldSelector != nil {
+		for _, r := range listOpts.FieldSelector.Requirements() {
+			if _, ok := workspaceFields(&restworkspacesv1alpha1.Workspace{})[r.Field]; !ok {
+				return nil, kerrors.NewBadRequest(fmt.Sprintf(
+					"invalid field selector: field '%s' is not supported, supported fields are %s",
+					r.Field, strings.Join(supportedFieldSelectors(), ", ")))
+			}
+		}
+	}
+
 	if listOpts.LabelSelector == nil {
 		return &listOpts, nil
 	}
@@ -105,17 +201,36 @@ func mapListOptions(opts ...client.ListOption) (*client.ListOptions, error) {
 	rr, _ := listOpts.LabelSelector.Requirements()
 	for _, ls := range rr {
 		if strings.HasPrefix(ls.Key(), workspacesv1alpha1.LabelInternalDomain) {
-			return nil, fmt.Errorf("invalid label selector: key '%s' is reserved", ls.Key())
+			return nil, kerrors.NewBadRequest(fmt.Sprintf("invalid label selector: key '%s' is reserved", ls.Key()))
 		}
 	}
 
 	return &listOpts, nil
 }
 
+// supportedFieldSelectors returns the sorted list of the fields supported in field selectors
+func supportedFieldSelectors() []string {
+	ff := []string{}
+	for f := range workspaceFields(&restworkspacesv1alpha1.Workspace{}) {
+		ff = append(ff, f)
+	}
+	slices.Sort(ff)
+	return ff
+}
+
+// MatchesListOptions returns true if the Workspace satisfies the label and field selectors
+// of the list options, as they are applied when listing workspaces
+func MatchesListOptions(listOpts *client.ListOptions, w *restworkspacesv1alpha1.Workspace) bool {
+	if !matchesListOpts(listOpts, w.GetLabels()) {
+		return false
+	}
+	return listOpts == nil || listOpts.FieldSelector == nil || listOpts.FieldSelector.Matches(workspaceFields(w))
+}
+
 func matchesListOpts(
 	listOpts *client.ListOptions,
 	objLabels map[string]string,
 ) bool {
-	return objLabels == nil || listOpts == nil || listOpts.LabelSelector == nil ||
+	return listOpts == nil || listOpts.LabelSelector == nil ||
 		listOpts.LabelSelector.Matches(labels.Set(objLabels))
 }
@@ -2,7 +2,11 @@ package readclient_test
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,10 +15,12 @@ import (
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/mapper"
 	"github.com/konflux-workspaces/workspaces/server/persistence/readclient"
 	"github.com/konflux-workspaces/workspaces/server/persistence/readclient/mocks"
 )
@@ -103,6 +109,14 @@ var _ = Describe("List", func() {
 				},
 			},
 		}, []metav1.ObjectMeta{}),
+		Entry("unlabeled InternalWorkspaces are not matched", []workspacesv1alpha1.InternalWorkspace{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unlabeled",
+					Namespace: "unlabeled",
+				},
+			},
+		}, []metav1.ObjectMeta{}),
 		Entry("single matching InternalWorkspaces returns one workspace", []workspacesv1alpha1.InternalWorkspace{
 			{
 				ObjectMeta: metav1.ObjectMeta{
@@ -362,7 +376,287 @@ var _ = Describe("List", func() {
 			err := rc.ListUserWorkspaces(ctx, user, &actualWorkspaces, client.MatchingLabels{internalLabel: "whatever"})
 
 			// then
-			Expect(err).To(MatchError(fmt.Errorf("invalid label selector: key '%s' is reserved", internalLabel)))
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+			Expect(err.(kerrors.APIStatus).Status().Code).To(BeEquivalentTo(http.StatusBadRequest))
+			Expect(err.Error()).To(Equal(fmt.Sprintf("invalid label selector: key '%s' is reserved", internalLabel)))
+		})
+
+		It("returns BadRequest if unsupported fields are used", func() {
+			// given
+			frc.EXPECT().
+				ListAsUser(ctx, user, gomock.Any()).
+				Return(nil).
+				Times(1)
+
+			// when
+			actualWorkspaces := restworkspacesv1alpha1.WorkspaceList{}
+			err := rc.ListUserWorkspaces(ctx, user, &actualWorkspaces, client.MatchingFields{"spec.owner": "whatever"})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.owner"))
 		})
 	})
 })
+
+var _ = Describe("List with field selectors", func() {
+	var ctx context.Context
+	var ctrl *gomock.Controller
+	var frc *mocks.MockFakeIWReadClient
+	var rc *readclient.ReadClient
+	user := "user"
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		frc = mocks.NewMockFakeIWReadClient(ctrl)
+		rc = readclient.New(frc, mapper.Default)
+
+		iw := func(owner, name string, visibility workspacesv1alpha1.InternalWorkspaceVisibility, cluster string) workspacesv1alpha1.InternalWorkspace {
+			return workspacesv1alpha1.InternalWorkspace{
+				ObjectMeta: metav1.ObjectMeta{Name: owner + "-" + name, Namespace: "workspaces-system"},
+				Spec:       workspacesv1alpha1.InternalWorkspaceSpec{DisplayName: name, Visibility: visibility},
+				Status: workspacesv1alpha1.InternalWorkspaceStatus{
+					Owner: workspacesv1alpha1.UserInfoStatus{Username: owner},
+					Space: workspacesv1alpha1.SpaceInfo{Name: owner + "-" + name, TargetCluster: cluster},
+				},
+			}
+		}
+		frc.EXPECT().
+			ListAsUser(ctx, user, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, iww *workspacesv1alpha1.InternalWorkspaceList) error {
+				iww.Items = []workspacesv1alpha1.InternalWorkspace{
+					iw("user", "default", workspacesv1alpha1.InternalWorkspaceVisibilityPrivate, "cluster-a"),
+					iw("user", "public", workspacesv1alpha1.InternalWorkspaceVisibilityCommunity, "cluster-b"),
+					iw("alice", "default", workspacesv1alpha1.InternalWorkspaceVisibilityCommunity, "cluster-a"),
+				}
+				return nil
+			})
+		frc.EXPECT().
+			UserHasDirectAccess(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(false, nil).
+			AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	DescribeTable("filters the workspaces", func(selector string, expectedKeys ...string) {
+		// given
+		s, err := fields.ParseSelector(selector)
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		ww := restworkspacesv1alpha1.WorkspaceList{}
+		err = rc.ListUserWorkspaces(ctx, user, &ww, client.MatchingFieldsSelector{Selector: s})
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		kk := []string{}
+		for _, w := range ww.Items {
+			kk = append(kk, w.Namespace+"/"+w.Name)
+		}
+		Expect(kk).To(Equal(expectedKeys))
+	},
+		Entry("by visibility", "spec.visibility=community", "alice/default", "user/public"),
+		Entry("by owner", "metadata.namespace=user", "user/default", "user/public"),
+		Entry("by target cluster", "status.space.targetCluster=cluster-a", "alice/default", "user/default"),
+		Entry("by name", "metadata.name=default", "alice/default", "user/default"),
+		Entry("by multiple fields", "spec.visibility=community,status.space.targetCluster!=cluster-b", "alice/default"),
+	)
+})
+
+var _ = Describe("Paginated List", func() {
+	var ctx context.Context
+	var ctrl *gomock.Controller
+	var frc *mocks.MockFakeIWReadClient
+	var mp *mocks.MockFakeIWMapper
+	var rc *readclient.ReadClient
+	user := "user"
+
+	// workspaces in expected order
+	expectedKeys := []string{"alice/a", "alice/b", "bob/a", "user/default", "user/z"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = gomock.NewController(GinkgoT())
+		frc = mocks.NewMockFakeIWReadClient(ctrl)
+		mp = mocks.NewMockFakeIWMapper(ctrl)
+		rc = readclient.New(frc, mp)
+
+		// internal client returns the workspaces unsorted
+		frc.EXPECT().
+			ListAsUser(ctx, user, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, iww *workspacesv1alpha1.InternalWorkspaceList) error {
+				for _, k := range []string{"user/z", "bob/a", "alice/b", "user/default", "alice/a"} {
+					ns, n, _ := strings.Cut(k, "/")
+					iww.Items = append(iww.Items, workspacesv1alpha1.InternalWorkspace{
+						ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: ns},
+					})
+				}
+				return nil
+			}).
+			AnyTimes()
+		frc.EXPECT().
+			UserHasDirectAccess(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(false, nil).
+			AnyTimes()
+		mp.EXPECT().
+			InternalWorkspaceListToWorkspaceList(gomock.Any()).
+			DoAndReturn(func(iww *workspacesv1alpha1.InternalWorkspaceList) (*restworkspacesv1alpha1.WorkspaceList, error) {
+				ww := restworkspacesv1alpha1.WorkspaceList{Items: []restworkspacesv1alpha1.Workspace{}}
+				for _, w := range iww.Items {
+					ww.Items = append(ww.Items, restworkspacesv1alpha1.Workspace{ObjectMeta: w.ObjectMeta})
+				}
+				return &ww, nil
+			}).
+			AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	keys := func(ww *restworkspacesv1alpha1.WorkspaceList) []string {
+		kk := []string{}
+		for _, w := range ww.Items {
+			kk = append(kk, w.Namespace+"/"+w.Name)
+		}
+		return kk
+	}
+
+	It("should return all the workspaces sorted if no limit is set", func() {
+		// when
+		ww := restworkspacesv1alpha1.WorkspaceList{}
+		err := rc.ListUserWorkspaces(ctx, user, &ww)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys(&ww)).To(Equal(expectedKeys))
+		Expect(ww.Continue).To(BeEmpty())
+		Expect(ww.RemainingItemCount).To(BeNil())
+	})
+
+	It("should return the first page", func() {
+		// when
+		ww := restworkspacesv1alpha1.WorkspaceList{}
+		err := rc.ListUserWorkspaces(ctx, user, &ww, client.Limit(2))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys(&ww)).To(Equal(expectedKeys[:2]))
+		Expect(ww.Continue).NotTo(BeEmpty())
+		Expect(ww.RemainingItemCount).To(HaveValue(BeEquivalentTo(3)))
+	})
+
+	It("should walk through all the pages", func() {
+		// given
+		kk := []string{}
+		c := ""
+
+		// when
+		for i := 0; i < len(expectedKeys); i++ {
+			ww := restworkspacesv1alpha1.WorkspaceList{}
+			Expect(rc.ListUserWorkspaces(ctx, user, &ww, client.Limit(2), client.Continue(c))).To(Succeed())
+			kk = append(kk, keys(&ww)...)
+			if c = ww.Continue; c == "" {
+				Expect(ww.RemainingItemCount).To(BeNil())
+				break
+			}
+		}
+
+		// then
+		Expect(kk).To(Equal(expectedKeys))
+		Expect(c).To(BeEmpty())
+	})
+
+	When("a continue token has been issued", func() {
+		var token string
+
+		BeforeEach(func() {
+			ww := restworkspacesv1alpha1.WorkspaceList{}
+			Expect(rc.ListUserWorkspaces(ctx, user, &ww, client.Limit(2))).To(Succeed())
+			token = ww.Continue
+		})
+
+		DescribeTable("should reject invalid continue tokens", func(tamper func(string) string) {
+			// when
+			ww := restworkspacesv1alpha1.WorkspaceList{}
+			err := rc.ListUserWorkspaces(ctx, user, &ww, client.Limit(2), client.Continue(tamper(token)))
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsResourceExpired(err)).To(BeTrue())
+		},
+			Entry("malformed", func(string) string { return "not-a-token" }),
+			Entry("tampered position", func(t string) string {
+				_, s, _ := strings.Cut(t, ".")
+				p := base64.RawURLEncoding.EncodeToString([]byte(`{"ns":"bob","n":"a"}`))
+				return p + "." + s
+			}),
+			Entry("tampered signature", func(t string) string { return t + "x" }),
+		)
+
+		It("should reject the token for another user", func() {
+			// given
+			frc.EXPECT().
+				ListAsUser(ctx, "other", gomock.Any()).
+				Return(nil)
+
+			// when
+			ww := restworkspacesv1alpha1.WorkspaceList{}
+			err := rc.ListUserWorkspaces(ctx, "other", &ww, client.Continue(token))
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsResourceExpired(err)).To(BeTrue())
+		})
+
+		It("should reject the token once expired", func() {
+			// given
+			rc.WithContinueTTL(-time.Second)
+
+			// when
+			ww := restworkspacesv1alpha1.WorkspaceList{}
+			err := rc.ListUserWorkspaces(ctx, user, &ww, client.Limit(2), client.Continue(token))
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsResourceExpired(err)).To(BeTrue())
+			Expect(kerrors.ReasonForError(err)).To(Equal(metav1.StatusReasonExpired))
+		})
+
+		It("should reject the token in a ReadClient with a different key", func() {
+			// given
+			orc := readclient.New(frc, mp)
+
+			// when
+			ww := restworkspacesv1alpha1.WorkspaceList{}
+			err := orc.ListUserWorkspaces(ctx, user, &ww, client.Continue(token))
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsResourceExpired(err)).To(BeTrue())
+		})
+	})
+
+	It("should accept tokens issued by a ReadClient sharing the same key", func() {
+		// given
+		key := []byte("a-shared-key")
+		rc.WithContinueKey(key)
+		orc := readclient.New(frc, mp).WithContinueKey(key)
+		ww := restworkspacesv1alpha1.WorkspaceList{}
+		Expect(rc.ListUserWorkspaces(ctx, user, &ww, client.Limit(2))).To(Succeed())
+
+		// when
+		oww := restworkspacesv1alpha1.WorkspaceList{}
+		err := orc.ListUserWorkspaces(ctx, user, &oww, client.Limit(2), client.Continue(ww.Continue))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys(&oww)).To(Equal(expectedKeys[2:4]))
+	})
+})
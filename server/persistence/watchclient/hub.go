@@ -0,0 +1,259 @@
+package watchclient
+
+import (
+	"slices"
+	"sync"
+
+	toolscache "k8s.io/client-go/tools/cache"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+// record a change notified by the informers
+type record struct {
+	// rv the resource version of the change
+	rv uint64
+	// space the name of the Space the change refers to
+	space string
+	// user the user bound by a changed SpaceBinding, empty for InternalWorkspace changes
+	user string
+	// added true if the InternalWorkspace has been created
+	added bool
+	// deleted true if the InternalWorkspace has been deleted
+	deleted bool
+	// object the InternalWorkspace after the change, or its last known state if deleted
+	object *workspacesv1alpha1.InternalWorkspace
+	// old the InternalWorkspace before the change
+	old *workspacesv1alpha1.InternalWorkspace
+}
+
+// mayConcern returns true if the change may have changed the workspaces visible to `user`
+func (r *record) mayConcern(user string) bool {
+	if r.object == nil {
+		return r.user == user || r.user == workspacesv1alpha1.PublicViewerName
+	}
+	return mayBeVisibleTo(r.object, user) || (r.old != nil && mayBeVisibleTo(r.old, user))
+}
+
+// hub keeps a bounded history of changes and notifies them to the registered watchers
+type hub struct {
+	mu sync.Mutex
+
+	historySize int
+	history     []record
+	// boundary the oldest resource version watches can be resumed from
+	boundary uint64
+	// lastRV the resource version of the last notified change
+	lastRV uint64
+
+	workspaces map[string]*workspacesv1alpha1.InternalWorkspace
+	watchers   map[*watcher]struct{}
+}
+
+func newHub(historySize int) *hub {
+	return &hub{
+		historySize: historySize,
+		workspaces:  map[string]*workspacesv1alpha1.InternalWorkspace{},
+		watchers:    map[*watcher]struct{}{},
+	}
+}
+
+// subscribe registers the watcher and returns the last resource version,
+// the boundary, and the changes that happened after resource version `from`
+func (h *hub) subscribe(w *watcher, from uint64) (uint64, uint64, []record) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.watchers[w] = struct{}{}
+
+	i, _ := slices.BinarySearchFunc(h.history, from, func(r record, rv uint64) int {
+		switch {
+		case r.rv <= rv:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return h.lastRV, h.boundary, slices.Clone(h.history[i:])
+}
+
+// unsubscribe unregisters the watcher
+func (h *hub) unsubscribe(w *watcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.watchers, w)
+}
+
+// workspace returns the last known state of the InternalWorkspace related to `space`
+func (h *hub) workspace(space string) *workspacesv1alpha1.InternalWorkspace {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if w, ok := h.workspaces[space]; ok {
+		return w.DeepCopy()
+	}
+	return nil
+}
+
+func (h *hub) onInternalWorkspaceAdd(obj interface{}, isInInitialList bool) {
+	w, ok := obj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.workspaces[internalWorkspaceKey(w)] = w
+	if isInInitialList {
+		rv, _ := parseResourceVersion(w.ResourceVersion)
+		h.boundary = max(h.boundary, rv)
+		h.lastRV = max(h.lastRV, rv)
+		return
+	}
+	h.record(record{space: internalWorkspaceKey(w), added: true, object: w}, w.ResourceVersion, nil)
+}
+
+func (h *hub) onInternalWorkspaceUpdate(oldObj, newObj interface{}) {
+	o, ok := oldObj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return
+	}
+	w, ok := newObj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if k := internalWorkspaceKey(o); k != internalWorkspaceKey(w) {
+		delete(h.workspaces, k)
+	}
+	h.workspaces[internalWorkspaceKey(w)] = w
+	h.record(record{space: internalWorkspaceKey(w), object: w, old: o}, w.ResourceVersion, nil)
+}
+
+func (h *hub) onInternalWorkspaceDelete(obj interface{}) {
+	if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	w, ok := obj.(*workspacesv1alpha1.InternalWorkspace)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.workspaces, internalWorkspaceKey(w))
+	h.record(record{space: internalWorkspaceKey(w), deleted: true, object: w}, w.ResourceVersion, w)
+}
+
+func (h *hub) onSpaceBindingAdd(obj interface{}, isInInitialList bool) {
+	sb, ok := obj.(*toolchainv1alpha1.SpaceBinding)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if isInInitialList {
+		rv, _ := parseResourceVersion(sb.ResourceVersion)
+		h.boundary = max(h.boundary, rv)
+		h.lastRV = max(h.lastRV, rv)
+		return
+	}
+	h.recordSpaceBinding(sb)
+}
+
+func (h *hub) onSpaceBindingUpdate(oldObj, newObj interface{}) {
+	o, ok := oldObj.(*toolchainv1alpha1.SpaceBinding)
+	if !ok {
+		return
+	}
+	sb, ok := newObj.(*toolchainv1alpha1.SpaceBinding)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// a SpaceBinding moved to a different user or Space affects both the old and the new ones
+	if o.Spec.MasterUserRecord != sb.Spec.MasterUserRecord || o.Spec.Space != sb.Spec.Space {
+		old := o.DeepCopy()
+		old.ResourceVersion = sb.ResourceVersion
+		h.recordSpaceBinding(old)
+	}
+	h.recordSpaceBinding(sb)
+}
+
+func (h *hub) onSpaceBindingDelete(obj interface{}) {
+	if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	sb, ok := obj.(*toolchainv1alpha1.SpaceBinding)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.recordSpaceBinding(sb)
+}
+
+// recordSpaceBinding records a change on a SpaceBinding.
+// The caller must hold the lock.
+func (h *hub) recordSpaceBinding(sb *toolchainv1alpha1.SpaceBinding) {
+	h.record(record{space: sb.Spec.Space, user: sb.Spec.MasterUserRecord}, sb.ResourceVersion, nil)
+}
+
+// record appends a change to the history and notifies the interested watchers.
+// The caller must hold the lock.
+func (h *hub) record(r record, resourceVersion string, deleted *workspacesv1alpha1.InternalWorkspace) {
+	rv, err := parseResourceVersion(resourceVersion)
+	if err != nil || rv == 0 {
+		// the cache always provides a resource version, just in case
+		// the change is notified with the last known one
+		rv = h.lastRV
+	}
+	r.rv = max(rv, h.lastRV)
+	h.lastRV = r.rv
+
+	h.history = append(h.history, r)
+	if l := len(h.history); l > h.historySize {
+		evicted := h.history[:l-h.historySize]
+		h.boundary = max(h.boundary, evicted[len(evicted)-1].rv)
+		h.history = slices.Clone(h.history[l-h.historySize:])
+	}
+
+	for w := range h.watchers {
+		if r.mayConcern(w.user) || w.visible(r.space) {
+			w.notify(r.rv, r.space, deleted)
+		}
+	}
+}
+
+// internalWorkspaceKey returns the key used to index an InternalWorkspace,
+// consistent with the one used for the Workspaces it is mapped to
+func internalWorkspaceKey(w *workspacesv1alpha1.InternalWorkspace) string {
+	if w.Status.Space.Name != "" {
+		return w.Status.Space.Name
+	}
+	return w.Status.Owner.Username + "/" + w.Spec.DisplayName
+}
+
+// mayBeVisibleTo returns true if the InternalWorkspace may be visible to `user`
+// according to its content
+func mayBeVisibleTo(w *workspacesv1alpha1.InternalWorkspace, user string) bool {
+	isUser := func(m workspacesv1alpha1.InternalWorkspaceMember) bool { return m.Username == user }
+	return w.Spec.Visibility == workspacesv1alpha1.InternalWorkspaceVisibilityCommunity ||
+		w.Status.Owner.Username == user ||
+		slices.ContainsFunc(w.Spec.Members, isUser) ||
+		slices.ContainsFunc(w.Status.Members, isUser)
+}
@@ -0,0 +1,470 @@
+package watchclient
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/persistence/clientinterface"
+	"github.com/konflux-workspaces/workspaces/server/persistence/mapper"
+	"github.com/konflux-workspaces/workspaces/server/persistence/readclient"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+const (
+	// DefaultBookmarkInterval the default interval between two bookmark events
+	DefaultBookmarkInterval = time.Minute
+	// DefaultHistorySize the default number of changes retained for resuming watches
+	DefaultHistorySize = 1024
+)
+
+var _ workspace.WorkspaceWatcher = &WatchClient{}
+
+// WorkspacesReader lists and reads the workspaces visible to users
+type WorkspacesReader interface {
+	workspace.WorkspaceLister
+	workspace.WorkspaceReader
+}
+
+// Options configures a WatchClient
+type Options struct {
+	// BookmarkInterval the interval between two bookmark events
+	BookmarkInterval time.Duration
+	// HistorySize the number of changes retained for resuming watches
+	HistorySize int
+}
+
+// WatchClient implements the WorkspaceWatcher interface on top of
+// the informers watching InternalWorkspaces and SpaceBindings
+type WatchClient struct {
+	reader           WorkspacesReader
+	mapper           clientinterface.InternalWorkspacesMapper
+	hub              *hub
+	bookmarkInterval time.Duration
+}
+
+// NewDefault creates a new WatchClient with default options and the default InternalWorkspaces/Workspaces mapper
+func NewDefault(ctx context.Context, informers cache.Informers, reader WorkspacesReader) (*WatchClient, error) {
+	return New(ctx, informers, reader, mapper.Default, Options{})
+}
+
+// New creates a new WatchClient that is notified of changes by the provided informers
+// and uses the provided reader to retrieve the workspaces visible to users.
+// IMPORTANT: to support resuming watches, the WatchClient should be created before the informers are started.
+func New(
+	ctx context.Context,
+	informers cache.Informers,
+	reader WorkspacesReader,
+	mapper clientinterface.InternalWorkspacesMapper,
+	opts Options,
+) (*WatchClient, error) {
+	if opts.BookmarkInterval <= 0 {
+		opts.BookmarkInterval = DefaultBookmarkInterval
+	}
+	if opts.HistorySize <= 0 {
+		opts.HistorySize = DefaultHistorySize
+	}
+
+	h := newHub(opts.HistorySize)
+	iwi, err := informers.GetInformer(ctx, &workspacesv1alpha1.InternalWorkspace{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := iwi.AddEventHandler(toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc:    h.onInternalWorkspaceAdd,
+		UpdateFunc: h.onInternalWorkspaceUpdate,
+		DeleteFunc: h.onInternalWorkspaceDelete,
+	}); err != nil {
+		return nil, err
+	}
+
+	sbi, err := informers.GetInformer(ctx, &toolchainv1alpha1.SpaceBinding{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sbi.AddEventHandler(toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc:    h.onSpaceBindingAdd,
+		UpdateFunc: h.onSpaceBindingUpdate,
+		DeleteFunc: h.onSpaceBindingDelete,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &WatchClient{
+		reader:           reader,
+		mapper:           mapper,
+		hub:              h,
+		bookmarkInterval: opts.BookmarkInterval,
+	}, nil
+}
+
+// WatchUserWorkspaces watches the workspaces `user` has access to.
+// The visibility rules and the selectors are the same ones applied when listing workspaces.
+// Once the watch is started, only the workspaces affected by a change are evaluated again.
+//
+// If a resource version is provided in the raw list options, only the changes
+// that happened after it are notified. If the resource version is too old,
+// a ResourceExpired error is returned.
+func (c *WatchClient) WatchUserWorkspaces(ctx context.Context, user string, opts ...client.ListOption) (watch.Interface, error) {
+	l := log.FromContext(ctx).With("user", user)
+
+	// parse options
+	listOpts, err := readclient.MapListOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	from, bookmarks := uint64(0), false
+	if listOpts.Raw != nil {
+		if from, err = parseResourceVersion(listOpts.Raw.ResourceVersion); err != nil {
+			return nil, kerrors.NewBadRequest(err.Error())
+		}
+		bookmarks = listOpts.Raw.AllowWatchBookmarks
+	}
+
+	// subscribe to changes
+	w := &watcher{
+		user: user,
+		listOpts: &client.ListOptions{
+			Namespace:     listOpts.Namespace,
+			LabelSelector: listOpts.LabelSelector,
+			FieldSelector: listOpts.FieldSelector,
+		},
+		signal:  make(chan struct{}, 1),
+		changed: map[string]struct{}{},
+		deleted: map[string]*workspacesv1alpha1.InternalWorkspace{},
+	}
+	rv, boundary, rr := c.hub.subscribe(w, from)
+	if from != 0 && from < boundary {
+		c.hub.unsubscribe(w)
+		return nil, kerrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", from, boundary))
+	}
+	w.setLastResourceVersion(max(rv, from))
+
+	// retrieve the current state
+	current, err := c.list(ctx, w)
+	if err != nil {
+		c.hub.unsubscribe(w)
+		return nil, err
+	}
+	w.setVisible(current)
+
+	// calculate the initial events
+	var ee []watch.Event
+	if from == 0 {
+		ee = c.initialEvents(current)
+	} else {
+		ee = c.replayEvents(w, current, from, rr)
+	}
+
+	// stream events
+	l.Debug("starting watch", "from", from, "initial-events", len(ee))
+	ch := make(chan watch.Event)
+	pw := watch.NewProxyWatcher(ch)
+	go func() {
+		defer close(ch)
+		defer c.hub.unsubscribe(w)
+		c.run(ctx, w, ch, pw.StopChan(), current, ee, bookmarks)
+	}()
+	return pw, nil
+}
+
+// run sends the initial events and then the events generated by the changes notified by the hub,
+// until either the context is done or the watch is stopped
+func (c *WatchClient) run(
+	ctx context.Context,
+	w *watcher,
+	ch chan<- watch.Event,
+	stop <-chan struct{},
+	current map[string]*restworkspacesv1alpha1.Workspace,
+	initial []watch.Event,
+	bookmarks bool,
+) {
+	send := func(e watch.Event) bool {
+		select {
+		case ch <- e:
+			return true
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for _, e := range initial {
+		if !send(e) {
+			return
+		}
+	}
+
+	t := time.NewTicker(c.bookmarkInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+
+		case <-t.C:
+			if !bookmarks {
+				continue
+			}
+			if !send(watch.Event{Type: watch.Bookmark, Object: bookmark(w.lastResourceVersion())}) {
+				return
+			}
+
+		case <-w.signal:
+			rv, changed, deleted := w.drain()
+			next, err := c.update(ctx, w, current, changed)
+			if err != nil {
+				log.FromContext(ctx).Error("error reading workspaces for watch", "error", err)
+				send(watch.Event{Type: watch.Error, Object: statusForError(err)})
+				return
+			}
+
+			for _, e := range c.diff(current, next, deleted, rv) {
+				if !send(e) {
+					return
+				}
+			}
+			current = next
+			w.setVisible(current)
+			w.setLastResourceVersion(rv)
+		}
+	}
+}
+
+// list returns the workspaces currently visible to the watcher's user, indexed by Space name
+func (c *WatchClient) list(ctx context.Context, w *watcher) (map[string]*restworkspacesv1alpha1.Workspace, error) {
+	ww := restworkspacesv1alpha1.WorkspaceList{}
+	if err := c.reader.ListUserWorkspaces(ctx, w.user, &ww, w.listOpts); err != nil {
+		return nil, err
+	}
+
+	rww := make(map[string]*restworkspacesv1alpha1.Workspace, len(ww.Items))
+	for i := range ww.Items {
+		if w.matches(&ww.Items[i]) {
+			rww[key(&ww.Items[i])] = &ww.Items[i]
+		}
+	}
+	return rww, nil
+}
+
+// update returns the workspaces visible to the watcher's user after the changes to the given spaces.
+// Only the workspaces related to the changed spaces are evaluated again, the other ones are kept from `current`.
+func (c *WatchClient) update(
+	ctx context.Context,
+	w *watcher,
+	current map[string]*restworkspacesv1alpha1.Workspace,
+	changed map[string]struct{},
+) (map[string]*restworkspacesv1alpha1.Workspace, error) {
+	next := maps.Clone(current)
+	for _, k := range sortedKeys(changed) {
+		delete(next, k)
+
+		// the workspace has been deleted
+		iw := c.hub.workspace(k)
+		if iw == nil {
+			continue
+		}
+
+		o, err := c.read(ctx, w, iw)
+		if err != nil {
+			return nil, err
+		}
+		if o == nil {
+			continue
+		}
+
+		// the key of a workspace changes when its Space is set
+		for ok, e := range next {
+			if e.Namespace == o.Namespace && e.Name == o.Name {
+				delete(next, ok)
+			}
+		}
+		next[key(o)] = o
+	}
+	return next, nil
+}
+
+// read returns the Workspace the InternalWorkspace is mapped to if it is visible to the watcher's user
+// and the watcher is interested in it, nil otherwise
+func (c *WatchClient) read(ctx context.Context, w *watcher, iw *workspacesv1alpha1.InternalWorkspace) (*restworkspacesv1alpha1.Workspace, error) {
+	if iw.Status.Owner.Username == "" {
+		return nil, nil
+	}
+
+	o := restworkspacesv1alpha1.Workspace{}
+	if err := c.reader.ReadUserWorkspace(ctx, w.user, iw.Status.Owner.Username, iw.Spec.DisplayName, &o); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !w.matches(&o) {
+		return nil, nil
+	}
+	return &o, nil
+}
+
+// initialEvents returns the ADDED events for the current state
+func (c *WatchClient) initialEvents(current map[string]*restworkspacesv1alpha1.Workspace) []watch.Event {
+	ee := make([]watch.Event, 0, len(current))
+	for _, k := range sortedKeys(current) {
+		ee = append(ee, watch.Event{Type: watch.Added, Object: current[k].DeepCopy()})
+	}
+	return ee
+}
+
+// replayEvents returns the events needed by a client that knows the state at resource version `from`
+// to catch up with the current state
+func (c *WatchClient) replayEvents(
+	w *watcher,
+	current map[string]*restworkspacesv1alpha1.Workspace,
+	from uint64,
+	rr []record,
+) []watch.Event {
+	added := map[string]bool{}
+	touched := map[string]uint64{}
+	deleted := map[string]*workspacesv1alpha1.InternalWorkspace{}
+	for _, r := range rr {
+		if r.added {
+			added[r.space] = true
+		}
+		if r.deleted {
+			deleted[r.space] = r.object
+		}
+		if r.mayConcern(w.user) {
+			touched[r.space] = max(touched[r.space], r.rv)
+		}
+	}
+
+	// objects changed or made visible after `from`
+	ee := []watch.Event{}
+	for _, k := range sortedKeys(current) {
+		o := current[k]
+		orv, _ := parseResourceVersion(o.ResourceVersion)
+		_, t := touched[k]
+		switch {
+		case orv > from && added[k]:
+			ee = append(ee, watch.Event{Type: watch.Added, Object: o.DeepCopy()})
+		case orv > from:
+			ee = append(ee, watch.Event{Type: watch.Modified, Object: o.DeepCopy()})
+		case t:
+			ee = append(ee, watch.Event{Type: watch.Added, Object: o.DeepCopy()})
+		}
+	}
+
+	// objects deleted or not visible anymore
+	for _, k := range sortedKeys(touched) {
+		if _, ok := current[k]; ok {
+			continue
+		}
+
+		iw, ok := deleted[k]
+		if !ok {
+			if iw = c.hub.workspace(k); iw == nil {
+				continue
+			}
+		}
+		if o := c.deletedWorkspace(w, iw, touched[k]); o != nil {
+			ee = append(ee, watch.Event{Type: watch.Deleted, Object: o})
+		}
+	}
+	return ee
+}
+
+// diff returns the events describing the transition from state `current` to state `next`
+func (c *WatchClient) diff(
+	current, next map[string]*restworkspacesv1alpha1.Workspace,
+	deleted map[string]*workspacesv1alpha1.InternalWorkspace,
+	rv uint64,
+) []watch.Event {
+	ee := []watch.Event{}
+	for _, k := range sortedKeys(next) {
+		n := next[k]
+		o, ok := current[k]
+		switch {
+		case !ok:
+			ee = append(ee, watch.Event{Type: watch.Added, Object: n.DeepCopy()})
+		case !equalWorkspaces(o, n):
+			ee = append(ee, watch.Event{Type: watch.Modified, Object: n.DeepCopy()})
+		}
+	}
+
+	for _, k := range sortedKeys(current) {
+		if _, ok := next[k]; ok {
+			continue
+		}
+
+		o := current[k].DeepCopy()
+		if iw, ok := deleted[k]; ok {
+			if dw, err := c.mapper.InternalWorkspaceToWorkspace(iw); err == nil {
+				dw.Labels = o.Labels
+				o = dw
+			}
+		}
+		if orv, _ := parseResourceVersion(o.ResourceVersion); orv < rv {
+			o.ResourceVersion = strconv.FormatUint(rv, 10)
+		}
+		ee = append(ee, watch.Event{Type: watch.Deleted, Object: o})
+	}
+	return ee
+}
+
+// deletedWorkspace maps the InternalWorkspace `iw` to the Workspace sent in a DELETED event,
+// returning nil if the watcher is not interested in it
+func (c *WatchClient) deletedWorkspace(w *watcher, iw *workspacesv1alpha1.InternalWorkspace, rv uint64) *restworkspacesv1alpha1.Workspace {
+	o, err := c.mapper.InternalWorkspaceToWorkspace(iw)
+	if err != nil || !w.matches(o) {
+		return nil
+	}
+	if orv, _ := parseResourceVersion(o.ResourceVersion); orv < rv {
+		o.ResourceVersion = strconv.FormatUint(rv, 10)
+	}
+	return o
+}
+
+// parseResourceVersion parses a resource version, returning 0 if it is not set
+func parseResourceVersion(rv string) (uint64, error) {
+	if rv == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseUint(rv, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resource version %q: %w", rv, err)
+	}
+	return v, nil
+}
+
+// key returns the key used to index a Workspace, i.e. the name of its Space
+func key(w *restworkspacesv1alpha1.Workspace) string {
+	if w.Status.Space != nil && w.Status.Space.Name != "" {
+		return w.Status.Space.Name
+	}
+	return w.Namespace + "/" + w.Name
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	kk := make([]string, 0, len(m))
+	for k := range m {
+		kk = append(kk, k)
+	}
+	slices.Sort(kk)
+	return kk
+}
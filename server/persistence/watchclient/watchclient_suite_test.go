@@ -0,0 +1,18 @@
+package watchclient_test
+
+import (
+	"log/slog"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/konflux-workspaces/workspaces/server/log"
+)
+
+func TestWatchclient(t *testing.T) {
+	slog.SetDefault(slog.New(&log.NoOpHandler{}))
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "WatchClient Suite")
+}
@@ -0,0 +1,475 @@
+package watchclient_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+
+	"github.com/konflux-workspaces/workspaces/server/persistence/mapper"
+	"github.com/konflux-workspaces/workspaces/server/persistence/readclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/watchclient"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+// fakeLister lists and reads the InternalWorkspaces owned by the user, the community ones,
+// and the ones the user is bound to via SpaceBindings
+type fakeLister struct {
+	mu         sync.Mutex
+	workspaces map[string]*workspacesv1alpha1.InternalWorkspace
+	bindings   map[string][]string
+	// lists the number of ListUserWorkspaces calls
+	lists int
+}
+
+func (l *fakeLister) set(w *workspacesv1alpha1.InternalWorkspace) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.workspaces[w.Name] = w
+}
+
+func (l *fakeLister) remove(w *workspacesv1alpha1.InternalWorkspace) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.workspaces, w.Name)
+}
+
+func (l *fakeLister) bind(user, space string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bindings[user] = append(l.bindings[user], space)
+}
+
+func (l *fakeLister) listCalls() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lists
+}
+
+func (l *fakeLister) isVisible(user string, w *workspacesv1alpha1.InternalWorkspace) bool {
+	return w.Status.Owner.Username == user ||
+		w.Spec.Visibility == workspacesv1alpha1.InternalWorkspaceVisibilityCommunity ||
+		slices.Contains(l.bindings[user], w.Status.Space.Name)
+}
+
+func (l *fakeLister) ListUserWorkspaces(_ context.Context, user string, objs *restworkspacesv1alpha1.WorkspaceList, opts ...client.ListOption) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lists++
+
+	listOpts, err := readclient.MapListOptions(opts...)
+	if err != nil {
+		return err
+	}
+
+	objs.Items = nil
+	for _, w := range l.workspaces {
+		if !l.isVisible(user, w) {
+			continue
+		}
+
+		ww, err := mapper.Default.InternalWorkspaceToWorkspace(w)
+		if err != nil {
+			return err
+		}
+		if readclient.MatchesListOptions(listOpts, ww) {
+			objs.Items = append(objs.Items, *ww)
+		}
+	}
+	return nil
+}
+
+func (l *fakeLister) ReadUserWorkspace(_ context.Context, user, owner, space string, obj *restworkspacesv1alpha1.Workspace, _ ...client.GetOption) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, w := range l.workspaces {
+		if w.Status.Owner.Username != owner || w.Spec.DisplayName != space || !l.isVisible(user, w) {
+			continue
+		}
+
+		ww, err := mapper.Default.InternalWorkspaceToWorkspace(w)
+		if err != nil {
+			return err
+		}
+		ww.DeepCopyInto(obj)
+		return nil
+	}
+	return kerrors.NewNotFound(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), space)
+}
+
+var _ = Describe("WatchClient", func() {
+	user := "owner"
+	otherUser := "other-user"
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var lister *fakeLister
+	var iwInformer, sbInformer *controllertest.FakeInformer
+	var cli *watchclient.WatchClient
+
+	internalWorkspace := func(name, owner string, rv int, visibility workspacesv1alpha1.InternalWorkspaceVisibility) *workspacesv1alpha1.InternalWorkspace {
+		return &workspacesv1alpha1.InternalWorkspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name + "-abcde",
+				Namespace:       "workspaces-system",
+				ResourceVersion: strconv.Itoa(rv),
+			},
+			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+				DisplayName: name,
+				Visibility:  visibility,
+			},
+			Status: workspacesv1alpha1.InternalWorkspaceStatus{
+				Space: workspacesv1alpha1.SpaceInfo{Name: name + "-space"},
+				Owner: workspacesv1alpha1.UserInfoStatus{Username: owner},
+			},
+		}
+	}
+
+	// add simulates the creation of an InternalWorkspace
+	add := func(w *workspacesv1alpha1.InternalWorkspace) {
+		lister.set(w)
+		iwInformer.Add(w)
+	}
+
+	// update simulates the update of an InternalWorkspace
+	update := func(o, w *workspacesv1alpha1.InternalWorkspace) {
+		lister.set(w)
+		iwInformer.Update(o, w)
+	}
+
+	// remove simulates the deletion of an InternalWorkspace
+	remove := func(w *workspacesv1alpha1.InternalWorkspace) {
+		lister.remove(w)
+		iwInformer.Delete(w)
+	}
+
+	nextEvent := func(w watch.Interface) watch.Event {
+		var e watch.Event
+		EventuallyWithOffset(1, w.ResultChan()).Should(Receive(&e))
+		return e
+	}
+
+	expectEvent := func(w watch.Interface, t watch.EventType, name, rv string) {
+		e := nextEvent(w)
+		ExpectWithOffset(1, e.Type).To(Equal(t))
+		ExpectWithOffset(1, e.Object).To(BeAssignableToTypeOf(&restworkspacesv1alpha1.Workspace{}))
+		ws := e.Object.(*restworkspacesv1alpha1.Workspace)
+		ExpectWithOffset(1, ws.Name).To(Equal(name))
+		ExpectWithOffset(1, ws.ResourceVersion).To(Equal(rv))
+	}
+
+	buildClient := func(opts watchclient.Options) {
+		scheme := runtime.NewScheme()
+		Expect(toolchainv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(workspacesv1alpha1.AddToScheme(scheme)).To(Succeed())
+		informers := &informertest.FakeInformers{Scheme: scheme}
+
+		var err error
+		cli, err = watchclient.New(ctx, informers, lister, mapper.Default, opts)
+		Expect(err).NotTo(HaveOccurred())
+
+		iwInformer, err = informers.FakeInformerFor(ctx, &workspacesv1alpha1.InternalWorkspace{})
+		Expect(err).NotTo(HaveOccurred())
+		sbInformer, err = informers.FakeInformerFor(ctx, &toolchainv1alpha1.SpaceBinding{})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(func() { cancel() })
+
+		lister = &fakeLister{
+			workspaces: map[string]*workspacesv1alpha1.InternalWorkspace{},
+			bindings:   map[string][]string{},
+		}
+		buildClient(watchclient.Options{})
+	})
+
+	When("no resource version is provided", func() {
+		It("should notify the visible workspaces as ADDED", func() {
+			// given
+			add(internalWorkspace("owned", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			add(internalWorkspace("community", otherUser, 2, workspacesv1alpha1.InternalWorkspaceVisibilityCommunity))
+			add(internalWorkspace("private", otherUser, 3, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+
+			// when
+			w, err := cli.WatchUserWorkspaces(ctx, user)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			expectEvent(w, watch.Added, "community", "2")
+			expectEvent(w, watch.Added, "owned", "1")
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+		})
+
+		It("should notify the changes to the visible workspaces", func() {
+			// given
+			owned := internalWorkspace("owned", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(owned)
+			w, err := cli.WatchUserWorkspaces(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			expectEvent(w, watch.Added, "owned", "1")
+
+			// when
+			add(internalWorkspace("private", otherUser, 2, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			added := internalWorkspace("added", user, 3, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(added)
+
+			// then
+			expectEvent(w, watch.Added, "added", "3")
+
+			// when
+			updated := owned.DeepCopy()
+			updated.ResourceVersion = "4"
+			updated.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+			update(owned, updated)
+
+			// then
+			expectEvent(w, watch.Modified, "owned", "4")
+
+			// when
+			deleted := added.DeepCopy()
+			deleted.ResourceVersion = "5"
+			remove(deleted)
+
+			// then
+			expectEvent(w, watch.Deleted, "added", "5")
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+			Expect(lister.listCalls()).To(Equal(1))
+		})
+
+		It("should notify workspaces made visible by SpaceBindings", func() {
+			// given
+			private := internalWorkspace("private", otherUser, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(private)
+			w, err := cli.WatchUserWorkspaces(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+
+			// when
+			lister.bind(user, private.Status.Space.Name)
+			sbInformer.Add(&toolchainv1alpha1.SpaceBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "sb", Namespace: "toolchain-host", ResourceVersion: "2"},
+				Spec: toolchainv1alpha1.SpaceBindingSpec{
+					MasterUserRecord: user,
+					Space:            private.Status.Space.Name,
+					SpaceRole:        "viewer",
+				},
+			})
+
+			// then
+			expectEvent(w, watch.Added, "private", "1")
+		})
+	})
+
+	When("a resource version is provided", func() {
+		It("should notify only the changes happened after it", func() {
+			// given
+			add(internalWorkspace("old", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			old := internalWorkspace("updated", user, 2, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(old)
+			updated := old.DeepCopy()
+			updated.ResourceVersion = "3"
+			updated.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+			update(old, updated)
+			deleted := internalWorkspace("deleted", user, 4, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(deleted)
+			deleted = deleted.DeepCopy()
+			deleted.ResourceVersion = "5"
+			remove(deleted)
+
+			// when
+			w, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: "2"}})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			expectEvent(w, watch.Modified, "updated", "3")
+			expectEvent(w, watch.Deleted, "deleted", "5")
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+		})
+
+		It("should return ResourceExpired if it is older than the history", func() {
+			// given
+			buildClient(watchclient.Options{HistorySize: 1})
+			add(internalWorkspace("first", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			add(internalWorkspace("second", user, 2, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			add(internalWorkspace("third", user, 3, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+
+			// when
+			_, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: "1"}})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsResourceExpired(err)).To(BeTrue())
+		})
+
+		It("should return BadRequest if it is invalid", func() {
+			// when
+			_, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: "invalid"}})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+	})
+
+	When("a field selector is provided", func() {
+		It("should notify only the workspace with the requested name", func() {
+			// given
+			add(internalWorkspace("foo", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			add(internalWorkspace("bar", user, 2, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+
+			// when
+			w, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector("metadata.name", "bar"),
+			})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			expectEvent(w, watch.Added, "bar", "2")
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+		})
+
+		It("should notify only the changes to the matching workspaces", func() {
+			// given
+			foo := internalWorkspace("foo", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(foo)
+			add(internalWorkspace("bar", user, 2, workspacesv1alpha1.InternalWorkspaceVisibilityCommunity))
+			w, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector("spec.visibility", "community"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			expectEvent(w, watch.Added, "bar", "2")
+
+			// when
+			add(internalWorkspace("baz", user, 3, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			updated := foo.DeepCopy()
+			updated.ResourceVersion = "4"
+			updated.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityCommunity
+			update(foo, updated)
+
+			// then
+			expectEvent(w, watch.Added, "foo", "4")
+
+			// when
+			private := updated.DeepCopy()
+			private.ResourceVersion = "5"
+			private.Spec.Visibility = workspacesv1alpha1.InternalWorkspaceVisibilityPrivate
+			update(updated, private)
+
+			// then
+			expectEvent(w, watch.Deleted, "foo", "5")
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+		})
+
+		It("should return BadRequest if it is not supported", func() {
+			// when
+			_, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{
+				FieldSelector: fields.OneTermEqualSelector("spec.owner", "owner"),
+			})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+	})
+
+	When("a label selector is provided", func() {
+		It("should notify only the changes to the matching workspaces", func() {
+			// given
+			foo := internalWorkspace("foo", user, 1, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
+			add(foo)
+			w, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{
+				LabelSelector: labels.SelectorFromSet(labels.Set{"team": "a"}),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+
+			// when
+			labeled := foo.DeepCopy()
+			labeled.ResourceVersion = "2"
+			labeled.Labels = map[string]string{"team": "a"}
+			update(foo, labeled)
+
+			// then
+			expectEvent(w, watch.Added, "foo", "2")
+
+			// when
+			unlabeled := labeled.DeepCopy()
+			unlabeled.ResourceVersion = "3"
+			unlabeled.Labels = map[string]string{"team": "b"}
+			update(labeled, unlabeled)
+
+			// then
+			expectEvent(w, watch.Deleted, "foo", "3")
+			Consistently(w.ResultChan()).ShouldNot(Receive())
+		})
+
+		It("should return BadRequest if it uses reserved labels", func() {
+			// when
+			_, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{
+				LabelSelector: labels.SelectorFromSet(labels.Set{workspacesv1alpha1.LabelInternalDomain + "owner": "owner"}),
+			})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsBadRequest(err)).To(BeTrue())
+		})
+	})
+
+	When("bookmarks are allowed", func() {
+		It("should periodically send bookmarks", func() {
+			// given
+			buildClient(watchclient.Options{BookmarkInterval: 10 * time.Millisecond})
+			add(internalWorkspace("owned", user, 7, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+
+			// when
+			w, err := cli.WatchUserWorkspaces(ctx, user, &client.ListOptions{Raw: &metav1.ListOptions{AllowWatchBookmarks: true}})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(w.Stop)
+			expectEvent(w, watch.Added, "owned", "7")
+			expectEvent(w, watch.Bookmark, "", "7")
+		})
+	})
+
+	When("the context is cancelled", func() {
+		It("should close the result channel", func() {
+			// given
+			w, err := cli.WatchUserWorkspaces(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+
+			// when
+			cancel()
+
+			// then
+			Eventually(w.ResultChan()).Should(BeClosed())
+		})
+	})
+})
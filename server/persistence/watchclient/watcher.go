@@ -0,0 +1,132 @@
+package watchclient
+
+import (
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-workspaces/workspaces/server/persistence/readclient"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+// watcher the state of a user's watch
+type watcher struct {
+	user     string
+	listOpts *client.ListOptions
+
+	// signal is notified when a change that may concern the watcher happens
+	signal chan struct{}
+
+	mu sync.Mutex
+	// rv the resource version of the last processed change
+	rv uint64
+	// pending the resource version of the last change to process
+	pending uint64
+	// changed the spaces changed since the last processed change
+	changed map[string]struct{}
+	// deleted the InternalWorkspaces deleted since the last processed change
+	deleted map[string]*workspacesv1alpha1.InternalWorkspace
+	// spaces the spaces of the workspaces currently visible to the user
+	spaces map[string]struct{}
+}
+
+// matches returns true if the watcher is interested in the Workspace
+func (w *watcher) matches(ws *restworkspacesv1alpha1.Workspace) bool {
+	return (w.listOpts.Namespace == "" || ws.Namespace == w.listOpts.Namespace) &&
+		readclient.MatchesListOptions(w.listOpts, ws)
+}
+
+// notify records a change and signals it to the watcher's goroutine
+func (w *watcher) notify(rv uint64, space string, deleted *workspacesv1alpha1.InternalWorkspace) {
+	w.mu.Lock()
+	w.pending = max(w.pending, rv)
+	w.changed[space] = struct{}{}
+	if deleted != nil {
+		w.deleted[space] = deleted
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// drain returns the resource version of the last change to process, and
+// the spaces changed and the InternalWorkspaces deleted since the last processed change
+func (w *watcher) drain() (uint64, map[string]struct{}, map[string]*workspacesv1alpha1.InternalWorkspace) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	rv, changed, deleted := max(w.pending, w.rv), w.changed, w.deleted
+	w.changed = map[string]struct{}{}
+	w.deleted = map[string]*workspacesv1alpha1.InternalWorkspace{}
+	return rv, changed, deleted
+}
+
+// visible returns true if the workspace related to `space` is currently visible to the user
+func (w *watcher) visible(space string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, ok := w.spaces[space]
+	return ok
+}
+
+// setVisible stores the spaces of the workspaces currently visible to the user
+func (w *watcher) setVisible(current map[string]*restworkspacesv1alpha1.Workspace) {
+	ss := make(map[string]struct{}, len(current))
+	for k := range current {
+		ss[k] = struct{}{}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.spaces = ss
+}
+
+func (w *watcher) lastResourceVersion() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.rv
+}
+
+func (w *watcher) setLastResourceVersion(rv uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.rv = max(w.rv, rv)
+}
+
+// bookmark returns the object sent in BOOKMARK events
+func bookmark(rv uint64) *restworkspacesv1alpha1.Workspace {
+	return &restworkspacesv1alpha1.Workspace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Workspace",
+			APIVersion: restworkspacesv1alpha1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			ResourceVersion: strconv.FormatUint(rv, 10),
+		},
+	}
+}
+
+// statusForError returns the Status sent in ERROR events
+func statusForError(err error) *metav1.Status {
+	if s, ok := err.(kerrors.APIStatus); ok {
+		st := s.Status()
+		return &st
+	}
+	return &kerrors.NewInternalError(err).ErrStatus
+}
+
+func equalWorkspaces(a, b *restworkspacesv1alpha1.Workspace) bool {
+	return equality.Semantic.DeepEqual(a, b)
+}
@@ -1,19 +1,42 @@
 package writeclient
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/lru"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
 	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
 
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 )
 
+const (
+	// DefaultImpersonatedClientsCacheSize is the default maximum number of
+	// impersonating clients kept in memory
+	DefaultImpersonatedClientsCacheSize int = 256
+
+	// ImpersonationExtraSub is the impersonation extra containing the user's sub claim
+	ImpersonationExtraSub string = "sub"
+	// ImpersonationExtraUserSignup is the impersonation extra containing the user's UserSignup name
+	ImpersonationExtraUserSignup string = "usersignup"
+)
+
 // BuildClientFunc defines a function that builds a controller-runtime client
 // that impersonates the given user
-type BuildClientFunc func(user string) (client.Client, error)
+type BuildClientFunc func(ctx context.Context, user string) (client.Client, error)
+
+// UserSignupReader retrieves the UserSignup of a user by its compliant username
+type UserSignupReader interface {
+	GetUserSignupByComplaintName(ctx context.Context, complaintName string, userSignup *toolchainv1alpha1.UserSignup) error
+}
 
 // WriteClient implements Write primitives on Workspaces.
 // Creates or updates InternalWorkspaces starting from a request on Workspaces.
@@ -21,6 +44,7 @@ type WriteClient struct {
 	buildClient         BuildClientFunc
 	workspacesNamespace string
 	workspacesReader    *iwclient.Client
+	creations           creationsTracker
 }
 
 // New creates a new WriteClient
@@ -33,26 +57,100 @@ func New(buildClient BuildClientFunc, workspacesNamespace string, workspacesRead
 }
 
 // NewWithConfig creates a new WriteClient initialized with the given configuration
-func NewWithConfig(config *rest.Config, workspacesNamespace string, workspacesReader *iwclient.Client) *WriteClient {
-	return New(BuildBuildClientFuncForConfig(config), workspacesNamespace, workspacesReader)
+func NewWithConfig(config *rest.Config, workspacesNamespace string, workspacesReader *iwclient.Client) (*WriteClient, error) {
+	buildClient, err := BuildBuildClientFuncForConfig(config, workspacesReader, DefaultImpersonatedClientsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return New(buildClient, workspacesNamespace, workspacesReader), nil
 }
 
 // BuildBuildClientFuncForConfig provides a configured BuildClientFunc for building a controller-runtime client
-// for a given cluster and impersonating an user
-func BuildBuildClientFuncForConfig(config *rest.Config) BuildClientFunc {
-	newConfig := rest.CopyConfig(config)
+// for a given cluster and impersonating an user.
+// Built clients are cached per user in a LRU cache of size `cacheSize`.
+func BuildBuildClientFuncForConfig(config *rest.Config, userSignupReader UserSignupReader, cacheSize int) (BuildClientFunc, error) {
+	s := runtime.NewScheme()
+	if err := restworkspacesv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
+	if err := workspacesv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
 
-	return func(user string) (client.Client, error) {
-		// newConfig.Impersonate.UserName = user
+	// the RESTMapper does not depend on the user, so it is shared across clients
+	hc, err := rest.HTTPClientFor(config)
+	if err != nil {
+		return nil, err
+	}
+	m, err := apiutil.NewDynamicRESTMapper(config, hc)
+	if err != nil {
+		return nil, err
+	}
 
-		s := runtime.NewScheme()
-		if err := restworkspacesv1alpha1.AddToScheme(s); err != nil {
-			return nil, err
+	cc := &impersonatingClientsCache{clients: lru.New(cacheSize)}
+	return func(ctx context.Context, user string) (client.Client, error) {
+		u := toolchainv1alpha1.UserSignup{}
+		if err := userSignupReader.GetUserSignupByComplaintName(ctx, user, &u); err != nil {
+			return nil, fmt.Errorf("error retrieving UserSignup for user %s: %w", user, err)
+		}
+		ic := ImpersonationConfigForUserSignup(&u)
+
+		// reuse the cached client if the impersonated identity did not change
+		if c, ok := cc.get(user, ic); ok {
+			return c, nil
 		}
-		if err := workspacesv1alpha1.AddToScheme(s); err != nil {
+
+		newConfig := rest.CopyConfig(config)
+		newConfig.Impersonate = ic
+		c, err := client.New(newConfig, client.Options{Scheme: s, Mapper: m})
+		if err != nil {
 			return nil, err
 		}
+		cc.add(user, ic, c)
+		return c, nil
+	}, nil
+}
 
-		return client.New(newConfig, client.Options{Scheme: s})
+// ImpersonationConfigForUserSignup builds the configuration for impersonating the user
+// the UserSignup belongs to.
+func ImpersonationConfigForUserSignup(u *toolchainv1alpha1.UserSignup) rest.ImpersonationConfig {
+	return rest.ImpersonationConfig{
+		UserName: u.Status.CompliantUsername,
+		Groups: []string{
+			"system:authenticated",
+			toolchainv1alpha1.KubesawAuthenticatedUsername,
+		},
+		Extra: map[string][]string{
+			ImpersonationExtraSub:        {u.Spec.IdentityClaims.Sub},
+			ImpersonationExtraUserSignup: {u.Name},
+		},
 	}
 }
+
+// impersonatingClientsCache is a LRU cache of clients indexed by user.
+// lru.Cache is safe for concurrent use.
+type impersonatingClientsCache struct {
+	clients *lru.Cache
+}
+
+type impersonatingClientsCacheEntry struct {
+	config rest.ImpersonationConfig
+	client client.Client
+}
+
+func (c *impersonatingClientsCache) get(user string, config rest.ImpersonationConfig) (client.Client, bool) {
+	v, ok := c.clients.Get(user)
+	if !ok {
+		return nil, false
+	}
+	e := v.(impersonatingClientsCacheEntry)
+	if !reflect.DeepEqual(e.config, config) {
+		c.clients.Remove(user)
+		return nil, false
+	}
+	return e.client, true
+}
+
+func (c *impersonatingClientsCache) add(user string, config rest.ImpersonationConfig, cli client.Client) {
+	c.clients.Add(user, impersonatingClientsCacheEntry{config: config, client: cli})
+}
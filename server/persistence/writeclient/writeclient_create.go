@@ -2,23 +2,79 @@ package writeclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
 	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/persistence/clientinterface"
 	"github.com/konflux-workspaces/workspaces/server/persistence/mapper"
 	"github.com/konflux-workspaces/workspaces/server/persistence/mutate"
 
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 )
 
 var _ workspace.WorkspaceCreator = &WriteClient{}
 
+// maxNamePrefixLength the length of the DisplayName's prefix used in InternalWorkspaces' names,
+// leaving room for the hash suffix within the 63 characters of a DNS-1123 label
+const maxNamePrefixLength = 52
+
 // CreateUserWorkspace creates as `user` the InternalWorkspace representing the provided Workspace
 func (c *WriteClient) CreateUserWorkspace(ctx context.Context, user string, workspace *restworkspacesv1alpha1.Workspace, opts ...client.CreateOption) error {
-	cli, err := c.buildClient(user)
+	l := log.FromContext(ctx).With("workspace", workspace, "user", user)
+
+	// workspaces can be created only if the feature is enabled
+	cfg, err := c.workspacesReader.GetWorkspacesConfig(ctx)
 	if err != nil {
+		l.Error("error retrieving WorkspacesConfig", "error", err)
+		return kerrors.NewInternalError(err)
+	}
+	if !cfg.Features.IsWorkspaceCreationEnabled() {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name,
+			fmt.Errorf("workspace creation is disabled"))
+	}
+
+	cli, err := c.buildClient(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	// retrieve the owner's UserSignup
+	u := toolchainv1alpha1.UserSignup{}
+	if err := c.workspacesReader.GetUserSignupByComplaintName(ctx, user, &u); err != nil {
+		l.Error("error retrieving UserSignup for user", "error", err)
+		return kerrors.NewInternalError(fmt.Errorf("error retrieving user information"))
+	}
+
+	// display names are unique per owner: the check is repeated atomically
+	// by the API server, as InternalWorkspaces' names are derived from owner and DisplayName
+	key := clientinterface.SpaceKey{Owner: user, Name: workspace.Name}
+	exists, err := c.workspacesReader.WorkspaceExists(ctx, key)
+	if err != nil {
+		l.Error("error checking if workspace already exists", "error", err)
+		return kerrors.NewInternalError(err)
+	}
+	if exists {
+		return kerrors.NewAlreadyExists(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name)
+	}
+
+	// creations of the same user are serialized, so that they can not exceed the user's quota
+	owner := ownerJwtInfo(&u, cfg)
+	uc := c.creations.acquire(owner.IdentityKey())
+	defer c.creations.release(owner.IdentityKey(), uc)
+	if err := c.checkQuota(ctx, uc, &u, cfg, workspace.Name); err != nil {
+		l.Debug("error checking user quota", "error", err)
 		return err
 	}
 
@@ -28,14 +84,23 @@ func (c *WriteClient) CreateUserWorkspace(ctx context.Context, user string, work
 		return err
 	}
 	iw.SetNamespace(c.workspacesNamespace)
-	iw.SetName("")
-	iw.SetGenerateName(workspace.Name)
+	iw.SetName(internalWorkspaceName(owner, workspace.Name))
+	iw.SetGenerateName("")
+	iw.SetResourceVersion("")
+	iw.SetUID("")
+	iw.Spec.Owner = workspacesv1alpha1.UserInfo{JwtInfo: owner}
 
 	// create InternalWorkspace
-	log.FromContext(ctx).Debug("creating user workspace", "workspace", workspace, "user", user)
+	l.Debug("creating user workspace")
 	if err := cli.Create(ctx, iw, opts...); err != nil {
+		if isDuplicate(err) {
+			return kerrors.NewAlreadyExists(
+				restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+				workspace.Name)
+		}
 		return err
 	}
+	uc.track(iw.Name)
 
 	// map InternalWorkspace to Workspace
 	w, err := mapper.Default.InternalWorkspaceToWorkspace(iw)
@@ -43,6 +108,9 @@ func (c *WriteClient) CreateUserWorkspace(ctx context.Context, user string, work
 		return err
 	}
 
+	// status is not persisted on creation, so the owner is set explicitly
+	w.SetNamespace(user)
+
 	// apply the is-owner label
 	mutate.ApplyIsOwnerLabel(w, user)
 	// if a user is creating a workspace, then they must have direct access to it
@@ -52,3 +120,38 @@ func (c *WriteClient) CreateUserWorkspace(ctx context.Context, user string, work
 	w.DeepCopyInto(workspace)
 	return nil
 }
+
+// ownerJwtInfo builds the JwtInfo identifying the user the UserSignup belongs to as owner.
+// UserSignups lacking the issuer are assumed to belong to the default issuer.
+func ownerJwtInfo(u *toolchainv1alpha1.UserSignup, cfg *workspacesv1alpha1.WorkspacesConfigSpec) workspacesv1alpha1.JwtInfo {
+	return workspacesv1alpha1.JwtInfo{
+		Email:  u.Spec.IdentityClaims.Email,
+		UserId: u.Spec.IdentityClaims.UserID,
+		Sub:    u.Spec.IdentityClaims.Sub,
+		Issuer: cfg.Identity.IssuerOrDefault(u.GetAnnotations()[workspacesv1alpha1.AnnotationUserSignupIssuer]),
+	}
+}
+
+// internalWorkspaceName builds the name of the InternalWorkspace with the given DisplayName owned by `owner`.
+// Names are deterministic, so that concurrent creations of the same workspace conflict on the API server.
+func internalWorkspaceName(owner workspacesv1alpha1.JwtInfo, displayName string) string {
+	h := sha256.Sum256([]byte(owner.IdentityKey() + "/" + displayName))
+	p := displayName
+	if len(p) > maxNamePrefixLength {
+		p = p[:maxNamePrefixLength]
+	}
+	return fmt.Sprintf("%s-%x", p, h[:5])
+}
+
+// isDuplicate returns true if the InternalWorkspace was not created because its owner already has one
+// with the same DisplayName, as reported by the API server or by the validating webhook
+func isDuplicate(err error) bool {
+	if kerrors.IsAlreadyExists(err) {
+		return true
+	}
+	if !kerrors.IsInvalid(err) {
+		return false
+	}
+	c, ok := kerrors.StatusCause(err, metav1.CauseTypeFieldValueDuplicate)
+	return ok && c.Field == "spec.displayName"
+}
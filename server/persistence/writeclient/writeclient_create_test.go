@@ -2,18 +2,28 @@ package writeclient_test
 
 import (
 	"context"
+	"fmt"
 	"slices"
+	"sync"
+	"sync/atomic"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	"github.com/konflux-workspaces/workspaces/server/persistence/internal/cache"
 	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
 	"github.com/konflux-workspaces/workspaces/server/persistence/writeclient"
 
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 )
@@ -23,8 +33,27 @@ var _ = Describe("WriteclientCreate", func() {
 	var fakeClient client.WithWatch
 	var cli *writeclient.WriteClient
 
-	user := "foo"
+	user := "owner"
 	namespace := "bar"
+	kubesawNamespace := "toolchain-host"
+	userSignup := toolchainv1alpha1.UserSignup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      user,
+			Namespace: kubesawNamespace,
+		},
+		Spec: toolchainv1alpha1.UserSignupSpec{
+			IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+				PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
+					Sub:    "owner-sub",
+					Email:  "owner@email.com",
+					UserID: "owner-user-id",
+				},
+			},
+		},
+		Status: toolchainv1alpha1.UserSignupStatus{
+			CompliantUsername: user,
+		},
+	}
 	workspace := restworkspacesv1alpha1.Workspace{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: "owner",
@@ -51,24 +80,39 @@ var _ = Describe("WriteclientCreate", func() {
 		Expect(ww.Items).To(Satisfy(func(ww []workspacesv1alpha1.InternalWorkspace) bool {
 			return slices.ContainsFunc(ww, func(lw workspacesv1alpha1.InternalWorkspace) bool {
 				return lw.Spec.DisplayName == w.Name &&
-					lw.Status.Owner.Username == "owner" &&
+					lw.Spec.Owner.JwtInfo.Sub == userSignup.Spec.IdentityClaims.Sub &&
+					lw.Spec.Owner.JwtInfo.Email == userSignup.Spec.IdentityClaims.Email &&
+					lw.Spec.Owner.JwtInfo.UserId == userSignup.Spec.IdentityClaims.UserID &&
 					lw.Spec.Visibility == expectedVisibility
 			})
 		}))
 	}
 
-	BeforeEach(func() {
-		ctx = context.Background()
-		fakeClient = fake.NewFakeClient()
-		err := workspacesv1alpha1.AddToScheme(fakeClient.Scheme())
-		Expect(err).NotTo(HaveOccurred())
+	initializeCli := func(objs ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(toolchainv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(workspacesv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fcb := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+		for key, indexer := range cache.UserSignupIndexers {
+			fcb.WithIndex(&toolchainv1alpha1.UserSignup{}, key, indexer)
+		}
+		for key, indexer := range cache.InternalWorkspacesIndexers {
+			fcb.WithIndex(&workspacesv1alpha1.InternalWorkspace{}, key, indexer)
+		}
+		fakeClient = fcb.Build()
 
-		clientFunc := func(string) (client.Client, error) {
+		clientFunc := func(context.Context, string) (client.Client, error) {
 			return fakeClient, nil
 		}
 
-		iwcli := iwclient.New(fakeClient, namespace, namespace)
+		iwcli := iwclient.New(fakeClient, namespace, kubesawNamespace)
 		cli = writeclient.New(clientFunc, namespace, iwcli)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		initializeCli(&userSignup)
 	})
 
 	When("creating a community workspace", func() {
@@ -105,14 +149,322 @@ var _ = Describe("WriteclientCreate", func() {
 			workspace.Spec.Visibility = restworkspacesv1alpha1.WorkspaceVisibilityPrivate
 
 			// when
-			err := cli.CreateUserWorkspace(ctx, "owner", &workspace)
+			err := cli.CreateUserWorkspace(ctx, user, &workspace)
 
 			// then
 			Expect(err).NotTo(HaveOccurred())
+			Expect(workspace.Namespace).To(Equal(user))
 			Expect(workspace.Labels).To(And(
 				HaveKeyWithValue(restworkspacesv1alpha1.LabelIsOwner, "true"),
 				HaveKeyWithValue(restworkspacesv1alpha1.LabelHasDirectAccess, "true")))
 			validateCreatedInternalWorkspace(&workspace, workspacesv1alpha1.InternalWorkspaceVisibilityPrivate)
 		})
 	})
+
+	When("the user already owns a workspace with the same name", func() {
+		BeforeEach(func() {
+			initializeCli(&userSignup, &workspacesv1alpha1.InternalWorkspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      workspace.Name + "-abcde",
+					Namespace: namespace,
+				},
+				Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+					DisplayName: workspace.Name,
+					Visibility:  workspacesv1alpha1.InternalWorkspaceVisibilityPrivate,
+				},
+				Status: workspacesv1alpha1.InternalWorkspaceStatus{
+					Owner: workspacesv1alpha1.UserInfoStatus{
+						Username: user,
+					},
+				},
+			})
+		})
+
+		It("should return an AlreadyExists error", func() {
+			// given
+			w := workspace.DeepCopy()
+			w.Spec.Visibility = restworkspacesv1alpha1.WorkspaceVisibilityPrivate
+
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, w)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsAlreadyExists(err)).To(BeTrue())
+		})
+	})
+
+	When("the same workspace is created twice before the cache observes it", func() {
+		BeforeEach(func() {
+			// the reader does not observe the created workspaces
+			initializeCli(&userSignup)
+			clientFunc := func(context.Context, string) (client.Client, error) {
+				return fakeClient, nil
+			}
+			reader := fake.NewClientBuilder().WithScheme(fakeClient.Scheme()).WithObjects(&userSignup)
+			for key, indexer := range cache.UserSignupIndexers {
+				reader.WithIndex(&toolchainv1alpha1.UserSignup{}, key, indexer)
+			}
+			for key, indexer := range cache.InternalWorkspacesIndexers {
+				reader.WithIndex(&workspacesv1alpha1.InternalWorkspace{}, key, indexer)
+			}
+			cli = writeclient.New(clientFunc, namespace, iwclient.New(reader.Build(), namespace, kubesawNamespace))
+		})
+
+		It("should return an AlreadyExists error on the second creation", func() {
+			// given
+			first, second := workspace.DeepCopy(), workspace.DeepCopy()
+
+			// when
+			err1 := cli.CreateUserWorkspace(ctx, user, first)
+			err2 := cli.CreateUserWorkspace(ctx, user, second)
+
+			// then
+			Expect(err1).NotTo(HaveOccurred())
+			Expect(err2).To(HaveOccurred())
+			Expect(kerrors.IsAlreadyExists(err2)).To(BeTrue())
+
+			ww := workspacesv1alpha1.InternalWorkspaceList{}
+			Expect(fakeClient.List(ctx, &ww, client.InNamespace(namespace))).To(Succeed())
+			Expect(ww.Items).To(HaveLen(1))
+		})
+
+		It("should create workspaces with different names", func() {
+			// given
+			first, second := workspace.DeepCopy(), workspace.DeepCopy()
+			second.Name = "workspace-bar"
+
+			// when
+			err1 := cli.CreateUserWorkspace(ctx, user, first)
+			err2 := cli.CreateUserWorkspace(ctx, user, second)
+
+			// then
+			Expect(err1).NotTo(HaveOccurred())
+			Expect(err2).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the webhook rejects the workspace as duplicate", func() {
+		BeforeEach(func() {
+			initializeCli(&userSignup)
+			clientFunc := func(context.Context, string) (client.Client, error) {
+				return interceptor.NewClient(fakeClient, interceptor.Funcs{
+					Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+						return kerrors.NewInvalid(
+							workspacesv1alpha1.GroupVersion.WithKind("InternalWorkspace").GroupKind(),
+							obj.GetName(),
+							field.ErrorList{field.Duplicate(field.NewPath("spec", "displayName"), workspace.Name)})
+					},
+				}), nil
+			}
+			cli = writeclient.New(clientFunc, namespace, iwclient.New(fakeClient, namespace, kubesawNamespace))
+		})
+
+		It("should return an AlreadyExists error", func() {
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, workspace.DeepCopy())
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsAlreadyExists(err)).To(BeTrue())
+		})
+	})
+
+	When("a default issuer is configured", func() {
+		BeforeEach(func() {
+			c := workspacesv1alpha1.WorkspacesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+				Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+					Identity: workspacesv1alpha1.WorkspacesConfigIdentity{DefaultIssuer: "https://default-issuer"},
+				},
+			}
+			u := userSignup.DeepCopy()
+			u.Annotations = map[string]string{workspacesv1alpha1.AnnotationUserSignupIssuer: "https://other-issuer"}
+			initializeCli(u, &c)
+		})
+
+		It("should set the owner's issuer from the UserSignup", func() {
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, &workspace)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+
+			ww := workspacesv1alpha1.InternalWorkspaceList{}
+			Expect(fakeClient.List(ctx, &ww, client.InNamespace(namespace))).To(Succeed())
+			Expect(ww.Items).To(HaveLen(1))
+			Expect(ww.Items[0].Spec.Owner.JwtInfo.Issuer).To(Equal("https://other-issuer"))
+		})
+	})
+
+	When("the workspace creation is disabled", func() {
+		BeforeEach(func() {
+			c := workspacesv1alpha1.WorkspacesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+				Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+					Features: workspacesv1alpha1.WorkspacesConfigFeatures{WorkspaceCreation: ptr.To(false)},
+				},
+			}
+			initializeCli(&userSignup, &c)
+		})
+
+		It("should return a Forbidden error", func() {
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, &workspace)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+
+			ww := workspacesv1alpha1.InternalWorkspaceList{}
+			Expect(fakeClient.List(ctx, &ww, client.InNamespace(namespace))).To(Succeed())
+			Expect(ww.Items).To(BeEmpty())
+		})
+	})
+
+	When("the user has a quota", func() {
+		home := workspacesv1alpha1.InternalWorkspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "home",
+				Namespace: namespace,
+			},
+			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+				DisplayName: workspacesv1alpha1.DisplayNameDefaultWorkspace,
+				Owner: workspacesv1alpha1.UserInfo{
+					JwtInfo: workspacesv1alpha1.JwtInfo{Sub: userSignup.Spec.IdentityClaims.Sub},
+				},
+			},
+			Status: workspacesv1alpha1.InternalWorkspaceStatus{
+				Space: workspacesv1alpha1.SpaceInfo{IsHome: true, Name: "home", Tier: "base"},
+			},
+		}
+		withQuotas := func(q workspacesv1alpha1.WorkspacesConfigQuotas) *workspacesv1alpha1.WorkspacesConfig {
+			return &workspacesv1alpha1.WorkspacesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+				Spec:       workspacesv1alpha1.WorkspacesConfigSpec{Quotas: q},
+			}
+		}
+
+		It("should return a Forbidden error with the usage when the quota is exceeded", func() {
+			// given
+			initializeCli(&userSignup, home.DeepCopy(), withQuotas(workspacesv1alpha1.WorkspacesConfigQuotas{
+				MaxWorkspacesPerUser: ptr.To[int32](1),
+			}))
+
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, workspace.DeepCopy())
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("the user owns 1 workspaces out of the 1 allowed"))
+		})
+
+		It("should apply the override matching the user's tier", func() {
+			// given
+			initializeCli(&userSignup, home.DeepCopy(), withQuotas(workspacesv1alpha1.WorkspacesConfigQuotas{
+				MaxWorkspacesPerUser: ptr.To[int32](1),
+				Overrides: []workspacesv1alpha1.WorkspacesConfigQuotaOverride{
+					{Tier: "base", MaxWorkspacesPerUser: ptr.To[int32](2)},
+				},
+			}))
+
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, workspace.DeepCopy())
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should apply the override matching the user's UserSignup labels", func() {
+			// given
+			u := userSignup.DeepCopy()
+			u.Labels = map[string]string{"plan": "premium"}
+			initializeCli(u, home.DeepCopy(), withQuotas(workspacesv1alpha1.WorkspacesConfigQuotas{
+				MaxWorkspacesPerUser: ptr.To[int32](1),
+				Overrides: []workspacesv1alpha1.WorkspacesConfigQuotaOverride{
+					{
+						UserSignupSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"plan": "premium"}},
+						MaxWorkspacesPerUser: nil,
+					},
+				},
+			}))
+
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, workspace.DeepCopy())
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should count the created workspaces not yet in the cache", func() {
+			// given a cache that does not observe the created workspaces
+			initializeCli(&userSignup, home.DeepCopy(), withQuotas(workspacesv1alpha1.WorkspacesConfigQuotas{
+				MaxWorkspacesPerUser: ptr.To[int32](2),
+			}))
+			clientFunc := func(context.Context, string) (client.Client, error) {
+				return fake.NewClientBuilder().WithScheme(fakeClient.Scheme()).Build(), nil
+			}
+			cli = writeclient.New(clientFunc, namespace, iwclient.New(fakeClient, namespace, kubesawNamespace))
+			first, second := workspace.DeepCopy(), workspace.DeepCopy()
+			second.Name = "workspace-bar"
+
+			// when
+			err1 := cli.CreateUserWorkspace(ctx, user, first)
+			err2 := cli.CreateUserWorkspace(ctx, user, second)
+
+			// then
+			Expect(err1).NotTo(HaveOccurred())
+			Expect(err2).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err2)).To(BeTrue())
+			Expect(err2.Error()).To(ContainSubstring("the user owns 2 workspaces out of the 2 allowed"))
+		})
+
+		It("should not exceed the quota with concurrent requests", func() {
+			// given
+			initializeCli(&userSignup, home.DeepCopy(), withQuotas(workspacesv1alpha1.WorkspacesConfigQuotas{
+				MaxWorkspacesPerUser: ptr.To[int32](3),
+			}))
+
+			// when
+			var wg sync.WaitGroup
+			var created atomic.Int32
+			for i := range 10 {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					w := workspace.DeepCopy()
+					w.Name = fmt.Sprintf("workspace-%d", i)
+					if err := cli.CreateUserWorkspace(ctx, user, w); err == nil {
+						created.Add(1)
+					} else {
+						Expect(kerrors.IsForbidden(err)).To(BeTrue())
+					}
+				}()
+			}
+			wg.Wait()
+
+			// then
+			Expect(created.Load()).To(BeEquivalentTo(2))
+		})
+	})
+
+	When("the user's UserSignup does not exist", func() {
+		BeforeEach(func() { initializeCli() })
+
+		It("should fail", func() {
+			// given
+			w := workspace.DeepCopy()
+			w.Spec.Visibility = restworkspacesv1alpha1.WorkspaceVisibilityPrivate
+
+			// when
+			err := cli.CreateUserWorkspace(ctx, user, w)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsInternalError(err)).To(BeTrue())
+		})
+	})
 })
@@ -0,0 +1,68 @@
+package writeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/persistence/clientinterface"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ workspace.WorkspaceDeleter = &WriteClient{}
+
+// DeleteUserWorkspace deletes as `user` the InternalWorkspace representing the provided Workspace.
+// Only the owner is allowed to delete a workspace, and the home workspace can not be deleted.
+func (c *WriteClient) DeleteUserWorkspace(ctx context.Context, user string, workspace *restworkspacesv1alpha1.Workspace, opts ...client.DeleteOption) error {
+	l := log.FromContext(ctx).With("workspace", workspace, "user", user)
+
+	// build client impersonating the user
+	cli, err := c.buildClient(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	// get the InternalWorkspace as user
+	iw := workspacesv1alpha1.InternalWorkspace{}
+	key := clientinterface.SpaceKey{Owner: workspace.Namespace, Name: workspace.Name}
+	if err := c.workspacesReader.GetAsUser(ctx, user, key, &iw); err != nil {
+		if !errors.Is(err, iwclient.ErrWorkspaceNotFound) && !errors.Is(err, iwclient.ErrUnauthorized) {
+			l.Error("error retrieving workspace", "error", err)
+			return kerrors.NewInternalError(err)
+		}
+		return kerrors.NewNotFound(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name)
+	}
+
+	// only the owner can delete the workspace
+	if iw.Status.Owner.Username != user {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name,
+			fmt.Errorf("to delete a workspace you need to be the owner"))
+	}
+
+	// the home workspace lives as long as its owner
+	if iw.Status.Space.IsHome || iw.Spec.DisplayName == workspacesv1alpha1.DisplayNameDefaultWorkspace {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name,
+			fmt.Errorf("the home workspace can not be deleted"))
+	}
+
+	// delete the InternalWorkspace
+	l.Debug("deleting user workspace", "internalworkspace", iw.Name)
+	if err := cli.Delete(ctx, &iw, opts...); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
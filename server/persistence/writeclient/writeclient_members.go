@@ -0,0 +1,180 @@
+package writeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/persistence/clientinterface"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var (
+	_ workspace.WorkspaceMembersLister = &WriteClient{}
+	_ workspace.WorkspaceMemberSetter  = &WriteClient{}
+	_ workspace.WorkspaceMemberRevoker = &WriteClient{}
+)
+
+// ListUserWorkspaceMembers lists as `user` the members of the workspace `owner/space`.
+// Only the owner and the workspace's admins are allowed to list its members.
+func (c *WriteClient) ListUserWorkspaceMembers(ctx context.Context, user, owner, space string, members *restworkspacesv1alpha1.WorkspaceMemberList) error {
+	iw, err := c.getManagedInternalWorkspace(ctx, user, owner, space)
+	if err != nil {
+		return err
+	}
+
+	members.Items = make([]restworkspacesv1alpha1.WorkspaceMember, 0, len(iw.Spec.Members))
+	for _, m := range iw.Spec.Members {
+		members.Items = append(members.Items, restworkspacesv1alpha1.WorkspaceMember{
+			Username: m.Username,
+			Role:     restworkspacesv1alpha1.WorkspaceRole(m.Role),
+		})
+	}
+	return nil
+}
+
+// SetUserWorkspaceMember grants as `user` the provided member access to the workspace `owner/space`.
+// If the member already has access to the workspace, its role is updated.
+// Only the owner and the workspace's admins are allowed to manage its members.
+func (c *WriteClient) SetUserWorkspaceMember(ctx context.Context, user, owner, space string, member *restworkspacesv1alpha1.WorkspaceMember) error {
+	l := log.FromContext(ctx).With("owner", owner, "workspace", space, "user", user, "member", member)
+
+	// build client impersonating the user
+	cli, err := c.buildClient(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	iw, err := c.getManagedInternalWorkspace(ctx, user, owner, space)
+	if err != nil {
+		return err
+	}
+
+	// the owner's access can not be changed
+	if member.Username == iw.Status.Owner.Username {
+		return kerrors.NewInvalid(
+			restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceMember").GroupKind(),
+			space,
+			field.ErrorList{field.Forbidden(field.NewPath("username"), "the owner's access can not be changed")})
+	}
+
+	// resolve the grantee
+	u := toolchainv1alpha1.UserSignup{}
+	if err := c.workspacesReader.GetUserSignupByComplaintName(ctx, member.Username, &u); err != nil {
+		if !errors.Is(err, iwclient.ErrUserSignupNotFound) {
+			l.Error("error retrieving UserSignup for member", "error", err)
+			return kerrors.NewInternalError(fmt.Errorf("error retrieving user information"))
+		}
+		return kerrors.NewInvalid(
+			restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceMember").GroupKind(),
+			space,
+			field.ErrorList{field.NotFound(field.NewPath("username"), member.Username)})
+	}
+
+	// add or update the member
+	m := workspacesv1alpha1.InternalWorkspaceMember{
+		Username: u.Status.CompliantUsername,
+		Role:     workspacesv1alpha1.InternalWorkspaceRole(member.Role),
+	}
+	i := slices.IndexFunc(iw.Spec.Members, func(e workspacesv1alpha1.InternalWorkspaceMember) bool {
+		return e.Username == m.Username
+	})
+	switch {
+	case i < 0:
+		iw.Spec.Members = append(iw.Spec.Members, m)
+	case iw.Spec.Members[i] == m:
+		return nil
+	default:
+		iw.Spec.Members[i] = m
+	}
+	slices.SortFunc(iw.Spec.Members, func(a, b workspacesv1alpha1.InternalWorkspaceMember) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	l.Debug("updating workspace members", "internalworkspace", iw.Name)
+	if err := cli.Update(ctx, iw); err != nil {
+		return err
+	}
+
+	member.Username, member.Role = m.Username, restworkspacesv1alpha1.WorkspaceRole(m.Role)
+	return nil
+}
+
+// RevokeUserWorkspaceMember revokes as `user` the access `username` has to the workspace `owner/space`.
+// Only the owner and the workspace's admins are allowed to manage its members.
+func (c *WriteClient) RevokeUserWorkspaceMember(ctx context.Context, user, owner, space, username string) error {
+	l := log.FromContext(ctx).With("owner", owner, "workspace", space, "user", user, "member", username)
+
+	// build client impersonating the user
+	cli, err := c.buildClient(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	iw, err := c.getManagedInternalWorkspace(ctx, user, owner, space)
+	if err != nil {
+		return err
+	}
+
+	mm := slices.DeleteFunc(slices.Clone(iw.Spec.Members), func(m workspacesv1alpha1.InternalWorkspaceMember) bool {
+		return m.Username == username
+	})
+	if len(mm) == len(iw.Spec.Members) {
+		return kerrors.NewNotFound(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspacemembers").GroupResource(),
+			username)
+	}
+	iw.Spec.Members = mm
+
+	l.Debug("updating workspace members", "internalworkspace", iw.Name)
+	return cli.Update(ctx, iw)
+}
+
+// getManagedInternalWorkspace retrieves the InternalWorkspace representing the workspace `owner/space`
+// if `user` is allowed to manage its members, i.e. if `user` is the owner or an admin of the workspace.
+func (c *WriteClient) getManagedInternalWorkspace(ctx context.Context, user, owner, space string) (*workspacesv1alpha1.InternalWorkspace, error) {
+	l := log.FromContext(ctx).With("owner", owner, "workspace", space, "user", user)
+
+	// get the InternalWorkspace as user
+	iw := workspacesv1alpha1.InternalWorkspace{}
+	key := clientinterface.SpaceKey{Owner: owner, Name: space}
+	if err := c.workspacesReader.GetAsUser(ctx, user, key, &iw); err != nil {
+		if !errors.Is(err, iwclient.ErrWorkspaceNotFound) && !errors.Is(err, iwclient.ErrUnauthorized) {
+			l.Error("error retrieving workspace", "error", err)
+			return nil, kerrors.NewInternalError(err)
+		}
+		return nil, kerrors.NewNotFound(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space)
+	}
+
+	// the owner can always manage the workspace's members
+	if iw.Status.Owner.Username == user {
+		return &iw, nil
+	}
+
+	// admins can manage the workspace's members
+	ok, err := c.workspacesReader.UserHasRole(ctx, user, iw.Name, string(workspacesv1alpha1.InternalWorkspaceRoleAdmin))
+	if err != nil {
+		l.Error("error checking user's role on workspace", "error", err)
+		return nil, kerrors.NewInternalError(err)
+	}
+	if !ok {
+		return nil, kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space,
+			fmt.Errorf("to manage a workspace's members you need to be the owner or an admin"))
+	}
+	return &iw, nil
+}
@@ -0,0 +1,284 @@
+package writeclient_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-workspaces/workspaces/server/persistence/internal/cache"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/writeclient"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("WriteclientMembers", func() {
+	var ctx context.Context
+	var fakeClient client.WithWatch
+	var cli *writeclient.WriteClient
+	var internalWorkspace workspacesv1alpha1.InternalWorkspace
+
+	workspacesNamespace := "workspaces-system"
+	kubesawNamespace := "toolchain-host"
+
+	owner := "owner"
+	admin := "admin-user"
+	viewer := "viewer-user"
+	grantee := "grantee"
+	space := "workspace-foo"
+
+	buildUserSignup := func(name string) *toolchainv1alpha1.UserSignup {
+		return &toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: kubesawNamespace,
+			},
+			Status: toolchainv1alpha1.UserSignupStatus{
+				CompliantUsername: name,
+			},
+		}
+	}
+
+	buildSpaceBinding := func(user, space, role string) *toolchainv1alpha1.SpaceBinding {
+		return &toolchainv1alpha1.SpaceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      space + "-" + user,
+				Namespace: kubesawNamespace,
+				Labels: map[string]string{
+					toolchainv1alpha1.SpaceBindingMasterUserRecordLabelKey: user,
+					toolchainv1alpha1.SpaceBindingSpaceLabelKey:            space,
+				},
+			},
+			Spec: toolchainv1alpha1.SpaceBindingSpec{
+				MasterUserRecord: user,
+				Space:            space,
+				SpaceRole:        role,
+			},
+		}
+	}
+
+	initializeCli := func(objs ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(toolchainv1alpha1.AddToScheme(scheme)).ToNot(HaveOccurred())
+		Expect(workspacesv1alpha1.AddToScheme(scheme)).ToNot(HaveOccurred())
+
+		fcb := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+		for key, indexer := range cache.UserSignupIndexers {
+			fcb.WithIndex(&toolchainv1alpha1.UserSignup{}, key, indexer)
+		}
+		for key, indexer := range cache.InternalWorkspacesIndexers {
+			fcb.WithIndex(&workspacesv1alpha1.InternalWorkspace{}, key, indexer)
+		}
+		fakeClient = fcb.Build()
+
+		clientFunc := func(context.Context, string) (client.Client, error) {
+			return fakeClient, nil
+		}
+		iwcli := iwclient.New(fakeClient, workspacesNamespace, kubesawNamespace)
+		cli = writeclient.New(clientFunc, workspacesNamespace, iwcli)
+	}
+
+	getInternalWorkspaceMembers := func() []workspacesv1alpha1.InternalWorkspaceMember {
+		iw := workspacesv1alpha1.InternalWorkspace{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &iw)).To(Succeed())
+		return iw.Spec.Members
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		internalWorkspace = workspacesv1alpha1.InternalWorkspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      space + "-fddjk",
+				Namespace: workspacesNamespace,
+			},
+			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+				Visibility:  workspacesv1alpha1.InternalWorkspaceVisibilityPrivate,
+				DisplayName: space,
+				Members: []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: admin, Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+					{Username: viewer, Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				},
+			},
+			Status: workspacesv1alpha1.InternalWorkspaceStatus{
+				Space: workspacesv1alpha1.SpaceInfo{
+					Name: space + "-fddjk",
+				},
+				Owner: workspacesv1alpha1.UserInfoStatus{
+					Username: owner,
+				},
+			},
+		}
+		initializeCli(
+			&internalWorkspace,
+			buildUserSignup(owner),
+			buildUserSignup(admin),
+			buildUserSignup(viewer),
+			buildUserSignup(grantee),
+			buildSpaceBinding(owner, internalWorkspace.Name, "admin"),
+			buildSpaceBinding(admin, internalWorkspace.Name, "admin"),
+			buildSpaceBinding(viewer, internalWorkspace.Name, "viewer"),
+		)
+	})
+
+	Describe("listing members", func() {
+		DescribeTable("owner and admins can list members", func(user string) {
+			// given
+			mm := restworkspacesv1alpha1.WorkspaceMemberList{}
+
+			// when
+			err := cli.ListUserWorkspaceMembers(ctx, user, owner, space, &mm)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mm.Items).To(ConsistOf(
+				restworkspacesv1alpha1.WorkspaceMember{Username: admin, Role: restworkspacesv1alpha1.WorkspaceRoleAdmin},
+				restworkspacesv1alpha1.WorkspaceMember{Username: viewer, Role: restworkspacesv1alpha1.WorkspaceRoleViewer},
+			))
+		},
+			Entry("owner", owner),
+			Entry("admin", admin),
+		)
+
+		It("forbids non-admin members", func() {
+			// given
+			mm := restworkspacesv1alpha1.WorkspaceMemberList{}
+
+			// when
+			err := cli.ListUserWorkspaceMembers(ctx, viewer, owner, space, &mm)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("returns NotFound to users with no access", func() {
+			// given
+			mm := restworkspacesv1alpha1.WorkspaceMemberList{}
+
+			// when
+			err := cli.ListUserWorkspaceMembers(ctx, grantee, owner, space, &mm)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("setting a member", func() {
+		DescribeTable("owner and admins can grant access", func(user string) {
+			// given
+			m := restworkspacesv1alpha1.WorkspaceMember{Username: grantee, Role: restworkspacesv1alpha1.WorkspaceRoleContributor}
+
+			// when
+			err := cli.SetUserWorkspaceMember(ctx, user, owner, space, &m)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getInternalWorkspaceMembers()).To(ContainElement(workspacesv1alpha1.InternalWorkspaceMember{
+				Username: grantee,
+				Role:     workspacesv1alpha1.InternalWorkspaceRoleContributor,
+			}))
+		},
+			Entry("owner", owner),
+			Entry("admin", admin),
+		)
+
+		It("updates the role of an existing member", func() {
+			// given
+			m := restworkspacesv1alpha1.WorkspaceMember{Username: viewer, Role: restworkspacesv1alpha1.WorkspaceRoleMaintainer}
+
+			// when
+			err := cli.SetUserWorkspaceMember(ctx, owner, owner, space, &m)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getInternalWorkspaceMembers()).To(ConsistOf(
+				workspacesv1alpha1.InternalWorkspaceMember{Username: admin, Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+				workspacesv1alpha1.InternalWorkspaceMember{Username: viewer, Role: workspacesv1alpha1.InternalWorkspaceRoleMaintainer},
+			))
+		})
+
+		It("forbids non-admin members", func() {
+			// given
+			m := restworkspacesv1alpha1.WorkspaceMember{Username: grantee, Role: restworkspacesv1alpha1.WorkspaceRoleAdmin}
+
+			// when
+			err := cli.SetUserWorkspaceMember(ctx, viewer, owner, space, &m)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			Expect(getInternalWorkspaceMembers()).To(HaveLen(2))
+		})
+
+		It("rejects unknown users", func() {
+			// given
+			m := restworkspacesv1alpha1.WorkspaceMember{Username: "unknown", Role: restworkspacesv1alpha1.WorkspaceRoleViewer}
+
+			// when
+			err := cli.SetUserWorkspaceMember(ctx, owner, owner, space, &m)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+			Expect(getInternalWorkspaceMembers()).To(HaveLen(2))
+		})
+
+		It("rejects changes to the owner's access", func() {
+			// given
+			m := restworkspacesv1alpha1.WorkspaceMember{Username: owner, Role: restworkspacesv1alpha1.WorkspaceRoleViewer}
+
+			// when
+			err := cli.SetUserWorkspaceMember(ctx, admin, owner, space, &m)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+			Expect(getInternalWorkspaceMembers()).To(HaveLen(2))
+		})
+	})
+
+	Describe("revoking a member", func() {
+		DescribeTable("owner and admins can revoke access", func(user string) {
+			// when
+			err := cli.RevokeUserWorkspaceMember(ctx, user, owner, space, viewer)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getInternalWorkspaceMembers()).To(ConsistOf(
+				workspacesv1alpha1.InternalWorkspaceMember{Username: admin, Role: workspacesv1alpha1.InternalWorkspaceRoleAdmin},
+			))
+		},
+			Entry("owner", owner),
+			Entry("admin", admin),
+		)
+
+		It("forbids non-admin members", func() {
+			// when
+			err := cli.RevokeUserWorkspaceMember(ctx, viewer, owner, space, admin)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			Expect(getInternalWorkspaceMembers()).To(HaveLen(2))
+		})
+
+		It("returns NotFound for users that are not members", func() {
+			// when
+			err := cli.RevokeUserWorkspaceMember(ctx, owner, owner, space, grantee)
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package writeclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+// pendingCreationTTL is how long an InternalWorkspace created by the server
+// is counted in its owner's usage while it is not yet in the cache
+const pendingCreationTTL = time.Minute
+
+// creationsTracker serializes the creation of the InternalWorkspaces of each user,
+// so that concurrent requests can not exceed the user's quota.
+// As the cache is updated asynchronously, the InternalWorkspaces created
+// but not yet in the cache are tracked as pending and counted too.
+type creationsTracker struct {
+	mu    sync.Mutex
+	users map[string]*userCreations
+}
+
+// userCreations holds the pending creations of a user
+type userCreations struct {
+	mu      sync.Mutex
+	refs    int
+	pending map[string]time.Time
+}
+
+// acquire locks the creations of the user identified by `sub`
+func (t *creationsTracker) acquire(sub string) *userCreations {
+	t.mu.Lock()
+	if t.users == nil {
+		t.users = map[string]*userCreations{}
+	}
+	uc, ok := t.users[sub]
+	if !ok {
+		uc = &userCreations{pending: map[string]time.Time{}}
+		t.users[sub] = uc
+	}
+	uc.refs++
+	t.mu.Unlock()
+
+	uc.mu.Lock()
+	return uc
+}
+
+// release unlocks the creations of the user identified by `sub`,
+// forgetting the user if no creation is pending or in progress
+func (t *creationsTracker) release(sub string, uc *userCreations) {
+	uc.mu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	uc.refs--
+	if uc.refs == 0 && len(uc.pending) == 0 {
+		delete(t.users, sub)
+	}
+}
+
+// usage returns the number of InternalWorkspaces owned by the user, counting
+// the cached ones and the pending ones. Pending creations found in the cache
+// or older than pendingCreationTTL are forgotten.
+func (uc *userCreations) usage(cached []workspacesv1alpha1.InternalWorkspace) int {
+	for _, w := range cached {
+		delete(uc.pending, w.Name)
+	}
+	for n, t := range uc.pending {
+		if time.Since(t) > pendingCreationTTL {
+			delete(uc.pending, n)
+		}
+	}
+	return len(cached) + len(uc.pending)
+}
+
+// track records the creation of the InternalWorkspace named `name`
+func (uc *userCreations) track(name string) {
+	uc.pending[name] = time.Now()
+}
+
+// checkQuota ensures the user can own one more InternalWorkspace.
+// It must be called while holding the user's creations.
+func (c *WriteClient) checkQuota(
+	ctx context.Context,
+	uc *userCreations,
+	u *toolchainv1alpha1.UserSignup,
+	cfg *workspacesv1alpha1.WorkspacesConfigSpec,
+	name string,
+) error {
+	ww := workspacesv1alpha1.InternalWorkspaceList{}
+	if err := c.workspacesReader.ListOwnedByUserSignup(ctx, u, &ww); err != nil {
+		return kerrors.NewInternalError(err)
+	}
+
+	tier := ""
+	for _, w := range ww.Items {
+		if w.Status.Space.IsHome {
+			tier = w.Status.Space.Tier
+			break
+		}
+	}
+	limit, err := cfg.Quotas.MaxWorkspacesFor(u.Labels, tier)
+	if err != nil {
+		return kerrors.NewInternalError(err)
+	}
+	if limit == nil {
+		return nil
+	}
+
+	if n := uc.usage(ww.Items); n >= int(*limit) {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			name,
+			fmt.Errorf("quota exceeded: the user owns %d workspaces out of the %d allowed", n, *limit))
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package writeclient_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-workspaces/workspaces/server/persistence/internal/cache"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/writeclient"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+)
+
+var _ = Describe("BuildBuildClientFuncForConfig", func() {
+	var ctx context.Context
+	var fakeClient client.WithWatch
+
+	kubesawNamespace := "toolchain-host"
+	config := &rest.Config{Host: "https://127.0.0.1:6443"}
+
+	buildUserSignup := func(name, sub string) *toolchainv1alpha1.UserSignup {
+		return &toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: kubesawNamespace,
+			},
+			Spec: toolchainv1alpha1.UserSignupSpec{
+				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
+						Sub: sub,
+					},
+				},
+			},
+			Status: toolchainv1alpha1.UserSignupStatus{
+				CompliantUsername: name,
+			},
+		}
+	}
+
+	buildClientFunc := func(cacheSize int, objs ...client.Object) writeclient.BuildClientFunc {
+		scheme := runtime.NewScheme()
+		Expect(toolchainv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fcb := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+		for key, indexer := range cache.UserSignupIndexers {
+			fcb.WithIndex(&toolchainv1alpha1.UserSignup{}, key, indexer)
+		}
+		fakeClient = fcb.Build()
+
+		iwcli := iwclient.New(fakeClient, "workspaces-system", kubesawNamespace)
+		f, err := writeclient.BuildBuildClientFuncForConfig(config, iwcli, cacheSize)
+		Expect(err).NotTo(HaveOccurred())
+		return f
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("fails if the user has no UserSignup", func() {
+		// given
+		f := buildClientFunc(2)
+
+		// when
+		c, err := f(ctx, "foo")
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(c).To(BeNil())
+	})
+
+	It("reuses the client built for the same user", func() {
+		// given
+		f := buildClientFunc(2, buildUserSignup("foo", "foo-sub"), buildUserSignup("bar", "bar-sub"))
+
+		// when
+		c1, err1 := f(ctx, "foo")
+		c2, err2 := f(ctx, "foo")
+		c3, err3 := f(ctx, "bar")
+
+		// then
+		Expect(err1).NotTo(HaveOccurred())
+		Expect(err2).NotTo(HaveOccurred())
+		Expect(err3).NotTo(HaveOccurred())
+		Expect(c1).To(BeIdenticalTo(c2))
+		Expect(c1).NotTo(BeIdenticalTo(c3))
+	})
+
+	It("rebuilds the client if the user's identity changed", func() {
+		// given
+		u := buildUserSignup("foo", "foo-sub")
+		f := buildClientFunc(2, u)
+		c1, err := f(ctx, "foo")
+		Expect(err).NotTo(HaveOccurred())
+
+		u.Spec.IdentityClaims.Sub = "new-foo-sub"
+		Expect(fakeClient.Update(ctx, u)).To(Succeed())
+
+		// when
+		c2, err := f(ctx, "foo")
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c1).NotTo(BeIdenticalTo(c2))
+	})
+
+	It("evicts the least recently used client", func() {
+		// given
+		f := buildClientFunc(1, buildUserSignup("foo", "foo-sub"), buildUserSignup("bar", "bar-sub"))
+		c1, err := f(ctx, "foo")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f(ctx, "bar")
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		c2, err := f(ctx, "foo")
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c1).NotTo(BeIdenticalTo(c2))
+	})
+})
+
+var _ = Describe("ImpersonationConfigForUserSignup", func() {
+	It("impersonates the user with groups and extras from the UserSignup", func() {
+		// given
+		u := toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo-signup"},
+			Spec: toolchainv1alpha1.UserSignupSpec{
+				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{Sub: "foo-sub"},
+				},
+			},
+			Status: toolchainv1alpha1.UserSignupStatus{CompliantUsername: "foo"},
+		}
+
+		// when
+		c := writeclient.ImpersonationConfigForUserSignup(&u)
+
+		// then
+		Expect(c.UserName).To(Equal("foo"))
+		Expect(c.Groups).To(ConsistOf("system:authenticated", toolchainv1alpha1.KubesawAuthenticatedUsername))
+		Expect(c.Extra).To(And(
+			HaveKeyWithValue(writeclient.ImpersonationExtraSub, []string{"foo-sub"}),
+			HaveKeyWithValue(writeclient.ImpersonationExtraUserSignup, []string{"foo-signup"}),
+		))
+	})
+})
@@ -0,0 +1,160 @@
+package writeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/persistence/clientinterface"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/mapper"
+	"github.com/konflux-workspaces/workspaces/server/persistence/mutate"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ workspace.WorkspaceTransferrer = &WriteClient{}
+
+// TransferUserWorkspace transfers as `user` the workspace `owner/space` to the new owner.
+// Only the owner is allowed to transfer a workspace, and the home workspace can not be transferred.
+// The previous owner keeps access to the workspace only if a role is requested for them.
+func (c *WriteClient) TransferUserWorkspace(
+	ctx context.Context,
+	user, owner, space string,
+	transfer *restworkspacesv1alpha1.WorkspaceTransfer,
+	workspace *restworkspacesv1alpha1.Workspace,
+) error {
+	l := log.FromContext(ctx).With("owner", owner, "workspace", space, "user", user, "transfer", transfer)
+
+	// build client impersonating the user
+	cli, err := c.buildClient(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	// get the InternalWorkspace as user
+	iw := workspacesv1alpha1.InternalWorkspace{}
+	key := clientinterface.SpaceKey{Owner: owner, Name: space}
+	if err := c.workspacesReader.GetAsUser(ctx, user, key, &iw); err != nil {
+		if !errors.Is(err, iwclient.ErrWorkspaceNotFound) && !errors.Is(err, iwclient.ErrUnauthorized) {
+			l.Error("error retrieving workspace", "error", err)
+			return kerrors.NewInternalError(err)
+		}
+		return kerrors.NewNotFound(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space)
+	}
+
+	// only the owner can transfer the workspace
+	if iw.Status.Owner.Username != user {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space,
+			fmt.Errorf("to transfer a workspace you need to be the owner"))
+	}
+
+	// workspaces can be transferred only if the feature is enabled
+	cfg, err := c.workspacesReader.GetWorkspacesConfig(ctx)
+	if err != nil {
+		l.Error("error retrieving WorkspacesConfig", "error", err)
+		return kerrors.NewInternalError(err)
+	}
+	if !cfg.Features.IsOwnershipTransferEnabled() {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space,
+			fmt.Errorf("ownership transfer is disabled"))
+	}
+
+	// the home workspace lives as long as its owner
+	if iw.Status.Space.IsHome || iw.Spec.DisplayName == workspacesv1alpha1.DisplayNameDefaultWorkspace {
+		return kerrors.NewForbidden(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space,
+			fmt.Errorf("the home workspace can not be transferred"))
+	}
+
+	if transfer.NewOwner == iw.Status.Owner.Username {
+		return kerrors.NewInvalid(
+			restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceTransfer").GroupKind(),
+			space,
+			field.ErrorList{field.Invalid(field.NewPath("newOwner"), transfer.NewOwner, "the workspace is already owned by this user")})
+	}
+
+	// resolve the new owner
+	u := toolchainv1alpha1.UserSignup{}
+	if err := c.workspacesReader.GetUserSignupByComplaintName(ctx, transfer.NewOwner, &u); err != nil {
+		if !errors.Is(err, iwclient.ErrUserSignupNotFound) {
+			l.Error("error retrieving UserSignup for new owner", "error", err)
+			return kerrors.NewInternalError(fmt.Errorf("error retrieving user information"))
+		}
+		return kerrors.NewInvalid(
+			restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceTransfer").GroupKind(),
+			space,
+			field.ErrorList{field.NotFound(field.NewPath("newOwner"), transfer.NewOwner)})
+	}
+
+	// display names are unique per owner
+	exists, err := c.workspacesReader.WorkspaceExists(ctx, clientinterface.SpaceKey{Owner: u.Status.CompliantUsername, Name: space})
+	if err != nil {
+		l.Error("error checking if workspace already exists", "error", err)
+		return kerrors.NewInternalError(err)
+	}
+	if exists {
+		return kerrors.NewAlreadyExists(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			space)
+	}
+
+	// the owner change is admitted only if annotated as a transfer to the new owner
+	iw.Spec.Owner = workspacesv1alpha1.UserInfo{JwtInfo: ownerJwtInfo(&u, cfg)}
+	if iw.Annotations == nil {
+		iw.Annotations = map[string]string{}
+	}
+	iw.Annotations[workspacesv1alpha1.AnnotationOwnerTransfer] = u.Spec.IdentityClaims.Sub
+
+	// the new owner's access is granted through ownership,
+	// while the previous owner's one is kept only if requested
+	iw.Spec.Members = slices.DeleteFunc(iw.Spec.Members, func(m workspacesv1alpha1.InternalWorkspaceMember) bool {
+		return m.Username == u.Status.CompliantUsername || m.Username == user
+	})
+	if transfer.PreviousOwnerRole != "" {
+		iw.Spec.Members = append(iw.Spec.Members, workspacesv1alpha1.InternalWorkspaceMember{
+			Username: user,
+			Role:     workspacesv1alpha1.InternalWorkspaceRole(transfer.PreviousOwnerRole),
+		})
+	}
+	slices.SortFunc(iw.Spec.Members, func(a, b workspacesv1alpha1.InternalWorkspaceMember) int {
+		return strings.Compare(a.Username, b.Username)
+	})
+
+	l.Debug("transferring workspace", "internalworkspace", iw.Name)
+	if err := cli.Update(ctx, &iw); err != nil {
+		return err
+	}
+
+	// map InternalWorkspace to Workspace
+	w, err := mapper.Default.InternalWorkspaceToWorkspace(&iw)
+	if err != nil {
+		return kerrors.NewInternalError(err)
+	}
+
+	// status is updated by the operator, so the new owner is set explicitly
+	w.SetNamespace(u.Status.CompliantUsername)
+
+	mutate.ApplyIsOwnerLabel(w, user)
+	w.Labels[restworkspacesv1alpha1.LabelHasDirectAccess] = strconv.FormatBool(transfer.PreviousOwnerRole != "")
+
+	w.DeepCopyInto(workspace)
+	return nil
+}
@@ -0,0 +1,279 @@
+package writeclient_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/konflux-workspaces/workspaces/server/persistence/internal/cache"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+	"github.com/konflux-workspaces/workspaces/server/persistence/writeclient"
+
+	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("WriteclientTransfer", func() {
+	var ctx context.Context
+	var fakeClient client.WithWatch
+	var cli *writeclient.WriteClient
+	var internalWorkspace workspacesv1alpha1.InternalWorkspace
+
+	workspacesNamespace := "workspaces-system"
+	kubesawNamespace := "toolchain-host"
+
+	owner := "owner"
+	newOwner := "new-owner"
+	other := "other"
+	space := "workspace-foo"
+
+	buildUserSignup := func(name string) *toolchainv1alpha1.UserSignup {
+		return &toolchainv1alpha1.UserSignup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: kubesawNamespace,
+			},
+			Spec: toolchainv1alpha1.UserSignupSpec{
+				IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+					PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
+						Sub:    name + "-sub",
+						UserID: name + "-id",
+						Email:  name + "@email.com",
+					},
+				},
+			},
+			Status: toolchainv1alpha1.UserSignupStatus{
+				CompliantUsername: name,
+			},
+		}
+	}
+
+	initializeCli := func(objs ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(toolchainv1alpha1.AddToScheme(scheme)).ToNot(HaveOccurred())
+		Expect(workspacesv1alpha1.AddToScheme(scheme)).ToNot(HaveOccurred())
+
+		fcb := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...)
+		for key, indexer := range cache.UserSignupIndexers {
+			fcb.WithIndex(&toolchainv1alpha1.UserSignup{}, key, indexer)
+		}
+		for key, indexer := range cache.InternalWorkspacesIndexers {
+			fcb.WithIndex(&workspacesv1alpha1.InternalWorkspace{}, key, indexer)
+		}
+		fakeClient = fcb.Build()
+
+		clientFunc := func(context.Context, string) (client.Client, error) {
+			return fakeClient, nil
+		}
+		iwcli := iwclient.New(fakeClient, workspacesNamespace, kubesawNamespace)
+		cli = writeclient.New(clientFunc, workspacesNamespace, iwcli)
+	}
+
+	getInternalWorkspace := func() workspacesv1alpha1.InternalWorkspace {
+		iw := workspacesv1alpha1.InternalWorkspace{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &iw)).To(Succeed())
+		return iw
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		internalWorkspace = workspacesv1alpha1.InternalWorkspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      space + "-fddjk",
+				Namespace: workspacesNamespace,
+			},
+			Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+				Visibility:  workspacesv1alpha1.InternalWorkspaceVisibilityCommunity,
+				DisplayName: space,
+				Owner: workspacesv1alpha1.UserInfo{
+					JwtInfo: workspacesv1alpha1.JwtInfo{Sub: owner + "-sub"},
+				},
+				Members: []workspacesv1alpha1.InternalWorkspaceMember{
+					{Username: newOwner, Role: workspacesv1alpha1.InternalWorkspaceRoleContributor},
+					{Username: other, Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				},
+			},
+			Status: workspacesv1alpha1.InternalWorkspaceStatus{
+				Space: workspacesv1alpha1.SpaceInfo{
+					Name: space + "-fddjk",
+				},
+				Owner: workspacesv1alpha1.UserInfoStatus{
+					Username: owner,
+				},
+			},
+		}
+	})
+
+	When("transferring an owned workspace", func() {
+		BeforeEach(func() {
+			initializeCli(&internalWorkspace, buildUserSignup(owner), buildUserSignup(newOwner), buildUserSignup(other))
+		})
+
+		It("should set the new owner and revoke the previous owner's access", func() {
+			// given
+			w := restworkspacesv1alpha1.Workspace{}
+
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: newOwner}, &w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Namespace).To(Equal(newOwner))
+			Expect(w.Name).To(Equal(space))
+			Expect(w.Labels).To(HaveKeyWithValue(restworkspacesv1alpha1.LabelIsOwner, "false"))
+			Expect(w.Labels).To(HaveKeyWithValue(restworkspacesv1alpha1.LabelHasDirectAccess, "false"))
+
+			iw := getInternalWorkspace()
+			Expect(iw.Spec.Owner.JwtInfo).To(Equal(workspacesv1alpha1.JwtInfo{
+				Sub:    newOwner + "-sub",
+				UserId: newOwner + "-id",
+				Email:  newOwner + "@email.com",
+			}))
+			Expect(iw.Annotations).To(HaveKeyWithValue(workspacesv1alpha1.AnnotationOwnerTransfer, newOwner+"-sub"))
+			Expect(iw.Spec.Members).To(Equal([]workspacesv1alpha1.InternalWorkspaceMember{
+				{Username: other, Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+			}))
+		})
+
+		It("should keep the previous owner as member with the requested role", func() {
+			// given
+			w := restworkspacesv1alpha1.Workspace{}
+			t := restworkspacesv1alpha1.WorkspaceTransfer{
+				NewOwner:          newOwner,
+				PreviousOwnerRole: restworkspacesv1alpha1.WorkspaceRoleMaintainer,
+			}
+
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &t, &w)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.Labels).To(HaveKeyWithValue(restworkspacesv1alpha1.LabelHasDirectAccess, "true"))
+			Expect(getInternalWorkspace().Spec.Members).To(Equal([]workspacesv1alpha1.InternalWorkspaceMember{
+				{Username: other, Role: workspacesv1alpha1.InternalWorkspaceRoleViewer},
+				{Username: owner, Role: workspacesv1alpha1.InternalWorkspaceRoleMaintainer},
+			}))
+		})
+
+		It("should fail with 422 if the new owner does not exist", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: "not-found"}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+			Expect(getInternalWorkspace().Spec.Owner.JwtInfo.Sub).To(Equal(owner + "-sub"))
+		})
+
+		It("should fail with 422 if the new owner is the current one", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: owner}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("should fail with 403 if the user is not the owner", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, other, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: other}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			Expect(getInternalWorkspace().Spec.Owner.JwtInfo.Sub).To(Equal(owner + "-sub"))
+		})
+	})
+
+	When("the new owner already has a workspace with the same name", func() {
+		BeforeEach(func() {
+			existing := workspacesv1alpha1.InternalWorkspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      space + "-abcde",
+					Namespace: workspacesNamespace,
+				},
+				Spec: workspacesv1alpha1.InternalWorkspaceSpec{
+					Visibility:  workspacesv1alpha1.InternalWorkspaceVisibilityPrivate,
+					DisplayName: space,
+				},
+				Status: workspacesv1alpha1.InternalWorkspaceStatus{
+					Space: workspacesv1alpha1.SpaceInfo{Name: space + "-abcde"},
+					Owner: workspacesv1alpha1.UserInfoStatus{Username: newOwner},
+				},
+			}
+			initializeCli(&internalWorkspace, &existing, buildUserSignup(owner), buildUserSignup(newOwner))
+		})
+
+		It("should fail with 409", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: newOwner}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsAlreadyExists(err)).To(BeTrue())
+			Expect(getInternalWorkspace().Spec.Owner.JwtInfo.Sub).To(Equal(owner + "-sub"))
+		})
+	})
+
+	When("the ownership transfer is disabled", func() {
+		BeforeEach(func() {
+			c := workspacesv1alpha1.WorkspacesConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: workspacesv1alpha1.WorkspacesConfigName},
+				Spec: workspacesv1alpha1.WorkspacesConfigSpec{
+					Features: workspacesv1alpha1.WorkspacesConfigFeatures{OwnershipTransfer: ptr.To(false)},
+				},
+			}
+			initializeCli(&internalWorkspace, &c, buildUserSignup(owner), buildUserSignup(newOwner))
+		})
+
+		It("should fail with 403", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: newOwner}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			Expect(getInternalWorkspace().Spec.Owner.JwtInfo.Sub).To(Equal(owner + "-sub"))
+		})
+	})
+
+	When("transferring the home workspace", func() {
+		BeforeEach(func() {
+			internalWorkspace.Spec.DisplayName = workspacesv1alpha1.DisplayNameDefaultWorkspace
+			internalWorkspace.Status.Space.IsHome = true
+			initializeCli(&internalWorkspace, buildUserSignup(owner), buildUserSignup(newOwner))
+		})
+
+		It("should fail with 403", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, workspacesv1alpha1.DisplayNameDefaultWorkspace, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: newOwner}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsForbidden(err)).To(BeTrue())
+			Expect(getInternalWorkspace().Spec.Owner.JwtInfo.Sub).To(Equal(owner + "-sub"))
+		})
+	})
+
+	When("transferring a non existing workspace", func() {
+		BeforeEach(func() { initializeCli(buildUserSignup(owner), buildUserSignup(newOwner)) })
+
+		It("should fail with 404", func() {
+			// when
+			err := cli.TransferUserWorkspace(ctx, owner, owner, space, &restworkspacesv1alpha1.WorkspaceTransfer{NewOwner: newOwner}, &restworkspacesv1alpha1.Workspace{})
+
+			// then
+			Expect(err).To(HaveOccurred())
+			Expect(kerrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -2,6 +2,8 @@ package writeclient
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,7 +23,7 @@ var _ workspace.WorkspaceUpdater = &WriteClient{}
 // UpdateUserWorkspace updates as `user` the InternalWorkspace representing the provided Workspace
 func (c *WriteClient) UpdateUserWorkspace(ctx context.Context, user string, workspace *restworkspacesv1alpha1.Workspace, opts ...client.UpdateOption) error {
 	// build client impersonating the user
-	cli, err := c.buildClient(user)
+	cli, err := c.buildClient(ctx, user)
 	if err != nil {
 		return err
 	}
@@ -41,21 +43,44 @@ func (c *WriteClient) UpdateUserWorkspace(ctx context.Context, user string, work
 			workspace.Name)
 	}
 
-	// check Generation matching
-	if iw.Generation != ciw.Generation {
-		return kerrors.NewResourceExpired("workspace version changed")
-	}
 	if ciw.Status.Owner.Username != user {
 		return kerrors.NewForbidden(
 			workspacesv1alpha1.GroupVersion.WithResource("workspace").GroupResource(),
 			"to update a workspace you need to be the owner", nil)
 	}
 
+	// check UID precondition
+	if iw.UID != "" && iw.UID != ciw.UID {
+		return kerrors.NewConflict(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name,
+			fmt.Errorf("precondition failed: UID in precondition: %s, UID in object meta: %s", iw.UID, ciw.UID))
+	}
+
+	// the ResourceVersion is sent as precondition to the API Server,
+	// that will reject the update if the InternalWorkspace has been changed in the meantime
+	if iw.ResourceVersion != "" {
+		ciw.ResourceVersion = iw.ResourceVersion
+	}
+
 	// update the InternalWorkspace
 	ciw.Spec.Visibility = iw.Spec.Visibility
+	if mf, ok := iw.GetAnnotations()[restworkspacesv1alpha1.AnnotationManagedFields]; ok {
+		if ciw.Annotations == nil {
+			ciw.Annotations = map[string]string{}
+		}
+		ciw.Annotations[restworkspacesv1alpha1.AnnotationManagedFields] = mf
+	}
 	log.FromContext(ctx).Debug("updating user workspace", "workspace", iw, "user", user)
 	err = cli.Update(ctx, &ciw, opts...)
-	if err != nil {
+	switch {
+	case kerrors.IsConflict(err):
+		// do not leak the InternalWorkspace's details
+		return kerrors.NewConflict(
+			restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(),
+			workspace.Name,
+			errors.New("the object has been modified; please apply your changes to the latest version and try again"))
+	case err != nil:
 		return err
 	}
 
@@ -59,7 +59,7 @@ var _ = Describe("WriteclientUpdate", func() {
 		BeforeEach(func() {
 			fakeClient = fakeClientBuilder.Build()
 
-			clientFunc := func(string) (client.Client, error) {
+			clientFunc := func(context.Context, string) (client.Client, error) {
 				return fakeClient, nil
 			}
 			iwcli := iwclient.New(fakeClient, namespace, namespace)
@@ -113,7 +113,7 @@ var _ = Describe("WriteclientUpdate", func() {
 			}
 			fakeClient = fcb.Build()
 
-			clientFunc := func(string) (client.Client, error) {
+			clientFunc := func(context.Context, string) (client.Client, error) {
 				return fakeClient, nil
 			}
 			iwcli := iwclient.New(fakeClient, workspacesNamespace, kubesawNamespace)
@@ -180,6 +180,77 @@ var _ = Describe("WriteclientUpdate", func() {
 				Expect(w.Labels).To(HaveKeyWithValue(restworkspacesv1alpha1.LabelIsOwner, "true"))
 				Expect(w.Labels).To(HaveKeyWithValue(restworkspacesv1alpha1.LabelHasDirectAccess, "true"))
 			})
+
+			It("should update if the resourceVersion matches", func() {
+				// given
+				ciw := workspacesv1alpha1.InternalWorkspace{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &ciw)).To(Succeed())
+				w := workspace.DeepCopy()
+				w.ResourceVersion = ciw.ResourceVersion
+				w.UID = ciw.UID
+				w.Spec.Visibility = restworkspacesv1alpha1.WorkspaceVisibilityCommunity
+
+				// when
+				err := cli.UpdateUserWorkspace(ctx, user, w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.ResourceVersion).NotTo(Equal(ciw.ResourceVersion))
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &ciw)).To(Succeed())
+				Expect(ciw.Spec.Visibility).To(Equal(workspacesv1alpha1.InternalWorkspaceVisibilityCommunity))
+			})
+
+			It("should store the managed fields", func() {
+				// given
+				w := workspace.DeepCopy()
+				w.ManagedFields = []metav1.ManagedFieldsEntry{
+					{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply},
+				}
+
+				// when
+				err := cli.UpdateUserWorkspace(ctx, user, w)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(w.ManagedFields).To(HaveLen(1))
+				Expect(w.ManagedFields[0].Manager).To(Equal("kubectl"))
+				ciw := workspacesv1alpha1.InternalWorkspace{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &ciw)).To(Succeed())
+				Expect(ciw.Annotations).To(HaveKey(restworkspacesv1alpha1.AnnotationManagedFields))
+			})
+
+			It("should fail with Conflict if the resourceVersion is stale", func() {
+				// given
+				ciw := workspacesv1alpha1.InternalWorkspace{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &ciw)).To(Succeed())
+				w := workspace.DeepCopy()
+				w.ResourceVersion = ciw.ResourceVersion
+				ciw.Labels = map[string]string{"concurrent": "change"}
+				Expect(fakeClient.Update(ctx, &ciw)).To(Succeed())
+				w.Spec.Visibility = restworkspacesv1alpha1.WorkspaceVisibilityCommunity
+
+				// when
+				err := cli.UpdateUserWorkspace(ctx, user, w)
+
+				// then
+				Expect(err).To(HaveOccurred())
+				Expect(kerrors.IsConflict(err)).To(BeTrue())
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(&internalWorkspace), &ciw)).To(Succeed())
+				Expect(ciw.Spec.Visibility).To(Equal(workspacesv1alpha1.InternalWorkspaceVisibilityPrivate))
+			})
+
+			It("should fail with Conflict if the UID does not match", func() {
+				// given
+				w := workspace.DeepCopy()
+				w.UID = "another-uid"
+
+				// when
+				err := cli.UpdateUserWorkspace(ctx, user, w)
+
+				// then
+				Expect(err).To(HaveOccurred())
+				Expect(kerrors.IsConflict(err)).To(BeTrue())
+			})
 		})
 	})
 })
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+const (
+	// DefaultClockSkew is the default tolerance applied when checking the tokens' exp and nbf claims
+	DefaultClockSkew time.Duration = 30 * time.Second
+	// DefaultGroupsClaim is the default claim the user's groups are read from
+	DefaultGroupsClaim string = "groups"
+)
+
+// Identity is the identity of an authenticated user
+type Identity struct {
+	Issuer string
+	Sub    string
+	Email  string
+	Groups []string
+}
+
+// OIDCConfig configures the OIDCAuthenticator
+type OIDCConfig struct {
+	// IssuerURL is the URL of the OIDC issuer, used for discovering its configuration and keys
+	IssuerURL string
+	// Audiences are the accepted values of the tokens' aud claim. At least one is required.
+	Audiences []string
+	// ClockSkew is the tolerance applied when checking the tokens' exp and nbf claims
+	ClockSkew time.Duration
+	// GroupsClaim is the claim the user's groups are read from
+	GroupsClaim string
+}
+
+// OIDCAuthenticator authenticates users from the JWTs issued by an OIDC issuer.
+// The issuer's keys are fetched from its JWKS endpoint, and fetched again
+// when a token is signed with an unknown key, so that keys can be rotated.
+type OIDCAuthenticator struct {
+	verifier    *oidc.IDTokenVerifier
+	audiences   []string
+	clockSkew   time.Duration
+	groupsClaim string
+}
+
+// NewOIDCAuthenticator discovers the issuer's configuration and builds an OIDCAuthenticator.
+// The context is used for fetching the issuer's keys for the whole life of the authenticator.
+func NewOIDCAuthenticator(ctx context.Context, cfg OIDCConfig) (*OIDCAuthenticator, error) {
+	if len(cfg.Audiences) == 0 {
+		return nil, errors.New("at least one audience is required")
+	}
+
+	p, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering OIDC issuer %s: %w", cfg.IssuerURL, err)
+	}
+
+	gc := cfg.GroupsClaim
+	if gc == "" {
+		gc = DefaultGroupsClaim
+	}
+	return &OIDCAuthenticator{
+		verifier: p.Verifier(&oidc.Config{
+			// audiences are checked by the authenticator, as more than one can be accepted
+			SkipClientIDCheck: true,
+			// expiry and nbf are checked by the authenticator, applying the clock skew
+			SkipExpiryCheck: true,
+		}),
+		audiences:   cfg.Audiences,
+		clockSkew:   cfg.ClockSkew,
+		groupsClaim: gc,
+	}, nil
+}
+
+// Authenticate validates the token's signature, issuer, audience and expiry,
+// and returns the identity it carries
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	t, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(t.Audience, func(aud string) bool { return slices.Contains(a.audiences, aud) }) {
+		return nil, fmt.Errorf("token audience %v not accepted", t.Audience)
+	}
+	if t.Subject == "" {
+		return nil, errors.New("token has no sub claim")
+	}
+
+	cc := map[string]any{}
+	if err := t.Claims(&cc); err != nil {
+		return nil, fmt.Errorf("error parsing token claims: %w", err)
+	}
+	if err := a.checkValidity(t, cc); err != nil {
+		return nil, err
+	}
+	i := Identity{Issuer: t.Issuer, Sub: t.Subject}
+	if e, ok := cc["email"].(string); ok {
+		i.Email = e
+	}
+	i.Groups = stringsClaim(cc[a.groupsClaim])
+	return &i, nil
+}
+
+// checkValidity ensures the token is not expired and is already valid,
+// tolerating the clock skew between the server and the issuer
+func (a *OIDCAuthenticator) checkValidity(t *oidc.IDToken, cc map[string]any) error {
+	now := time.Now()
+	if t.Expiry.Add(a.clockSkew).Before(now) {
+		return &oidc.TokenExpiredError{Expiry: t.Expiry}
+	}
+	if nbf, ok := cc["nbf"].(float64); ok {
+		if nt := time.Unix(int64(nbf), 0); now.Add(a.clockSkew).Before(nt) {
+			return fmt.Errorf("token not valid before %v", nt)
+		}
+	}
+	return nil
+}
+
+// stringsClaim reads a claim that can be either a string or a list of strings
+func stringsClaim(c any) []string {
+	switch v := c.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		ss := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				ss = append(ss, s)
+			}
+		}
+		return ss
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,197 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-jose/go-jose/v4"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/auth"
+)
+
+// jwksStub is a local OIDC issuer serving its discovery document and JWKS
+type jwksStub struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	keys []jose.JSONWebKey
+}
+
+func newJWKSStub() *jwksStub {
+	s := &jwksStub{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                s.URL,
+			"jwks_uri":                              s.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: s.keys})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// newKey generates a new signing key, publishing it in the JWKS if `publish` is true
+func (s *jwksStub) newKey(kid string, publish bool) *rsa.PrivateKey {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+	if publish {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.keys = append(s.keys, jose.JSONWebKey{Key: k.Public(), KeyID: kid, Algorithm: string(jose.RS256), Use: "sig"})
+	}
+	return k
+}
+
+func signToken(key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	s, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: kid}},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	Expect(err).NotTo(HaveOccurred())
+	p, err := json.Marshal(claims)
+	Expect(err).NotTo(HaveOccurred())
+	o, err := s.Sign(p)
+	Expect(err).NotTo(HaveOccurred())
+	t, err := o.CompactSerialize()
+	Expect(err).NotTo(HaveOccurred())
+	return t
+}
+
+var _ = Describe("OIDCAuthenticator", func() {
+	var ctx context.Context
+	var stub *jwksStub
+	var key *rsa.PrivateKey
+	var authenticator *auth.OIDCAuthenticator
+
+	claims := func(overrides map[string]any) map[string]any {
+		cc := map[string]any{
+			"iss":    stub.URL,
+			"aud":    "workspaces",
+			"sub":    "user-sub",
+			"email":  "user@email.com",
+			"groups": []string{"group-a", "group-b"},
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"iat":    time.Now().Unix(),
+		}
+		for k, v := range overrides {
+			cc[k] = v
+		}
+		return cc
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		stub = newJWKSStub()
+		DeferCleanup(stub.Close)
+		key = stub.newKey("key-1", true)
+
+		var err error
+		authenticator, err = auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{
+			IssuerURL: stub.URL,
+			Audiences: []string{"other", "workspaces"},
+			ClockSkew: time.Minute,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the identity of valid tokens", func() {
+		// given
+		t := signToken(key, "key-1", claims(nil))
+
+		// when
+		i, err := authenticator.Authenticate(ctx, t)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*i).To(Equal(auth.Identity{Issuer: stub.URL, Sub: "user-sub", Email: "user@email.com", Groups: []string{"group-a", "group-b"}}))
+	})
+
+	DescribeTable("rejects invalid tokens", func(overrides map[string]any) {
+		// given
+		t := signToken(key, "key-1", claims(overrides))
+
+		// when
+		_, err := authenticator.Authenticate(ctx, t)
+
+		// then
+		Expect(err).To(HaveOccurred())
+	},
+		Entry("not accepted audience", map[string]any{"aud": "not-workspaces"}),
+		Entry("other issuer", map[string]any{"iss": "https://not-the-issuer"}),
+		Entry("expired beyond the clock skew", map[string]any{"exp": time.Now().Add(-2 * time.Minute).Unix()}),
+		Entry("missing sub", map[string]any{"sub": ""}),
+		Entry("not valid yet beyond the clock skew", map[string]any{"nbf": time.Now().Add(2 * time.Minute).Unix()}),
+	)
+
+	It("accepts tokens valid within the clock skew", func() {
+		// given
+		t := signToken(key, "key-1", claims(map[string]any{"nbf": time.Now().Add(30 * time.Second).Unix()}))
+
+		// when
+		i, err := authenticator.Authenticate(ctx, t)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.Sub).To(Equal("user-sub"))
+	})
+
+	It("accepts tokens expired within the clock skew", func() {
+		// given
+		t := signToken(key, "key-1", claims(map[string]any{"exp": time.Now().Add(-30 * time.Second).Unix()}))
+
+		// when
+		i, err := authenticator.Authenticate(ctx, t)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.Sub).To(Equal("user-sub"))
+	})
+
+	It("rejects tokens signed with keys not published by the issuer", func() {
+		// given
+		k := stub.newKey("key-1", false)
+		t := signToken(k, "key-1", claims(nil))
+
+		// when
+		_, err := authenticator.Authenticate(ctx, t)
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("accepts tokens signed with rotated keys", func() {
+		// given the issuer publishes a new key after the first token is validated
+		_, err := authenticator.Authenticate(ctx, signToken(key, "key-1", claims(nil)))
+		Expect(err).NotTo(HaveOccurred())
+		k := stub.newKey("key-2", true)
+
+		// when
+		i, err := authenticator.Authenticate(ctx, signToken(k, "key-2", claims(nil)))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i.Sub).To(Equal("user-sub"))
+	})
+
+	It("requires an audience", func() {
+		// when
+		_, err := auth.NewOIDCAuthenticator(ctx, auth.OIDCConfig{IssuerURL: stub.URL})
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
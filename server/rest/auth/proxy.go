@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies identifies the requests sent by proxies trusted to authenticate users
+// on behalf of the server and to forward their identity in the request headers
+type TrustedProxies struct {
+	// CIDRs are the networks trusted proxies send requests from
+	CIDRs []*net.IPNet
+	// ClientCertificates trusts the requests authenticated with a client certificate
+	// verified against the server's client CAs, i.e. via mutual TLS
+	ClientCertificates bool
+}
+
+// ParseCIDRs parses a comma-separated list of CIDRs
+func ParseCIDRs(s string) ([]*net.IPNet, error) {
+	nn := []*net.IPNet{}
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", c, err)
+		}
+		nn = append(nn, n)
+	}
+	return nn, nil
+}
+
+// IsEnabled returns true if any proxy is trusted
+func (p *TrustedProxies) IsEnabled() bool {
+	return p != nil && (len(p.CIDRs) > 0 || p.ClientCertificates)
+}
+
+// IsTrusted returns true if the request has been sent by a trusted proxy
+func (p *TrustedProxies) IsTrusted(r *http.Request) bool {
+	if p == nil {
+		return false
+	}
+
+	if p.ClientCertificates && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return true
+	}
+
+	h, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(h)
+	if ip == nil {
+		return false
+	}
+	for _, n := range p.CIDRs {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package auth_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/auth"
+)
+
+var _ = Describe("TrustedProxies", func() {
+	buildRequest := func(remoteAddr string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = remoteAddr
+		return r
+	}
+
+	DescribeTable("trusts requests from the configured CIDRs", func(remoteAddr string, expected bool) {
+		// given
+		cidrs, err := auth.ParseCIDRs("127.0.0.1/32, ::1/128,10.0.0.0/8")
+		Expect(err).NotTo(HaveOccurred())
+		p := auth.TrustedProxies{CIDRs: cidrs}
+
+		// when
+		trusted := p.IsTrusted(buildRequest(remoteAddr))
+
+		// then
+		Expect(trusted).To(Equal(expected))
+	},
+		Entry("loopback", "127.0.0.1:41234", true),
+		Entry("IPv6 loopback", "[::1]:41234", true),
+		Entry("in network", "10.1.2.3:41234", true),
+		Entry("out of network", "192.168.1.1:41234", false),
+		Entry("malformed address", "not-an-address", false),
+	)
+
+	It("trusts requests with a verified client certificate", func() {
+		// given
+		p := auth.TrustedProxies{ClientCertificates: true}
+		r := buildRequest("192.168.1.1:41234")
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}}
+
+		// when
+		trusted := p.IsTrusted(r)
+
+		// then
+		Expect(trusted).To(BeTrue())
+	})
+
+	It("does not trust TLS requests without a verified client certificate", func() {
+		// given
+		p := auth.TrustedProxies{ClientCertificates: true}
+		r := buildRequest("192.168.1.1:41234")
+		r.TLS = &tls.ConnectionState{}
+
+		// when
+		trusted := p.IsTrusted(r)
+
+		// then
+		Expect(trusted).To(BeFalse())
+	})
+
+	It("rejects invalid CIDRs", func() {
+		// when
+		_, err := auth.ParseCIDRs("127.0.0.1")
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,125 @@
+package discovery
+
+import (
+	_ "embed"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// crdManifest is the CustomResourceDefinition generated from the server/api/v1alpha1 types
+// and their kubebuilder markers. It is regenerated by `make manifests`.
+//
+//go:embed crd/workspaces.konflux-ci.dev_workspaces.yaml
+var crdManifest []byte
+
+// customResourceDefinition holds the fields of a CustomResourceDefinition the documents are built from
+type customResourceDefinition struct {
+	Spec struct {
+		Group string `json:"group"`
+		Names struct {
+			Kind       string   `json:"kind"`
+			ListKind   string   `json:"listKind"`
+			Plural     string   `json:"plural"`
+			Singular   string   `json:"singular"`
+			ShortNames []string `json:"shortNames,omitempty"`
+			Categories []string `json:"categories,omitempty"`
+		} `json:"names"`
+		Scope    string `json:"scope"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Served bool   `json:"served"`
+			Schema struct {
+				OpenAPIV3Schema map[string]any `json:"openAPIV3Schema"`
+			} `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// Documents are the discovery and OpenAPI v3 documents describing the workspaces API
+type Documents struct {
+	// APIGroupList is served at /apis
+	APIGroupList metav1.APIGroupList
+	// APIGroup is served at /apis/{group}
+	APIGroup metav1.APIGroup
+	// APIResourceList is served at /apis/{group}/{version}
+	APIResourceList metav1.APIResourceList
+	// OpenAPI is the OpenAPI v3 document of the group version, served at /openapi/v3/apis/{group}/{version}
+	OpenAPI []byte
+	// OpenAPIHash is the hash of the OpenAPI v3 document, used by clients for caching it
+	OpenAPIHash string
+}
+
+// NewDocuments builds the discovery and OpenAPI v3 documents from the embedded CustomResourceDefinition
+func NewDocuments() (*Documents, error) {
+	c := customResourceDefinition{}
+	if err := yaml.Unmarshal(crdManifest, &c); err != nil {
+		return nil, fmt.Errorf("error parsing CustomResourceDefinition: %w", err)
+	}
+	if len(c.Spec.Versions) != 1 {
+		return nil, fmt.Errorf("expected exactly one version in CustomResourceDefinition, found %d", len(c.Spec.Versions))
+	}
+
+	v := c.Spec.Versions[0]
+	gv := metav1.GroupVersionForDiscovery{
+		GroupVersion: c.Spec.Group + "/" + v.Name,
+		Version:      v.Name,
+	}
+	g := metav1.APIGroup{
+		TypeMeta:         metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"},
+		Name:             c.Spec.Group,
+		Versions:         []metav1.GroupVersionForDiscovery{gv},
+		PreferredVersion: gv,
+	}
+
+	namespaced := c.Spec.Scope == "Namespaced"
+	rl := metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: gv.GroupVersion,
+		APIResources: []metav1.APIResource{
+			{
+				Name:         c.Spec.Names.Plural,
+				SingularName: c.Spec.Names.Singular,
+				Namespaced:   namespaced,
+				Kind:         c.Spec.Names.Kind,
+				Verbs:        metav1.Verbs{"create", "delete", "get", "list", "patch", "update", "watch"},
+				ShortNames:   c.Spec.Names.ShortNames,
+				Categories:   c.Spec.Names.Categories,
+			},
+			{
+				Name:       c.Spec.Names.Plural + "/members",
+				Namespaced: namespaced,
+				Kind:       c.Spec.Names.Kind + "MemberList",
+				Verbs:      metav1.Verbs{"get"},
+			},
+			{
+				Name:       c.Spec.Names.Plural + "/transfer",
+				Namespaced: namespaced,
+				Kind:       c.Spec.Names.Kind + "Transfer",
+				Verbs:      metav1.Verbs{"create"},
+			},
+		},
+	}
+
+	o, h, err := buildOpenAPI(&c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Documents{
+		APIGroupList: metav1.APIGroupList{
+			TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"},
+			Groups:   []metav1.APIGroup{g},
+		},
+		APIGroup:        g,
+		APIResourceList: rl,
+		OpenAPI:         o,
+		OpenAPIHash:     h,
+	}, nil
+}
+
+// GroupVersionPath returns the path of the group version, relative to /apis
+func (d *Documents) GroupVersionPath() string {
+	return d.APIResourceList.GroupVersion
+}
@@ -0,0 +1,121 @@
+package discovery_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kdiscovery "k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/discovery"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Discovery", func() {
+	var documents *discovery.Documents
+	var server *httptest.Server
+
+	gv := restworkspacesv1alpha1.GroupVersion
+
+	BeforeEach(func() {
+		var err error
+		documents, err = discovery.NewDocuments()
+		Expect(err).NotTo(HaveOccurred())
+
+		// same routes the REST server registers
+		mux := http.NewServeMux()
+		mux.Handle("GET /apis", discovery.NewDocumentHandler(&documents.APIGroupList, marshal.DefaultMarshalerProvider))
+		mux.Handle(fmt.Sprintf("GET /apis/%s", gv.Group), discovery.NewDocumentHandler(&documents.APIGroup, marshal.DefaultMarshalerProvider))
+		mux.Handle(fmt.Sprintf("GET /apis/%s", gv.String()), discovery.NewDocumentHandler(&documents.APIResourceList, marshal.DefaultMarshalerProvider))
+		mux.Handle(fmt.Sprintf("GET %s", discovery.OpenAPIPrefix), discovery.NewOpenAPIIndexHandler(documents, marshal.DefaultMarshalerProvider))
+		mux.Handle(fmt.Sprintf("GET %s/apis/%s", discovery.OpenAPIPrefix, gv.String()), discovery.NewOpenAPIHandler(documents))
+		mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		server = httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+	})
+
+	It("is discovered by Kubernetes clients", func() {
+		// given
+		c, err := kdiscovery.NewDiscoveryClientForConfig(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		gg, rr, err := c.ServerGroupsAndResources()
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gg).To(HaveLen(1))
+		Expect(gg[0].Name).To(Equal(gv.Group))
+		Expect(gg[0].PreferredVersion.GroupVersion).To(Equal(gv.String()))
+
+		Expect(rr).To(HaveLen(1))
+		Expect(rr[0].GroupVersion).To(Equal(gv.String()))
+		Expect(rr[0].APIResources).To(ContainElement(SatisfyAll(
+			HaveField("Name", "workspaces"),
+			HaveField("SingularName", "workspace"),
+			HaveField("Kind", "Workspace"),
+			HaveField("Namespaced", true),
+			HaveField("Verbs", ConsistOf("create", "delete", "get", "list", "patch", "update", "watch")),
+		)))
+	})
+
+	It("serves the OpenAPI v3 document generated from the API types", func() {
+		// given
+		c, err := kdiscovery.NewDiscoveryClientForConfig(&rest.Config{Host: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+
+		// when
+		pp, err := c.OpenAPIV3().Paths()
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pp).To(HaveKey("apis/" + gv.String()))
+
+		d, err := pp["apis/"+gv.String()].Schema("application/json")
+		Expect(err).NotTo(HaveOccurred())
+
+		o := struct {
+			Paths      map[string]any `json:"paths"`
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]struct {
+						Properties map[string]struct {
+							Enum []string `json:"enum"`
+						} `json:"properties"`
+					} `json:"properties"`
+					GVK []metav1.GroupVersionKind `json:"x-kubernetes-group-version-kind"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}{}
+		Expect(json.Unmarshal(d, &o)).To(Succeed())
+		Expect(o.Paths).To(HaveKey("/apis/workspaces.konflux-ci.dev/v1alpha1/namespaces/{namespace}/workspaces/{name}"))
+
+		s, ok := o.Components.Schemas["dev.konflux-ci.workspaces.v1alpha1.Workspace"]
+		Expect(ok).To(BeTrue())
+		Expect(s.GVK).To(ConsistOf(metav1.GroupVersionKind{Group: gv.Group, Version: gv.Version, Kind: "Workspace"}))
+		Expect(s.Properties["spec"].Properties["visibility"].Enum).To(ConsistOf("community", "private"))
+		Expect(o.Components.Schemas).To(HaveKey("dev.konflux-ci.workspaces.v1alpha1.WorkspaceList"))
+	})
+
+	It("allows caching the OpenAPI v3 document requested by hash", func() {
+		// when
+		r, err := http.Get(fmt.Sprintf("%s%s/apis/%s?hash=%s", server.URL, discovery.OpenAPIPrefix, gv.String(), documents.OpenAPIHash))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		defer r.Body.Close()
+		Expect(r.StatusCode).To(Equal(http.StatusOK))
+		Expect(r.Header.Get("Cache-Control")).To(ContainSubstring("immutable"))
+		Expect(r.Header.Get("Etag")).To(Equal(`"` + documents.OpenAPIHash + `"`))
+	})
+})
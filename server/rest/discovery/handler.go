@@ -0,0 +1,96 @@
+package discovery
+
+import (
+	"net/http"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/status"
+)
+
+const (
+	// OpenAPIPrefix is the path the OpenAPI v3 documents are served at
+	OpenAPIPrefix string = "/openapi/v3"
+
+	cacheControlImmutable string = "public, immutable, max-age=31536000"
+	cacheControlNoCache   string = "no-cache, private"
+)
+
+// OpenAPIIndex lists the OpenAPI v3 documents served by the server, as Kubernetes clients expect at /openapi/v3
+type OpenAPIIndex struct {
+	Paths map[string]OpenAPIIndexPath `json:"paths"`
+}
+
+// OpenAPIIndexPath points to the OpenAPI v3 document of a group version
+type OpenAPIIndexPath struct {
+	ServerRelativeURL string `json:"serverRelativeURL"`
+}
+
+// NewDocumentHandler builds an http.Handler replying with the given discovery document
+func NewDocumentHandler(document any, marshalerProvider marshal.MarshalerProvider) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := log.FromContext(r.Context())
+
+		m, err := marshalerProvider(r)
+		if err != nil {
+			l.Debug("error building marshaler for request", "error", err)
+			if _, ok := err.(kerrors.APIStatus); !ok {
+				err = kerrors.NewBadRequest(err.Error())
+			}
+			if err := status.Write(w, nil, err); err != nil {
+				l.Error("error writing response", "error", err)
+			}
+			return
+		}
+
+		d, err := m.Marshal(document)
+		if err != nil {
+			l.Error("error marshaling response", "error", err)
+			if err := status.Write(w, m, err); err != nil {
+				l.Error("error writing response", "error", err)
+			}
+			return
+		}
+
+		w.Header().Add(header.ContentType, m.ContentType())
+		if _, err := w.Write(d); err != nil {
+			l.Error("error writing response", "error", err)
+		}
+	})
+}
+
+// NewOpenAPIIndexHandler builds an http.Handler replying with the index of the OpenAPI v3 documents.
+// Documents are referenced with their hash, so that clients can cache them.
+func NewOpenAPIIndexHandler(documents *Documents, marshalerProvider marshal.MarshalerProvider) http.Handler {
+	p := "apis/" + documents.GroupVersionPath()
+	return NewDocumentHandler(&OpenAPIIndex{
+		Paths: map[string]OpenAPIIndexPath{
+			p: {ServerRelativeURL: OpenAPIPrefix + "/" + p + "?hash=" + documents.OpenAPIHash},
+		},
+	}, marshalerProvider)
+}
+
+// NewOpenAPIHandler builds an http.Handler replying with the OpenAPI v3 document of the group version.
+// Requests carrying the document's hash can cache the response indefinitely.
+func NewOpenAPIHandler(documents *Documents) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Etag", `"`+documents.OpenAPIHash+`"`)
+		if r.URL.Query().Get("hash") == documents.OpenAPIHash {
+			w.Header().Set("Cache-Control", cacheControlImmutable)
+		} else {
+			w.Header().Set("Cache-Control", cacheControlNoCache)
+		}
+		if r.Header.Get("If-None-Match") == `"`+documents.OpenAPIHash+`"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set(header.ContentType, contentTypeJSON)
+		if _, err := w.Write(documents.OpenAPI); err != nil {
+			log.FromContext(r.Context()).Error("error writing response", "error", err)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package discovery
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	contentTypeJSON = "application/json"
+
+	// openAPIVersion is the version of the OpenAPI specification the documents comply with
+	openAPIVersion = "3.0.0"
+)
+
+// patchContentTypes are the patch formats accepted by the PATCH endpoint
+var patchContentTypes = []string{
+	"application/json-patch+json",
+	"application/merge-patch+json",
+	"application/strategic-merge-patch+json",
+	"application/apply-patch+yaml",
+}
+
+// buildOpenAPI builds the OpenAPI v3 document of the CustomResourceDefinition's version,
+// and returns it with its hash
+func buildOpenAPI(c *customResourceDefinition) ([]byte, string, error) {
+	v := c.Spec.Versions[0]
+	gv := c.Spec.Group + "/" + v.Name
+	gvk := func(kind string) []any {
+		return []any{map[string]any{"group": c.Spec.Group, "version": v.Name, "kind": kind}}
+	}
+
+	// components are named after the reversed group, as the Kubernetes API Server does for CRDs
+	prefix := reverseDomain(c.Spec.Group) + "." + v.Name + "."
+	kindName, listName := prefix+c.Spec.Names.Kind, prefix+c.Spec.Names.ListKind
+
+	k := map[string]any{}
+	for n, s := range v.Schema.OpenAPIV3Schema {
+		k[n] = s
+	}
+	k["x-kubernetes-group-version-kind"] = gvk(c.Spec.Names.Kind)
+	l := map[string]any{
+		"description": fmt.Sprintf("%s is a list of %s", c.Spec.Names.ListKind, c.Spec.Names.Kind),
+		"type":        "object",
+		"required":    []any{"items"},
+		"properties": map[string]any{
+			"apiVersion": map[string]any{"type": "string"},
+			"kind":       map[string]any{"type": "string"},
+			"metadata":   map[string]any{"type": "object"},
+			"items": map[string]any{
+				"type":  "array",
+				"items": ref(kindName),
+			},
+		},
+		"x-kubernetes-group-version-kind": gvk(c.Spec.Names.ListKind),
+	}
+
+	// operations mirror the endpoints registered by the REST server
+	op := func(action, id, kind, schema string, status int, body []string) map[string]any {
+		o := map[string]any{
+			"operationId":                     id + c.Spec.Names.Kind,
+			"tags":                            []any{strings.ReplaceAll(reverseDomain(c.Spec.Group), ".", "") + "_" + v.Name},
+			"x-kubernetes-action":             action,
+			"x-kubernetes-group-version-kind": gvk(kind)[0],
+			"responses": map[string]any{
+				fmt.Sprint(status): map[string]any{
+					"description": "OK",
+					"content":     jsonContent(schema, contentTypeJSON),
+				},
+				"401": map[string]any{"description": "Unauthorized"},
+			},
+		}
+		if len(body) > 0 {
+			o["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(kindName, body...),
+			}
+		}
+		return o
+	}
+	namespace := parameter("namespace", "object name and auth scope, such as for teams and projects")
+	name := parameter("name", "name of the "+c.Spec.Names.Kind)
+	watch := map[string]any{
+		"name":        "watch",
+		"in":          "query",
+		"description": "Watch for changes to the described resources and return them as a stream of add, update, and remove notifications.",
+		"schema":      map[string]any{"type": "boolean", "uniqueItems": true},
+	}
+	list := op("list", "list", c.Spec.Names.Kind, listName, 200, nil)
+	list["parameters"] = []any{watch}
+	nlist := op("list", "listNamespaced", c.Spec.Names.Kind, listName, 200, nil)
+	nlist["parameters"] = []any{watch}
+
+	base := "/apis/" + gv
+	d := map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{
+			"title":   "Workspaces",
+			"version": v.Name,
+		},
+		"paths": map[string]any{
+			base + "/" + c.Spec.Names.Plural: map[string]any{
+				"get": list,
+			},
+			base + "/namespaces/{namespace}/" + c.Spec.Names.Plural: map[string]any{
+				"parameters": []any{namespace},
+				"get":        nlist,
+				"post":       op("post", "createNamespaced", c.Spec.Names.Kind, kindName, 201, []string{contentTypeJSON}),
+			},
+			base + "/namespaces/{namespace}/" + c.Spec.Names.Plural + "/{name}": map[string]any{
+				"parameters": []any{namespace, name},
+				"get":        op("get", "readNamespaced", c.Spec.Names.Kind, kindName, 200, nil),
+				"put":        op("put", "replaceNamespaced", c.Spec.Names.Kind, kindName, 200, []string{contentTypeJSON}),
+				"patch":      op("patch", "patchNamespaced", c.Spec.Names.Kind, kindName, 200, patchContentTypes),
+				"delete":     op("delete", "deleteNamespaced", c.Spec.Names.Kind, "", 200, nil),
+			},
+		},
+		"components": map[string]any{
+			"schemas": map[string]any{
+				kindName: k,
+				listName: l,
+			},
+		},
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, "", fmt.Errorf("error marshaling OpenAPI document: %w", err)
+	}
+	return b, fmt.Sprintf("%X", sha512.Sum512(b)), nil
+}
+
+// reverseDomain reverses the segments of a domain, e.g. workspaces.konflux-ci.dev becomes dev.konflux-ci.workspaces
+func reverseDomain(d string) string {
+	ss := strings.Split(d, ".")
+	slices.Reverse(ss)
+	return strings.Join(ss, ".")
+}
+
+// ref returns a reference to the named component schema
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// jsonContent returns the content of a request or response body having the named component schema,
+// or a generic object if the name is empty
+func jsonContent(schema string, contentTypes ...string) map[string]any {
+	s := map[string]any{"type": "object"}
+	if schema != "" {
+		s = ref(schema)
+	}
+
+	cc := map[string]any{}
+	for _, ct := range contentTypes {
+		cc[ct] = map[string]any{"schema": s}
+	}
+	return cc
+}
+
+// parameter returns a required path parameter
+func parameter(name, description string) map[string]any {
+	return map[string]any{
+		"name":        name,
+		"in":          "path",
+		"description": description,
+		"required":    true,
+		"schema":      map[string]any{"type": "string", "uniqueItems": true},
+	}
+}
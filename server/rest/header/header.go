@@ -1,5 +1,6 @@
 package header
 
 const (
+	Accept      string = "Accept"
 	ContentType string = "Content-Type"
 )
@@ -0,0 +1,38 @@
+package marshal
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/serializer/cbor/direct"
+)
+
+const ContentTypeCbor string = "application/cbor"
+
+// selfDescribedCbor is the head of the Self-Described CBOR tag (RFC 8949 Section 3.4.6),
+// Kubernetes' CBOR serializer prefixes its output with it to recognize CBOR data
+var selfDescribedCbor = []byte{0xd9, 0xd9, 0xf7}
+
+// CborMarshaler marshals values as CBOR the way Kubernetes' CBOR serializer does
+type CborMarshaler struct{}
+
+func (m *CborMarshaler) Marshal(v any) ([]byte, error) {
+	d, err := direct.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, selfDescribedCbor...), d...), nil
+}
+
+func (m *CborMarshaler) ContentType() string {
+	return ContentTypeCbor
+}
+
+// CborUnmarshaler unmarshals CBOR data the way Kubernetes' CBOR serializer does.
+// Unknown fields are rejected.
+type CborUnmarshaler struct{}
+
+func (u *CborUnmarshaler) Unmarshal(d []byte, r any) error {
+	return direct.Unmarshal(d, r)
+}
+
+func (u *CborUnmarshaler) ContentType() string {
+	return ContentTypeCbor
+}
@@ -19,6 +19,10 @@ var (
 	DefaultMarshal   Marshaler   = &JsonMarshaler{}
 	DefaultUnmarshal Unmarshaler = &JsonUnmarshaler{}
 
-	DefaultMarshalerProvider   MarshalerProvider   = func(r *http.Request) (Marshaler, error) { return DefaultMarshal, nil }
-	DefaultUnmarshalerProvider UnmarshalerProvider = func(r *http.Request) (Unmarshaler, error) { return DefaultUnmarshal, nil }
+	// Marshalers and Unmarshalers are the supported codecs, the default one first
+	Marshalers   = []Marshaler{DefaultMarshal, &YamlMarshaler{}, &CborMarshaler{}}
+	Unmarshalers = []Unmarshaler{DefaultUnmarshal, &YamlUnmarshaler{}, &CborUnmarshaler{}}
+
+	DefaultMarshalerProvider   MarshalerProvider   = NewMarshalerProvider(Marshalers...)
+	DefaultUnmarshalerProvider UnmarshalerProvider = NewUnmarshalerProvider(Unmarshalers...)
 )
@@ -0,0 +1,158 @@
+package marshal
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+)
+
+// mediaRange is an entry of an Accept header
+type mediaRange struct {
+	mediaType string
+	params    map[string]string
+	q         float64
+}
+
+// NewMarshalerProvider builds a MarshalerProvider negotiating the Marshaler to use from the request's Accept header.
+// Media ranges are tried by decreasing quality value and, at the same quality, in the order they are listed.
+// Media types with quality value 0 are never used.
+// If the request has no Accept header, the first Marshaler is returned.
+// If none of the Marshalers is accepted, a NotAcceptable error is returned.
+func NewMarshalerProvider(marshalers ...Marshaler) MarshalerProvider {
+	return func(r *http.Request) (Marshaler, error) {
+		if !hasHeader(r, header.Accept) {
+			return marshalers[0], nil
+		}
+
+		aa := parseAccept(r)
+		for _, a := range aa {
+			for _, m := range marshalers {
+				if a.q > 0 && a.matches(m.ContentType()) && !excluded(aa, m.ContentType()) {
+					return m, nil
+				}
+			}
+		}
+		return nil, NewNotAcceptableError(marshalers)
+	}
+}
+
+// NewUnmarshalerProvider builds an UnmarshalerProvider returning the Unmarshaler for the request's Content-Type header.
+// If the request has no Content-Type header, the first Unmarshaler is returned.
+// If no Unmarshaler supports it, an UnsupportedMediaType error is returned.
+func NewUnmarshalerProvider(unmarshalers ...Unmarshaler) UnmarshalerProvider {
+	return func(r *http.Request) (Unmarshaler, error) {
+		ct := r.Header.Get(header.ContentType)
+		if ct == "" {
+			return unmarshalers[0], nil
+		}
+
+		mt, _, err := mime.ParseMediaType(ct)
+		if err == nil {
+			for _, u := range unmarshalers {
+				if u.ContentType() == mt {
+					return u, nil
+				}
+			}
+		}
+		return nil, NewUnsupportedMediaTypeError(ct, unmarshalers)
+	}
+}
+
+// NewNotAcceptableError builds the error returned when none of the given Marshalers is accepted by the request
+func NewNotAcceptableError(marshalers []Marshaler) *kerrors.StatusError {
+	tt := make([]string, 0, len(marshalers))
+	for _, m := range marshalers {
+		tt = append(tt, m.ContentType())
+	}
+	return &kerrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReasonNotAcceptable,
+		Message: fmt.Sprintf("only the following media types are accepted: %s", strings.Join(tt, ", ")),
+	}}
+}
+
+// NewUnsupportedMediaTypeError builds the error returned when none of the given Unmarshalers supports the request's body
+func NewUnsupportedMediaTypeError(contentType string, unmarshalers []Unmarshaler) *kerrors.StatusError {
+	tt := make([]string, 0, len(unmarshalers))
+	for _, u := range unmarshalers {
+		tt = append(tt, u.ContentType())
+	}
+	return NewUnsupportedMediaTypeErrorFor(contentType, tt...)
+}
+
+// NewUnsupportedMediaTypeErrorFor builds the error returned when the request's body is not of one of the supported media types
+func NewUnsupportedMediaTypeErrorFor(contentType string, supported ...string) *kerrors.StatusError {
+	return &kerrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusUnsupportedMediaType,
+		Reason:  metav1.StatusReasonUnsupportedMediaType,
+		Message: fmt.Sprintf("the media type %q of the request body is not supported, supported media types are: %s", contentType, strings.Join(supported, ", ")),
+	}}
+}
+
+// parseAccept returns the media ranges listed by the request's Accept header sorted by decreasing quality value.
+// Malformed media ranges are skipped.
+func parseAccept(r *http.Request) []mediaRange {
+	aa := []mediaRange{}
+	for _, h := range r.Header.Values(header.Accept) {
+		for _, e := range strings.Split(h, ",") {
+			mt, pp, err := mime.ParseMediaType(strings.TrimSpace(e))
+			if err != nil {
+				continue
+			}
+
+			q := 1.0
+			if v, ok := pp["q"]; ok {
+				if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+					continue
+				}
+				delete(pp, "q")
+			}
+			aa = append(aa, mediaRange{mediaType: mt, params: pp, q: q})
+		}
+	}
+
+	sort.SliceStable(aa, func(i, j int) bool { return aa[i].q > aa[j].q })
+	return aa
+}
+
+// matches returns true if the media range includes the given media type
+func (a mediaRange) matches(mediaType string) bool {
+	switch {
+	case a.mediaType == "*/*", a.mediaType == mediaType:
+		return true
+	case strings.HasSuffix(a.mediaType, "/*"):
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(a.mediaType, "*"))
+	default:
+		return false
+	}
+}
+
+// excluded returns true if the media type is explicitly excluded with quality value 0
+func excluded(aa []mediaRange, mediaType string) bool {
+	for _, a := range aa {
+		if a.q == 0 && a.mediaType == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// hasHeader returns true if the request has a non-empty value for the header
+func hasHeader(r *http.Request, name string) bool {
+	for _, v := range r.Header.Values(name) {
+		if strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package marshal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Negotiation", func() {
+	var r *http.Request
+
+	BeforeEach(func() {
+		r = httptest.NewRequest(http.MethodGet, "/", nil)
+	})
+
+	Describe("Marshaler", func() {
+		DescribeTable("negotiates the media type from the Accept header",
+			func(accept string, expected string) {
+				// given
+				if accept != "" {
+					r.Header.Set("Accept", accept)
+				}
+
+				// when
+				m, err := marshal.DefaultMarshalerProvider(r)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(m.ContentType()).To(Equal(expected))
+			},
+			Entry("no Accept header", "", marshal.ContentTypeJson),
+			Entry("JSON", "application/json", marshal.ContentTypeJson),
+			Entry("YAML", "application/yaml", marshal.ContentTypeYaml),
+			Entry("CBOR", "application/cbor", marshal.ContentTypeCbor),
+			Entry("any media type", "*/*", marshal.ContentTypeJson),
+			Entry("any application media type", "application/*", marshal.ContentTypeJson),
+			Entry("first supported one", "application/vnd.kubernetes.protobuf, application/yaml, application/json", marshal.ContentTypeYaml),
+			Entry("highest quality value", "application/json;q=0.5, application/cbor;q=0.9, */*;q=0.1", marshal.ContentTypeCbor),
+			Entry("excluded with quality value 0", "application/json;q=0, */*", marshal.ContentTypeYaml),
+			Entry("Table", "application/json;as=Table;v=v1;g=meta.k8s.io, application/json", marshal.ContentTypeJson),
+		)
+
+		It("returns NotAcceptable if no supported media type is accepted", func() {
+			// given
+			r.Header.Set("Accept", "application/vnd.kubernetes.protobuf, text/html")
+
+			// when
+			_, err := marshal.DefaultMarshalerProvider(r)
+
+			// then
+			Expect(kerrors.IsNotAcceptable(err)).To(BeTrue())
+		})
+	})
+
+	Describe("Unmarshaler", func() {
+		DescribeTable("selects the codec from the Content-Type header",
+			func(contentType string, expected string) {
+				// given
+				if contentType != "" {
+					r.Header.Set("Content-Type", contentType)
+				}
+
+				// when
+				u, err := marshal.DefaultUnmarshalerProvider(r)
+
+				// then
+				Expect(err).NotTo(HaveOccurred())
+				Expect(u.ContentType()).To(Equal(expected))
+			},
+			Entry("no Content-Type header", "", marshal.ContentTypeJson),
+			Entry("JSON", "application/json; charset=utf-8", marshal.ContentTypeJson),
+			Entry("YAML", "application/yaml", marshal.ContentTypeYaml),
+			Entry("CBOR", "application/cbor", marshal.ContentTypeCbor),
+		)
+
+		DescribeTable("returns UnsupportedMediaType for unsupported media types",
+			func(contentType string) {
+				// given
+				r.Header.Set("Content-Type", contentType)
+
+				// when
+				_, err := marshal.DefaultUnmarshalerProvider(r)
+
+				// then
+				Expect(kerrors.IsUnsupportedMediaType(err)).To(BeTrue())
+			},
+			Entry("protobuf", "application/vnd.kubernetes.protobuf"),
+			Entry("malformed", "application/"),
+		)
+	})
+
+	DescribeTable("codecs round-trip Workspaces",
+		func(m marshal.Marshaler, u marshal.Unmarshaler) {
+			// given
+			w := restworkspacesv1alpha1.Workspace{
+				TypeMeta: metav1.TypeMeta{Kind: "Workspace", APIVersion: restworkspacesv1alpha1.GroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "foo",
+					Namespace:         "bar",
+					CreationTimestamp: metav1.NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)),
+					Labels:            map[string]string{"key": "value"},
+				},
+				Spec: restworkspacesv1alpha1.WorkspaceSpec{Visibility: restworkspacesv1alpha1.WorkspaceVisibilityCommunity},
+			}
+
+			// when
+			d, err := m.Marshal(&w)
+			Expect(err).NotTo(HaveOccurred())
+			o := restworkspacesv1alpha1.Workspace{}
+			err = u.Unmarshal(d, &o)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(o).To(Equal(w))
+		},
+		Entry("JSON", &marshal.JsonMarshaler{}, &marshal.JsonUnmarshaler{}),
+		Entry("YAML", &marshal.YamlMarshaler{}, &marshal.YamlUnmarshaler{}),
+		Entry("CBOR", &marshal.CborMarshaler{}, &marshal.CborUnmarshaler{}),
+	)
+})
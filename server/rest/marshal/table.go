@@ -0,0 +1,80 @@
+package marshal
+
+import (
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tableGroup is the API group of the Table kind
+const tableGroup string = "meta.k8s.io"
+
+// tableVersions are the supported versions of the Table kind
+var tableVersions = []string{"v1", "v1beta1"}
+
+// TableConvertor converts the objects it supports into a metav1.Table,
+// e.g. for rendering them with `kubectl get`.
+// It returns false for unsupported objects, e.g. Statuses, that are marshaled as they are.
+type TableConvertor func(r *http.Request, v any) (*metav1.Table, bool, error)
+
+// TableMarshaler marshals the objects supported by the TableConvertor as a metav1.Table,
+// and all the other ones with the wrapped Marshaler
+type TableMarshaler struct {
+	Marshaler
+
+	request   *http.Request
+	version   string
+	convertor TableConvertor
+}
+
+// NewTableMarshalerProvider builds a MarshalerProvider returning a TableMarshaler if the request accepts a Table
+// in the media type negotiated by next, e.g. its Accept header contains `application/json;as=Table;g=meta.k8s.io;v=v1`
+// as kubectl sends it, and the Marshaler built by next otherwise.
+func NewTableMarshalerProvider(next MarshalerProvider, convertor TableConvertor) MarshalerProvider {
+	return func(r *http.Request) (Marshaler, error) {
+		m, err := next(r)
+		if err != nil {
+			return nil, err
+		}
+
+		v, ok := acceptedTableVersion(r, m.ContentType())
+		if !ok {
+			return m, nil
+		}
+		return &TableMarshaler{
+			Marshaler: m,
+			request:   r,
+			version:   v,
+			convertor: convertor,
+		}, nil
+	}
+}
+
+// Marshal marshals v as a metav1.Table if the TableConvertor supports it, as it is otherwise
+func (m *TableMarshaler) Marshal(v any) ([]byte, error) {
+	t, ok, err := m.convertor(m.request, v)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return m.Marshaler.Marshal(v)
+	}
+
+	t.TypeMeta = metav1.TypeMeta{Kind: "Table", APIVersion: tableGroup + "/" + m.version}
+	return m.Marshaler.Marshal(t)
+}
+
+// acceptedTableVersion returns the first version of the Table kind accepted by the request in the given media type, if any
+func acceptedTableVersion(r *http.Request, mediaType string) (string, bool) {
+	for _, a := range parseAccept(r) {
+		if a.q == 0 || a.mediaType != mediaType || a.params["as"] != "Table" || a.params["g"] != tableGroup {
+			continue
+		}
+		for _, v := range tableVersions {
+			if a.params["v"] == v {
+				return v, true
+			}
+		}
+	}
+	return "", false
+}
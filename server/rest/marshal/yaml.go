@@ -0,0 +1,27 @@
+package marshal
+
+import "sigs.k8s.io/yaml"
+
+const ContentTypeYaml string = "application/yaml"
+
+// YamlMarshaler marshals values as YAML honoring their JSON tags and custom JSON marshaling
+type YamlMarshaler struct{}
+
+func (m *YamlMarshaler) Marshal(v any) ([]byte, error) {
+	return yaml.Marshal(v)
+}
+
+func (m *YamlMarshaler) ContentType() string {
+	return ContentTypeYaml
+}
+
+// YamlUnmarshaler unmarshals YAML documents honoring the JSON tags and custom JSON unmarshaling of values
+type YamlUnmarshaler struct{}
+
+func (u *YamlUnmarshaler) Unmarshal(d []byte, r any) error {
+	return yaml.Unmarshal(d, r)
+}
+
+func (u *YamlUnmarshaler) ContentType() string {
+	return ContentTypeYaml
+}
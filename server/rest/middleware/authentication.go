@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/auth"
+	"github.com/konflux-workspaces/workspaces/server/rest/status"
+)
+
+var _ http.Handler = &AuthenticationMiddleware{}
+
+// TokenAuthenticator authenticates users from bearer tokens
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*auth.Identity, error)
+}
+
+// TrustedProxyChecker checks if a request has been sent by a trusted proxy
+type TrustedProxyChecker interface {
+	IsTrusted(r *http.Request) bool
+}
+
+// AuthenticationMiddleware identifies the user sending the request and adds their identity in the request context.
+// Requests sent by trusted proxies are identified from their headers,
+// all the other ones from their bearer token.
+// Requests carrying no identity are forwarded unauthenticated.
+type AuthenticationMiddleware struct {
+	tokenAuthenticator TokenAuthenticator
+	trustedProxies     TrustedProxyChecker
+	headers            http.Handler
+	next               http.Handler
+}
+
+// NewAuthenticationMiddleware builds a new AuthenticationMiddleware.
+// `tokenAuthenticator` and `trustedProxies` can be nil to disable the respective authentication mode,
+// `headers` maps the headers set by trusted proxies to the context keys their value is stored at.
+func NewAuthenticationMiddleware(
+	next http.Handler,
+	tokenAuthenticator TokenAuthenticator,
+	trustedProxies TrustedProxyChecker,
+	headers map[string]interface{},
+) *AuthenticationMiddleware {
+	return &AuthenticationMiddleware{
+		tokenAuthenticator: tokenAuthenticator,
+		trustedProxies:     trustedProxies,
+		headers:            NewHeaderInfoMiddleware(next, headers),
+		next:               next,
+	}
+}
+
+func (m *AuthenticationMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// trusted proxies already authenticated the user
+	if m.trustedProxies != nil && m.trustedProxies.IsTrusted(r) {
+		m.headers.ServeHTTP(w, r)
+		return
+	}
+
+	t, ok := bearerToken(r)
+	if !ok || m.tokenAuthenticator == nil {
+		m.next.ServeHTTP(w, r)
+		return
+	}
+
+	i, err := m.tokenAuthenticator.Authenticate(r.Context(), t)
+	if err != nil {
+		log.FromContext(r.Context()).Debug("error authenticating token", "error", err)
+		if err := status.Write(w, nil, kerrors.NewUnauthorized("invalid bearer token")); err != nil {
+			log.FromContext(r.Context()).Error("error writing response", "error", err)
+		}
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), ccontext.UserSubKey, i.Sub)
+	if i.Issuer != "" {
+		ctx = context.WithValue(ctx, ccontext.UserIssuerKey, i.Issuer)
+	}
+	if i.Email != "" {
+		ctx = context.WithValue(ctx, ccontext.UserEmailKey, i.Email)
+	}
+	if len(i.Groups) > 0 {
+		ctx = context.WithValue(ctx, ccontext.UserGroupsKey, i.Groups)
+	}
+	m.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// bearerToken returns the token in the request's Authorization header
+func bearerToken(r *http.Request) (string, bool) {
+	s, t, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(s, "Bearer") || strings.TrimSpace(t) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(t), true
+}
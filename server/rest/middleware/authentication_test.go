@@ -0,0 +1,138 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/rest/auth"
+	"github.com/konflux-workspaces/workspaces/server/rest/middleware"
+)
+
+// fakeTokenAuthenticator accepts only the token "valid-token"
+type fakeTokenAuthenticator struct{}
+
+func (fakeTokenAuthenticator) Authenticate(_ context.Context, token string) (*auth.Identity, error) {
+	if token != "valid-token" {
+		return nil, errors.New("invalid token")
+	}
+	return &auth.Identity{Issuer: "https://issuer", Sub: "token-sub", Email: "token@email.com", Groups: []string{"group"}}, nil
+}
+
+var _ = Describe("Authentication", func() {
+	var (
+		ctx context.Context
+
+		// next handler's received context
+		nextCtx context.Context
+		next    http.Handler
+
+		// http
+		w *httptest.ResponseRecorder
+		r *http.Request
+	)
+
+	headers := map[string]interface{}{"X-Subject": ccontext.UserSubKey}
+	cidrs, _ := auth.ParseCIDRs("127.0.0.1/32")
+	trustedProxies := &auth.TrustedProxies{CIDRs: cidrs}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		nextCtx = nil
+		next = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			nextCtx = r.Context()
+			w.WriteHeader(http.StatusOK)
+		})
+
+		w = httptest.NewRecorder()
+		r = httptest.NewRequest(methodGet, endpointWhatever, nil).WithContext(ctx)
+		r.RemoteAddr = "192.168.1.1:41234"
+	})
+
+	When("the request is sent by a trusted proxy", func() {
+		BeforeEach(func() {
+			r.RemoteAddr = "127.0.0.1:41234"
+			r.Header.Set("X-Subject", "header-sub")
+			r.Header.Set("Authorization", "Bearer invalid-token")
+		})
+
+		It("reads the identity from the headers", func() {
+			// when
+			middleware.NewAuthenticationMiddleware(next, fakeTokenAuthenticator{}, trustedProxies, headers).ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(nextCtx.Value(ccontext.UserSubKey)).To(Equal("header-sub"))
+		})
+	})
+
+	When("the request is not sent by a trusted proxy", func() {
+		BeforeEach(func() {
+			r.Header.Set("X-Subject", "header-sub")
+		})
+
+		It("ignores the identity in the headers", func() {
+			// when
+			middleware.NewAuthenticationMiddleware(next, fakeTokenAuthenticator{}, trustedProxies, headers).ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(nextCtx.Value(ccontext.UserSubKey)).To(BeNil())
+		})
+
+		It("reads the identity from a valid bearer token", func() {
+			// given
+			r.Header.Set("Authorization", "Bearer valid-token")
+
+			// when
+			middleware.NewAuthenticationMiddleware(next, fakeTokenAuthenticator{}, trustedProxies, headers).ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(nextCtx.Value(ccontext.UserSubKey)).To(Equal("token-sub"))
+			Expect(nextCtx.Value(ccontext.UserIssuerKey)).To(Equal("https://issuer"))
+			Expect(nextCtx.Value(ccontext.UserEmailKey)).To(Equal("token@email.com"))
+			Expect(nextCtx.Value(ccontext.UserGroupsKey)).To(Equal([]string{"group"}))
+		})
+
+		It("replies Unauthorized to invalid bearer tokens", func() {
+			// given
+			r.Header.Set("Authorization", "Bearer invalid-token")
+
+			// when
+			middleware.NewAuthenticationMiddleware(next, fakeTokenAuthenticator{}, trustedProxies, headers).ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusUnauthorized))
+			Expect(nextCtx).To(BeNil())
+		})
+
+		It("forwards requests without bearer token unauthenticated", func() {
+			// when
+			middleware.NewAuthenticationMiddleware(next, fakeTokenAuthenticator{}, trustedProxies, headers).ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(nextCtx.Value(ccontext.UserSubKey)).To(BeNil())
+		})
+	})
+
+	When("token authentication is disabled", func() {
+		It("ignores bearer tokens", func() {
+			// given
+			r.Header.Set("Authorization", "Bearer valid-token")
+
+			// when
+			middleware.NewAuthenticationMiddleware(next, nil, trustedProxies, headers).ServeHTTP(w, r)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(nextCtx.Value(ccontext.UserSubKey)).To(BeNil())
+		})
+	})
+})
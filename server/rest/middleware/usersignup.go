@@ -2,13 +2,38 @@ package middleware
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/status"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+)
+
+const (
+	// StatusReasonUserDeactivated is the reason of the Status returned to deactivated users
+	StatusReasonUserDeactivated metav1.StatusReason = "UserDeactivated"
+	// StatusReasonUserBanned is the reason of the Status returned to banned users
+	StatusReasonUserBanned metav1.StatusReason = "UserBanned"
+	// StatusReasonUserNotSignedUp is the reason of the Status returned to users that have not signed up
+	StatusReasonUserNotSignedUp metav1.StatusReason = "UserNotSignedUp"
+	// StatusReasonUserPendingApproval is the reason of the Status returned to users waiting for approval
+	StatusReasonUserPendingApproval metav1.StatusReason = "UserPendingApproval"
+
+	// userSignupIdentityIndexKey is the key of the cache's UserSignup indexer on the JWT's issuer and Sub claims
+	userSignupIdentityIndexKey string = "identity"
+	// bannedUserEmailIndexKey is the key of the cache's BannedUser indexer on the lowercase banned email
+	bannedUserEmailIndexKey string = "email"
 )
 
 type UserSignupMiddleware struct {
@@ -33,48 +58,121 @@ func (m *UserSignupMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// retrieve UserSignup for given sub
-	us, err := m.lookupUserSignup(r.Context(), u)
+	// retrieve User's JWT issuer, if known
+	iss, _ := r.Context().Value(ccontext.UserIssuerKey).(string)
+
+	// retrieve UserSignup for given issuer and sub
+	us, err := m.lookupUserSignup(r.Context(), iss, u)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		log.FromContext(r.Context()).Error("error retrieving UserSignup", "error", err)
+		m.replyError(w, r, err)
 		return
 	}
 
 	if us == nil {
-		w.WriteHeader(http.StatusForbidden)
-		if _, err := w.Write([]byte("user needs to sign in")); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		m.replyForbidden(w, r, StatusReasonUserNotSignedUp)
+		return
+	}
+
+	// user is banned or deactivated
+	if reason, ok := userSignupForbiddenReason(us); ok {
+		m.replyForbidden(w, r, reason)
 		return
 	}
 
 	// user is waiting for approval
 	if us.Status.CompliantUsername == "" {
-		w.WriteHeader(http.StatusForbidden)
-		if _, err := w.Write([]byte("user is waiting for approval")); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		m.replyForbidden(w, r, StatusReasonUserPendingApproval)
 		return
 	}
 
-	// TODO(@filariow): check if user is deactivated or banned
+	// user's email is banned
+	banned, err := m.isBanned(r.Context(), us.Spec.IdentityClaims.Email)
+	if err != nil {
+		log.FromContext(r.Context()).Error("error retrieving BannedUsers", "error", err)
+		m.replyError(w, r, err)
+		return
+	}
+	if banned {
+		m.replyForbidden(w, r, StatusReasonUserBanned)
+		return
+	}
 
 	// inject the userSignup.ComplaintUsername
 	ctx := context.WithValue(r.Context(), ccontext.UserSignupComplaintNameKey, us.Status.CompliantUsername)
 	m.next.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (m *UserSignupMiddleware) lookupUserSignup(ctx context.Context, sub string) (*toolchainv1alpha1.UserSignup, error) {
-	uu := toolchainv1alpha1.UserSignupList{}
-	if err := m.cache.List(ctx, &uu); err != nil {
+// lookupUserSignup retrieves the UserSignup of the user identified by issuer and sub.
+// UserSignups lacking the issuer match the users of the default issuer.
+func (m *UserSignupMiddleware) lookupUserSignup(ctx context.Context, issuer, sub string) (*toolchainv1alpha1.UserSignup, error) {
+	wc := workspacesv1alpha1.WorkspacesConfig{}
+	if err := m.cache.Get(ctx, client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, &wc); err != nil && !kerrors.IsNotFound(err) {
 		return nil, err
 	}
 
-	for _, u := range uu.Items {
-		if u.Spec.IdentityClaims.Sub == sub {
-			return &u, nil
+	for _, k := range wc.Spec.Identity.IdentityKeys(issuer, sub) {
+		uu := toolchainv1alpha1.UserSignupList{}
+		if err := m.cache.List(ctx, &uu, client.MatchingFields{userSignupIdentityIndexKey: k}); err != nil {
+			return nil, err
+		}
+		if len(uu.Items) > 0 {
+			return &uu.Items[0], nil
 		}
 	}
-
 	return nil, nil
 }
+
+// replyForbidden replies with a Forbidden Status carrying the given reason
+func (m *UserSignupMiddleware) replyForbidden(w http.ResponseWriter, r *http.Request, reason metav1.StatusReason) {
+	m.replyError(w, r, &kerrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusForbidden,
+		Reason:  reason,
+		Message: fmt.Sprintf("forbidden: %s", forbiddenMessages[reason]),
+	}})
+}
+
+// replyError replies with the Status describing the error
+func (m *UserSignupMiddleware) replyError(w http.ResponseWriter, r *http.Request, err error) {
+	if err := status.Write(w, nil, err); err != nil {
+		log.FromContext(r.Context()).Error("error writing response", "error", err)
+	}
+}
+
+var forbiddenMessages = map[metav1.StatusReason]string{
+	StatusReasonUserDeactivated:     "user is deactivated",
+	StatusReasonUserBanned:          "user is banned",
+	StatusReasonUserNotSignedUp:     "user needs to sign in",
+	StatusReasonUserPendingApproval: "user is waiting for approval",
+}
+
+// userSignupForbiddenReason returns the reason why the user is not allowed to access the API,
+// if the UserSignup has been banned or deactivated
+func userSignupForbiddenReason(us *toolchainv1alpha1.UserSignup) (metav1.StatusReason, bool) {
+	state := us.GetLabels()[toolchainv1alpha1.UserSignupStateLabelKey]
+	switch {
+	case slices.Contains(us.Spec.States, toolchainv1alpha1.UserSignupStateBanned),
+		state == toolchainv1alpha1.UserSignupStateLabelValueBanned:
+		return StatusReasonUserBanned, true
+	case slices.Contains(us.Spec.States, toolchainv1alpha1.UserSignupStateDeactivated),
+		state == toolchainv1alpha1.UserSignupStateLabelValueDeactivated:
+		return StatusReasonUserDeactivated, true
+	default:
+		return "", false
+	}
+}
+
+// isBanned returns true if a BannedUser exists for the given email.
+// Emails are compared case-insensitively.
+func (m *UserSignupMiddleware) isBanned(ctx context.Context, email string) (bool, error) {
+	if email == "" {
+		return false, nil
+	}
+
+	bb := toolchainv1alpha1.BannedUserList{}
+	if err := m.cache.List(ctx, &bb, client.MatchingFields{bannedUserEmailIndexKey: strings.ToLower(email)}); err != nil {
+		return false, err
+	}
+	return len(bb.Items) > 0, nil
+}
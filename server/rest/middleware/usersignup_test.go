@@ -3,18 +3,22 @@ package middleware_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
 
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
 	"github.com/konflux-workspaces/workspaces/server/rest/middleware"
@@ -26,7 +30,8 @@ const (
 
 	methodGet = "GET"
 
-	testUserSub = "test-user-sub"
+	testUserSub   = "test-user-sub"
+	testUserEmail = "test-user@example.com"
 )
 
 var _ = Describe("Usersignup", func() {
@@ -95,11 +100,16 @@ var _ = Describe("Usersignup", func() {
 	When("sub HTTP header is present", func() {
 		BeforeEach(func() {
 			ctx = context.WithValue(context.TODO(), ccontext.UserSubKey, testUserSub)
+			c.EXPECT().
+				Get(gomock.Any(), client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, gomock.Any()).
+				AnyTimes()
 		})
 
 		It("requires an usersignup", func() {
 			// set expectations
-			c.EXPECT().List(gomock.Any(), gomock.Any()).Times(1)
+			c.EXPECT().
+				List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey("", testUserSub)}).
+				Times(1)
 			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(0)
 
 			// when
@@ -107,13 +117,13 @@ var _ = Describe("Usersignup", func() {
 
 			// then
 			Expect(w.Code).To(Equal(http.StatusForbidden))
-			Expect(w.Body.String()).To(Equal("user needs to sign in"))
+			expectStatusReason(w, middleware.StatusReasonUserNotSignedUp)
 		})
 
 		It("requires the usersignup fetch to complete successfully", func() {
 			// set expectations
 			c.EXPECT().
-				List(gomock.Any(), gomock.Any()).
+				List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey("", testUserSub)}).
 				Times(1).
 				Return(fmt.Errorf("error"))
 			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(0)
@@ -123,6 +133,7 @@ var _ = Describe("Usersignup", func() {
 
 			// then
 			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			expectStatusReason(w, metav1.StatusReasonInternalError)
 		})
 
 		It("requires the usersignup to be approved", func() {
@@ -162,39 +173,15 @@ var _ = Describe("Usersignup", func() {
 
 			// then
 			Expect(w.Code).To(Equal(http.StatusForbidden))
-			Expect(w.Body.String()).To(Equal("user is waiting for approval"))
+			expectStatusReason(w, middleware.StatusReasonUserPendingApproval)
 		})
 
 		It("succeeds when ComplaintName is set", func() {
 			// set expectations
 			c.EXPECT().
 				List(gomock.Any(), gomock.Any(), gomock.Any()).
-				Times(1).
-				DoAndReturn(
-					func(_ context.Context, list *toolchainv1alpha1.UserSignupList, _ ...client.ListOption) error {
-						*list = toolchainv1alpha1.UserSignupList{
-							Items: []toolchainv1alpha1.UserSignup{
-								{
-									ObjectMeta: metav1.ObjectMeta{
-										Name:      "test-user",
-										Namespace: "toolchain-host-operator",
-									},
-									Spec: toolchainv1alpha1.UserSignupSpec{
-										IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
-											PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
-												Sub: testUserSub,
-											},
-										},
-									},
-									Status: toolchainv1alpha1.UserSignupStatus{
-										CompliantUsername: "test-user",
-									},
-								},
-							},
-						}
-						return nil
-					},
-				)
+				Times(2).
+				DoAndReturn(listReturning([]toolchainv1alpha1.UserSignup{approvedUserSignup()}, nil))
 			h.EXPECT().
 				ServeHTTP(gomock.Any(), gomock.Any()).
 				Times(1).
@@ -217,5 +204,264 @@ var _ = Describe("Usersignup", func() {
 			Expect(w.Code).To(Equal(999))
 			Expect(w.Body.String()).To(BeZero())
 		})
+
+		DescribeTable("UserSignup state transitions",
+			func(mutate func(*toolchainv1alpha1.UserSignup), banned []toolchainv1alpha1.BannedUser, expectedReason metav1.StatusReason) {
+				// given
+				us := approvedUserSignup()
+				mutate(&us)
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), gomock.Any()).
+					MinTimes(1).
+					DoAndReturn(listReturning([]toolchainv1alpha1.UserSignup{us}, banned))
+
+				if expectedReason == "" {
+					h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(1)
+				} else {
+					h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(0)
+				}
+
+				// when
+				m.ServeHTTP(w, r.WithContext(ctx))
+
+				// then
+				if expectedReason == "" {
+					Expect(w.Code).To(Equal(http.StatusOK))
+					return
+				}
+				Expect(w.Code).To(Equal(http.StatusForbidden))
+				s := metav1.Status{}
+				Expect(json.Unmarshal(w.Body.Bytes(), &s)).To(Succeed())
+				Expect(s.Kind).To(Equal("Status"))
+				Expect(s.Code).To(Equal(int32(http.StatusForbidden)))
+				Expect(s.Reason).To(Equal(expectedReason))
+			},
+			Entry("approved", func(*toolchainv1alpha1.UserSignup) {}, nil, metav1.StatusReason("")),
+			Entry("approved, with another email banned", func(*toolchainv1alpha1.UserSignup) {},
+				[]toolchainv1alpha1.BannedUser{bannedUser("another@example.com")}, metav1.StatusReason("")),
+			Entry("deactivating", func(us *toolchainv1alpha1.UserSignup) {
+				us.Spec.States = append(us.Spec.States, toolchainv1alpha1.UserSignupStateDeactivating)
+			}, nil, metav1.StatusReason("")),
+			Entry("deactivated", func(us *toolchainv1alpha1.UserSignup) {
+				us.Spec.States = []toolchainv1alpha1.UserSignupState{toolchainv1alpha1.UserSignupStateDeactivated}
+			}, nil, middleware.StatusReasonUserDeactivated),
+			Entry("deactivated, by state label", func(us *toolchainv1alpha1.UserSignup) {
+				us.Labels = map[string]string{toolchainv1alpha1.UserSignupStateLabelKey: toolchainv1alpha1.UserSignupStateLabelValueDeactivated}
+			}, nil, middleware.StatusReasonUserDeactivated),
+			Entry("deactivated, not approved anymore", func(us *toolchainv1alpha1.UserSignup) {
+				us.Spec.States = []toolchainv1alpha1.UserSignupState{toolchainv1alpha1.UserSignupStateDeactivated}
+				us.Status.CompliantUsername = ""
+			}, nil, middleware.StatusReasonUserDeactivated),
+			Entry("reactivated", func(us *toolchainv1alpha1.UserSignup) {
+				us.Labels = map[string]string{toolchainv1alpha1.UserSignupStateLabelKey: toolchainv1alpha1.UserSignupStateLabelValueApproved}
+			}, nil, metav1.StatusReason("")),
+			Entry("banned", func(us *toolchainv1alpha1.UserSignup) {
+				us.Spec.States = []toolchainv1alpha1.UserSignupState{toolchainv1alpha1.UserSignupStateBanned}
+			}, nil, middleware.StatusReasonUserBanned),
+			Entry("banned, by state label", func(us *toolchainv1alpha1.UserSignup) {
+				us.Labels = map[string]string{toolchainv1alpha1.UserSignupStateLabelKey: toolchainv1alpha1.UserSignupStateLabelValueBanned}
+			}, nil, middleware.StatusReasonUserBanned),
+			Entry("deactivated and banned", func(us *toolchainv1alpha1.UserSignup) {
+				us.Spec.States = []toolchainv1alpha1.UserSignupState{toolchainv1alpha1.UserSignupStateDeactivated, toolchainv1alpha1.UserSignupStateBanned}
+			}, nil, middleware.StatusReasonUserBanned),
+			Entry("approved, with a BannedUser for the email", func(*toolchainv1alpha1.UserSignup) {},
+				[]toolchainv1alpha1.BannedUser{bannedUser(testUserEmail)}, middleware.StatusReasonUserBanned),
+			Entry("approved, with a BannedUser for the email in a different case", func(*toolchainv1alpha1.UserSignup) {},
+				[]toolchainv1alpha1.BannedUser{bannedUser("Test-User@Example.com")}, middleware.StatusReasonUserBanned),
+		)
+
+		It("requires the BannedUsers fetch to complete successfully", func() {
+			// set expectations
+			c.EXPECT().
+				List(gomock.Any(), gomock.Any(), gomock.Any()).
+				Times(2).
+				DoAndReturn(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					if _, ok := list.(*toolchainv1alpha1.BannedUserList); ok {
+						return fmt.Errorf("error")
+					}
+					return listReturning([]toolchainv1alpha1.UserSignup{approvedUserSignup()}, nil)(ctx, list, opts...)
+				})
+			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(0)
+
+			// when
+			m.ServeHTTP(w, r.WithContext(ctx))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			expectStatusReason(w, metav1.StatusReasonInternalError)
+		})
+	})
+
+	When("issuer and sub are present", func() {
+		testUserIssuer := "https://test-issuer"
+
+		BeforeEach(func() {
+			ctx = context.WithValue(context.TODO(), ccontext.UserSubKey, testUserSub)
+			ctx = context.WithValue(ctx, ccontext.UserIssuerKey, testUserIssuer)
+		})
+
+		It("looks up the usersignup by issuer and sub", func() {
+			// set expectations
+			c.EXPECT().
+				Get(gomock.Any(), client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, gomock.Any()).
+				Times(1)
+			gomock.InOrder(
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey(testUserIssuer, testUserSub)}).
+					Times(1),
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey("", testUserSub)}).
+					Times(1),
+			)
+			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(0)
+
+			// when
+			m.ServeHTTP(w, r.WithContext(ctx))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			expectStatusReason(w, middleware.StatusReasonUserNotSignedUp)
+		})
+
+		It("falls back to usersignups lacking the issuer if the issuer is the default one", func() {
+			// set expectations
+			c.EXPECT().
+				Get(gomock.Any(), client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					obj.(*workspacesv1alpha1.WorkspacesConfig).Spec.Identity.DefaultIssuer = testUserIssuer
+					return nil
+				})
+			gomock.InOrder(
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey(testUserIssuer, testUserSub)}).
+					Times(1),
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey("", testUserSub)}).
+					Times(1).
+					DoAndReturn(listReturning([]toolchainv1alpha1.UserSignup{approvedUserSignup()}, nil)),
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"email": testUserEmail}).
+					Times(1),
+			)
+			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(1)
+
+			// when
+			m.ServeHTTP(w, r.WithContext(ctx))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+
+		It("falls back to usersignups lacking the issuer if no default issuer is configured", func() {
+			// set expectations
+			c.EXPECT().
+				Get(gomock.Any(), client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, gomock.Any()).
+				Times(1)
+			gomock.InOrder(
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey(testUserIssuer, testUserSub)}).
+					Times(1),
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey("", testUserSub)}).
+					Times(1).
+					DoAndReturn(listReturning([]toolchainv1alpha1.UserSignup{approvedUserSignup()}, nil)),
+				c.EXPECT().
+					List(gomock.Any(), gomock.Any(), client.MatchingFields{"email": testUserEmail}).
+					Times(1),
+			)
+			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(1)
+
+			// when
+			m.ServeHTTP(w, r.WithContext(ctx))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+
+		It("does not fall back to usersignups lacking the issuer if the issuer is not the default one", func() {
+			// set expectations
+			c.EXPECT().
+				Get(gomock.Any(), client.ObjectKey{Name: workspacesv1alpha1.WorkspacesConfigName}, gomock.Any()).
+				Times(1).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					obj.(*workspacesv1alpha1.WorkspacesConfig).Spec.Identity.DefaultIssuer = "https://default-issuer"
+					return nil
+				})
+			c.EXPECT().
+				List(gomock.Any(), gomock.Any(), client.MatchingFields{"identity": workspacesv1alpha1.IdentityKey(testUserIssuer, testUserSub)}).
+				Times(1)
+			h.EXPECT().ServeHTTP(gomock.Any(), gomock.Any()).Times(0)
+
+			// when
+			m.ServeHTTP(w, r.WithContext(ctx))
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			expectStatusReason(w, middleware.StatusReasonUserNotSignedUp)
+		})
 	})
 })
+
+func approvedUserSignup() toolchainv1alpha1.UserSignup {
+	return toolchainv1alpha1.UserSignup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-user",
+			Namespace: "toolchain-host-operator",
+		},
+		Spec: toolchainv1alpha1.UserSignupSpec{
+			States: []toolchainv1alpha1.UserSignupState{toolchainv1alpha1.UserSignupStateApproved},
+			IdentityClaims: toolchainv1alpha1.IdentityClaimsEmbedded{
+				PropagatedClaims: toolchainv1alpha1.PropagatedClaims{
+					Sub:   testUserSub,
+					Email: testUserEmail,
+				},
+			},
+		},
+		Status: toolchainv1alpha1.UserSignupStatus{
+			CompliantUsername: "test-user",
+		},
+	}
+}
+
+func bannedUser(email string) toolchainv1alpha1.BannedUser {
+	return toolchainv1alpha1.BannedUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "banned-" + email,
+			Namespace: "toolchain-host-operator",
+		},
+		Spec: toolchainv1alpha1.BannedUserSpec{Email: email},
+	}
+}
+
+// expectStatusReason checks that the response's body is a Status with the given reason
+func expectStatusReason(w *httptest.ResponseRecorder, reason metav1.StatusReason) {
+	s := metav1.Status{}
+	ExpectWithOffset(1, json.Unmarshal(w.Body.Bytes(), &s)).To(Succeed())
+	ExpectWithOffset(1, s.Kind).To(Equal("Status"))
+	ExpectWithOffset(1, s.Code).To(BeEquivalentTo(w.Code))
+	ExpectWithOffset(1, s.Reason).To(Equal(reason))
+}
+
+// listReturning fills UserSignupLists with the given items, and BannedUserLists
+// with the given items matching the lowercase email the cache indexes them by
+func listReturning(uu []toolchainv1alpha1.UserSignup, bb []toolchainv1alpha1.BannedUser) func(context.Context, client.ObjectList, ...client.ListOption) error {
+	return func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		switch l := list.(type) {
+		case *toolchainv1alpha1.UserSignupList:
+			l.Items = uu
+		case *toolchainv1alpha1.BannedUserList:
+			listOpts := client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			l.Items = nil
+			for _, b := range bb {
+				if listOpts.FieldSelector == nil || listOpts.FieldSelector.Matches(fields.Set{"email": strings.ToLower(b.Spec.Email)}) {
+					l.Items = append(l.Items, b)
+				}
+			}
+		default:
+			Fail(fmt.Sprintf("unexpected list type %T", list))
+		}
+		return nil
+	}
+}
@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 
 	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/discovery"
 	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
 	"github.com/konflux-workspaces/workspaces/server/rest/middleware"
+	"github.com/konflux-workspaces/workspaces/server/rest/status"
 	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
 )
 
 const (
@@ -19,37 +27,64 @@ const (
 	NamespacedWorkspacesPrefix string = `/apis/workspaces.konflux-ci.dev/v1alpha1/namespaces/{namespace}/workspaces`
 )
 
+// AuthenticationOptions configures how the users sending the requests are authenticated
+type AuthenticationOptions struct {
+	// TokenAuthenticator authenticates users from their bearer token, if not nil
+	TokenAuthenticator middleware.TokenAuthenticator
+	// TrustedProxies identifies the proxies users are authenticated by, if not nil.
+	// Requests sent by trusted proxies carry the user's sub in the X-Subject header.
+	TrustedProxies middleware.TrustedProxyChecker
+}
+
 func New(
 	logger *slog.Logger,
 	addr string,
+	authOpts AuthenticationOptions,
 	cache cache.Cache,
 	readHandle workspace.ReadWorkspaceQueryHandlerFunc,
 	listHandle workspace.ListWorkspaceQueryHandlerFunc,
 	createHandle workspace.CreateWorkspaceCommandHandlerFunc,
 	updateHandle workspace.UpdateWorkspaceCommandHandlerFunc,
 	patchHandle workspace.PatchWorkspaceCommandHandlerFunc,
+	deleteHandle workspace.DeleteWorkspaceCommandHandlerFunc,
+	listMembersHandle workspace.ListWorkspaceMembersQueryHandlerFunc,
+	setMemberHandle workspace.SetWorkspaceMemberCommandHandlerFunc,
+	revokeMemberHandle workspace.RevokeWorkspaceMemberCommandHandlerFunc,
+	transferHandle workspace.TransferWorkspaceCommandHandlerFunc,
+	watchHandle workspace.WatchWorkspaceQueryHandlerFunc,
 ) *http.Server {
 	return &http.Server{
 		Addr:              addr,
-		Handler:           buildServerHandler(logger, cache, readHandle, listHandle, createHandle, updateHandle, patchHandle),
+		Handler:           buildServerHandler(logger, authOpts, cache, readHandle, listHandle, createHandle, updateHandle, patchHandle, deleteHandle, listMembersHandle, setMemberHandle, revokeMemberHandle, transferHandle, watchHandle),
 		ReadHeaderTimeout: 3 * time.Second,
 	}
 }
 
 func buildServerHandler(
 	logger *slog.Logger,
+	authOpts AuthenticationOptions,
 	cache cache.Cache,
 	readHandle workspace.ReadWorkspaceQueryHandlerFunc,
 	listHandle workspace.ListWorkspaceQueryHandlerFunc,
 	createHandle workspace.CreateWorkspaceCommandHandlerFunc,
 	updateHandle workspace.UpdateWorkspaceCommandHandlerFunc,
 	patchHandle workspace.PatchWorkspaceCommandHandlerFunc,
+	deleteHandle workspace.DeleteWorkspaceCommandHandlerFunc,
+	listMembersHandle workspace.ListWorkspaceMembersQueryHandlerFunc,
+	setMemberHandle workspace.SetWorkspaceMemberCommandHandlerFunc,
+	revokeMemberHandle workspace.RevokeWorkspaceMemberCommandHandlerFunc,
+	transferHandle workspace.TransferWorkspaceCommandHandlerFunc,
+	watchHandle workspace.WatchWorkspaceQueryHandlerFunc,
 ) http.Handler {
 	mux := http.NewServeMux()
 	addHealthz(mux)
-	addWorkspaces(mux, cache, readHandle, listHandle, createHandle, updateHandle, patchHandle)
-	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
+	addDiscovery(mux)
+	addWorkspaces(mux, authOpts, cache, readHandle, listHandle, createHandle, updateHandle, patchHandle, deleteHandle, listMembersHandle, setMemberHandle, revokeMemberHandle, transferHandle, watchHandle)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		err := kerrors.NewGenericServerResponse(http.StatusNotFound, r.Method, schema.GroupResource{}, "", "", 0, false)
+		if err := status.Write(w, nil, err); err != nil {
+			log.FromContext(r.Context()).Error("error writing response", "error", err)
+		}
 	})
 
 	if logger == nil {
@@ -63,38 +98,54 @@ func buildServerHandler(
 
 func addWorkspaces(
 	mux *http.ServeMux,
+	authOpts AuthenticationOptions,
 	cache cache.Cache,
 	readHandle workspace.ReadWorkspaceQueryHandlerFunc,
 	listHandle workspace.ListWorkspaceQueryHandlerFunc,
-	_ workspace.CreateWorkspaceCommandHandlerFunc,
+	createHandle workspace.CreateWorkspaceCommandHandlerFunc,
 	updateHandle workspace.UpdateWorkspaceCommandHandlerFunc,
 	patchHandle workspace.PatchWorkspaceCommandHandlerFunc,
+	deleteHandle workspace.DeleteWorkspaceCommandHandlerFunc,
+	listMembersHandle workspace.ListWorkspaceMembersQueryHandlerFunc,
+	setMemberHandle workspace.SetWorkspaceMemberCommandHandlerFunc,
+	revokeMemberHandle workspace.RevokeWorkspaceMemberCommandHandlerFunc,
+	transferHandle workspace.TransferWorkspaceCommandHandlerFunc,
+	watchHandle workspace.WatchWorkspaceQueryHandlerFunc,
 ) {
+	// Watch
+	wh := workspace.NewWatchWorkspaceHandler(
+		workspace.MapWatchWorkspaceHttp,
+		watchHandle,
+		workspace.WatchMarshalerProvider,
+	)
+
 	// Read
 	mux.Handle(fmt.Sprintf("GET %s/{name}", NamespacedWorkspacesPrefix),
-		withAuthHeaderInfo(
+		withAuthentication(authOpts,
 			withUserSignupAuth(cache,
-				workspace.NewReadWorkspaceHandler(
-					workspace.MapReadWorkspaceHttp,
-					readHandle,
-					marshal.DefaultMarshalerProvider,
-				))))
+				withWatch(wh,
+					workspace.NewReadWorkspaceHandler(
+						workspace.MapReadWorkspaceHttp,
+						readHandle,
+						workspace.TableMarshalerProvider,
+					)))))
 
 	// List
-	lh := withAuthHeaderInfo(
+	lh := withAuthentication(authOpts,
 		withUserSignupAuth(cache,
-			workspace.NewListWorkspaceHandler(
-				workspace.MapListWorkspaceHttp,
-				listHandle,
-				marshal.DefaultMarshalerProvider,
-			),
-		))
+			withWatch(wh,
+				workspace.NewListWorkspaceHandler(
+					workspace.MapListWorkspaceHttp,
+					listHandle,
+					workspace.TableMarshalerProvider,
+				),
+			)))
 	mux.Handle(fmt.Sprintf("GET %s", WorkspacesPrefix), lh)
 	mux.Handle(fmt.Sprintf("GET %s", NamespacedWorkspacesPrefix), lh)
 
 	// Update
 	mux.Handle(fmt.Sprintf("PUT %s/{name}", NamespacedWorkspacesPrefix),
-		withAuthHeaderInfo(
+		withAuthentication(authOpts,
 			withUserSignupAuth(cache,
 				workspace.NewUpdateWorkspaceHandler(
 					workspace.MapPutWorkspaceHttp,
@@ -105,7 +156,7 @@ func addWorkspaces(
 
 	// Patch
 	mux.Handle(fmt.Sprintf("PATCH %s/{name}", NamespacedWorkspacesPrefix),
-		withAuthHeaderInfo(
+		withAuthentication(authOpts,
 			withUserSignupAuth(cache,
 				workspace.NewPatchWorkspaceHandler(
 					workspace.MapPatchWorkspaceHttp,
@@ -114,20 +165,69 @@ func addWorkspaces(
 				))))
 
 	// Create
-	// mux.Handle(fmt.Sprintf("POST %s", NamespacedWorkspacesPrefix),
-	// 	withAuthHeaderInfo(
-	// 		withUserSignupAuth(cache, true,
-	// 			workspace.NewPostWorkspaceHandler(
-	// 				workspace.MapPostWorkspaceHttp,
-	// 				postHandle,
-	// 				marshal.DefaultMarshalerProvider,
-	// 				marshal.DefaultUnmarshalerProvider,
-	// 			))))
+	mux.Handle(fmt.Sprintf("POST %s", NamespacedWorkspacesPrefix),
+		withAuthentication(authOpts,
+			withUserSignupAuth(cache,
+				workspace.NewPostWorkspaceHandler(
+					workspace.MapPostWorkspaceHttp,
+					createHandle,
+					marshal.DefaultMarshalerProvider,
+					marshal.DefaultUnmarshalerProvider,
+				))))
+
+	// Delete
+	mux.Handle(fmt.Sprintf("DELETE %s/{name}", NamespacedWorkspacesPrefix),
+		withAuthentication(authOpts,
+			withUserSignupAuth(cache,
+				workspace.NewDeleteWorkspaceHandler(
+					workspace.MapDeleteWorkspaceHttp,
+					deleteHandle,
+					marshal.DefaultMarshalerProvider,
+				))))
+
+	// Members
+	mux.Handle(fmt.Sprintf("GET %s/{name}/members", NamespacedWorkspacesPrefix),
+		withAuthentication(authOpts,
+			withUserSignupAuth(cache,
+				workspace.NewListWorkspaceMembersHandler(
+					workspace.MapListWorkspaceMembersHttp,
+					listMembersHandle,
+					marshal.DefaultMarshalerProvider,
+				))))
+	mux.Handle(fmt.Sprintf("PUT %s/{name}/members/{username}", NamespacedWorkspacesPrefix),
+		withAuthentication(authOpts,
+			withUserSignupAuth(cache,
+				workspace.NewPutWorkspaceMemberHandler(
+					workspace.MapPutWorkspaceMemberHttp,
+					setMemberHandle,
+					marshal.DefaultMarshalerProvider,
+					marshal.DefaultUnmarshalerProvider,
+				))))
+	mux.Handle(fmt.Sprintf("DELETE %s/{name}/members/{username}", NamespacedWorkspacesPrefix),
+		withAuthentication(authOpts,
+			withUserSignupAuth(cache,
+				workspace.NewDeleteWorkspaceMemberHandler(
+					workspace.MapDeleteWorkspaceMemberHttp,
+					revokeMemberHandle,
+					marshal.DefaultMarshalerProvider,
+				))))
+
+	// Transfer
+	mux.Handle(fmt.Sprintf("POST %s/{name}/transfer", NamespacedWorkspacesPrefix),
+		withAuthentication(authOpts,
+			withUserSignupAuth(cache,
+				workspace.NewPostWorkspaceTransferHandler(
+					workspace.MapPostWorkspaceTransferHttp,
+					transferHandle,
+					marshal.DefaultMarshalerProvider,
+					marshal.DefaultUnmarshalerProvider,
+				))))
 }
 
-func withAuthHeaderInfo(next http.Handler) http.Handler {
-	return middleware.NewHeaderInfoMiddleware(next, map[string]interface{}{
+func withAuthentication(opts AuthenticationOptions, next http.Handler) http.Handler {
+	return middleware.NewAuthenticationMiddleware(next, opts.TokenAuthenticator, opts.TrustedProxies, map[string]interface{}{
 		"X-Subject": ccontext.UserSubKey,
+		"X-Issuer":  ccontext.UserIssuerKey,
 	})
 }
 
@@ -135,6 +235,34 @@ func withUserSignupAuth(cache cache.Cache, next http.Handler) http.Handler {
 	return middleware.NewUserSignupMiddleware(next, cache)
 }
 
+// withWatch serves the request with the watch handler if the `watch` query parameter is set to true
+func withWatch(watch http.Handler, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, _ := strconv.ParseBool(r.URL.Query().Get("watch")); ok {
+			watch.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// addDiscovery serves the discovery and OpenAPI v3 documents Kubernetes clients use for discovering the API.
+// They do not disclose any user data, so they are served to unauthenticated users too.
+func addDiscovery(mux *http.ServeMux) {
+	d, err := discovery.NewDocuments()
+	if err != nil {
+		// the documents are built from the CustomResourceDefinition embedded at build time
+		panic(fmt.Sprintf("error building discovery documents: %v", err))
+	}
+
+	gv := restworkspacesv1alpha1.GroupVersion
+	mux.Handle("GET /apis", discovery.NewDocumentHandler(&d.APIGroupList, marshal.DefaultMarshalerProvider))
+	mux.Handle(fmt.Sprintf("GET /apis/%s", gv.Group), discovery.NewDocumentHandler(&d.APIGroup, marshal.DefaultMarshalerProvider))
+	mux.Handle(fmt.Sprintf("GET /apis/%s", gv.String()), discovery.NewDocumentHandler(&d.APIResourceList, marshal.DefaultMarshalerProvider))
+	mux.Handle(fmt.Sprintf("GET %s", discovery.OpenAPIPrefix), discovery.NewOpenAPIIndexHandler(d, marshal.DefaultMarshalerProvider))
+	mux.Handle(fmt.Sprintf("GET %s/apis/%s", discovery.OpenAPIPrefix, gv.String()), discovery.NewOpenAPIHandler(d))
+}
+
 func addHealthz(mux *http.ServeMux) {
 	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
 		if _, err := w.Write([]byte("alive")); err != nil {
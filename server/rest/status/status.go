@@ -0,0 +1,71 @@
+package status
+
+import (
+	"errors"
+	"net/http"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/konflux-workspaces/workspaces/server/core"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var workspacesGroupResource = restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource()
+
+// FromError translates an error into the Kubernetes Status describing it.
+//
+// Errors implementing kerrors.APIStatus are returned as they are,
+// core.ErrNotFound is translated into a NotFound status,
+// core.ErrUnauthenticated into an Unauthorized status,
+// field validation errors into an Invalid status.
+// Any other error is translated into an InternalError status that does not disclose its details.
+func FromError(err error) *metav1.Status {
+	s := fromError(err)
+	s.TypeMeta = metav1.TypeMeta{Kind: "Status", APIVersion: "v1"}
+	s.Status = metav1.StatusFailure
+	return &s
+}
+
+func fromError(err error) metav1.Status {
+	if s := kerrors.APIStatus(nil); errors.As(err, &s) {
+		return s.Status()
+	}
+
+	var fe *field.Error
+	switch {
+	case errors.Is(err, core.ErrNotFound):
+		return kerrors.NewNotFound(workspacesGroupResource, "").Status()
+	case errors.Is(err, core.ErrUnauthenticated):
+		return kerrors.NewUnauthorized(err.Error()).Status()
+	case errors.As(err, &fe):
+		return kerrors.NewInvalid(restworkspacesv1alpha1.GroupVersion.WithKind("Workspace").GroupKind(), "", field.ErrorList{fe}).Status()
+	default:
+		return kerrors.NewInternalError(errors.New("an error occurred processing the request")).Status()
+	}
+}
+
+// Write replies to the request with the status code of the error and
+// the Kubernetes Status describing it as body.
+// If the Status can not be marshaled, only the status code is written.
+func Write(w http.ResponseWriter, m marshal.Marshaler, err error) error {
+	s := FromError(err)
+	if m == nil {
+		m = marshal.DefaultMarshal
+	}
+
+	d, merr := m.Marshal(s)
+	if merr != nil {
+		w.WriteHeader(int(s.Code))
+		return merr
+	}
+
+	w.Header().Add(header.ContentType, m.ContentType())
+	w.WriteHeader(int(s.Code))
+	_, werr := w.Write(d)
+	return werr
+}
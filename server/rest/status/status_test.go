@@ -0,0 +1,103 @@
+package status_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/konflux-workspaces/workspaces/server/core"
+	"github.com/konflux-workspaces/workspaces/server/persistence/iwclient"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/status"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+type badMarshaler struct{}
+
+func (b *badMarshaler) ContentType() string {
+	return "application/json"
+}
+
+func (b *badMarshaler) Marshal(any) ([]byte, error) {
+	return nil, fmt.Errorf("unable to marshal input!")
+}
+
+var _ = Describe("Status", func() {
+	gr := restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource()
+
+	DescribeTable("translating errors",
+		func(err error, code int32, reason metav1.StatusReason) {
+			// when
+			s := status.FromError(err)
+
+			// then
+			Expect(s.Kind).To(Equal("Status"))
+			Expect(s.APIVersion).To(Equal("v1"))
+			Expect(s.Status).To(Equal(metav1.StatusFailure))
+			Expect(s.Code).To(Equal(code))
+			Expect(s.Reason).To(Equal(reason))
+		},
+		Entry("not found", core.ErrNotFound, int32(http.StatusNotFound), metav1.StatusReasonNotFound),
+		Entry("wrapped not found", fmt.Errorf("error reading: %w", core.ErrNotFound), int32(http.StatusNotFound), metav1.StatusReasonNotFound),
+		Entry("workspace not found", iwclient.ErrWorkspaceNotFound, int32(http.StatusNotFound), metav1.StatusReasonNotFound),
+		Entry("unauthorized access to workspace", iwclient.ErrUnauthorized, int32(http.StatusNotFound), metav1.StatusReasonNotFound),
+		Entry("unauthenticated", core.ErrUnauthenticated, int32(http.StatusUnauthorized), metav1.StatusReasonUnauthorized),
+		Entry("forbidden", kerrors.NewForbidden(gr, "foo", fmt.Errorf("forbidden")), int32(http.StatusForbidden), metav1.StatusReasonForbidden),
+		Entry("conflict", kerrors.NewConflict(gr, "foo", fmt.Errorf("conflict")), int32(http.StatusConflict), metav1.StatusReasonConflict),
+		Entry("already exists", kerrors.NewAlreadyExists(gr, "foo"), int32(http.StatusConflict), metav1.StatusReasonAlreadyExists),
+		Entry("bad request", kerrors.NewBadRequest("bad"), int32(http.StatusBadRequest), metav1.StatusReasonBadRequest),
+		Entry("invalid", kerrors.NewInvalid(restworkspacesv1alpha1.GroupVersion.WithKind("Workspace").GroupKind(), "foo", nil),
+			int32(http.StatusUnprocessableEntity), metav1.StatusReasonInvalid),
+		Entry("field validation", field.Required(field.NewPath("spec", "visibility"), "visibility is required"),
+			int32(http.StatusUnprocessableEntity), metav1.StatusReasonInvalid),
+		Entry("unknown", fmt.Errorf("secret details"), int32(http.StatusInternalServerError), metav1.StatusReasonInternalError),
+	)
+
+	It("should not disclose the details of unknown errors", func() {
+		// when
+		s := status.FromError(fmt.Errorf("secret details"))
+
+		// then
+		Expect(s.Message).NotTo(ContainSubstring("secret details"))
+	})
+
+	It("should write the Status as body", func() {
+		// given
+		w := httptest.NewRecorder()
+
+		// when
+		err := status.Write(w, marshal.DefaultMarshal, kerrors.NewConflict(gr, "foo", fmt.Errorf("conflict")))
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Code).To(Equal(http.StatusConflict))
+		Expect(w.Header().Get("Content-Type")).To(Equal(marshal.ContentTypeJson))
+		s := metav1.Status{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &s)).To(Succeed())
+		Expect(s.Reason).To(Equal(metav1.StatusReasonConflict))
+		Expect(s.Details).NotTo(BeNil())
+		Expect(s.Details.Name).To(Equal("foo"))
+	})
+
+	It("should write only the status code if the Status can not be marshaled", func() {
+		// given
+		w := httptest.NewRecorder()
+
+		// when
+		err := status.Write(w, &badMarshaler{}, core.ErrNotFound)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(w.Code).To(Equal(http.StatusNotFound))
+		Expect(w.Body.Len()).To(BeZero())
+	})
+})
@@ -48,8 +48,8 @@ func (p *PostWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("building marshaler for request")
 	m, err := p.MarshalerProvider(r)
 	if err != nil {
-		l.Error("error building marshaler for request", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
 		return
 	}
 
@@ -57,8 +57,8 @@ func (p *PostWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("mapping request to create command")
 	q, err := p.MapperFunc(r, p.UnmarshalerProvider)
 	if err != nil {
-		l.Error("error mapping request to create command", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		l.Debug("error mapping request to create command", "error", err)
+		replyError(l, w, m, badRequest(err))
 		return
 	}
 
@@ -66,8 +66,7 @@ func (p *PostWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("executing create command", "command", q)
 	cr, err := p.CreateHandler(r.Context(), *q)
 	if err != nil {
-		l.Error("error executing create command", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -76,13 +75,14 @@ func (p *PostWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	d, err := m.Marshal(cr.Workspace)
 	if err != nil {
 		l.Error("error marshaling response", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		replyError(l, w, m, err)
 		return
 	}
 
 	// reply
 	l.Debug("writing response", "response", d)
 	w.Header().Add(header.ContentType, m.ContentType())
+	w.WriteHeader(http.StatusCreated)
 	if _, err := w.Write(d); err != nil {
 		l.Error("error writing response", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
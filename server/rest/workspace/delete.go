@@ -1 +1,119 @@
 package workspace
+
+import (
+	"context"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+)
+
+var (
+	_ http.Handler = &DeleteWorkspaceHandler{}
+
+	_ DeleteWorkspaceMapperFunc = MapDeleteWorkspaceHttp
+)
+
+// handler dependencies
+type DeleteWorkspaceMapperFunc func(r *http.Request) (*workspace.DeleteWorkspaceCommand, error)
+type DeleteWorkspaceCommandHandlerFunc func(context.Context, workspace.DeleteWorkspaceCommand) (*workspace.DeleteWorkspaceResponse, error)
+
+// DeleteWorkspaceHandler the http.Request handler for Delete Workspaces endpoint
+type DeleteWorkspaceHandler struct {
+	MapperFunc     DeleteWorkspaceMapperFunc
+	CommandHandler DeleteWorkspaceCommandHandlerFunc
+
+	MarshalerProvider marshal.MarshalerProvider
+}
+
+// NewDefaultDeleteWorkspaceHandler creates a DeleteWorkspaceHandler
+func NewDefaultDeleteWorkspaceHandler(
+	handler DeleteWorkspaceCommandHandlerFunc,
+) *DeleteWorkspaceHandler {
+	return NewDeleteWorkspaceHandler(
+		MapDeleteWorkspaceHttp,
+		handler,
+		marshal.DefaultMarshalerProvider,
+	)
+}
+
+// NewDeleteWorkspaceHandler creates a DeleteWorkspaceHandler
+func NewDeleteWorkspaceHandler(
+	mapperFunc DeleteWorkspaceMapperFunc,
+	commandHandler DeleteWorkspaceCommandHandlerFunc,
+	marshalerProvider marshal.MarshalerProvider,
+) *DeleteWorkspaceHandler {
+	return &DeleteWorkspaceHandler{
+		MapperFunc:        mapperFunc,
+		CommandHandler:    commandHandler,
+		MarshalerProvider: marshalerProvider,
+	}
+}
+
+func (h *DeleteWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := log.FromContext(r.Context())
+	l.Debug("executing delete")
+
+	// build marshaler for the given request
+	l.Debug("building marshaler for request")
+	m, err := h.MarshalerProvider(r)
+	if err != nil {
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
+		return
+	}
+
+	// map
+	l.Debug("mapping request to delete command")
+	c, err := h.MapperFunc(r)
+	if err != nil {
+		l.Debug("error mapping request to delete command", "error", err)
+		replyError(l, w, m, badRequest(err))
+		return
+	}
+
+	// execute
+	l.Debug("executing delete command", "command", c)
+	if _, err := h.CommandHandler(r.Context(), *c); err != nil {
+		replyError(l, w, m, err)
+		return
+	}
+
+	// marshal response
+	s := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+		Details: &metav1.StatusDetails{
+			Name:  c.Name,
+			Group: restworkspacesv1alpha1.GroupVersion.Group,
+			Kind:  "workspaces",
+		},
+	}
+	l.Debug("marshaling response", "response", &s)
+	d, err := m.Marshal(&s)
+	if err != nil {
+		l.Error("error marshaling response", "error", err)
+		replyError(l, w, m, err)
+		return
+	}
+
+	// reply
+	l.Debug("writing response", "response", d)
+	w.Header().Add(header.ContentType, m.ContentType())
+	if _, err := w.Write(d); err != nil {
+		l.Error("error writing response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func MapDeleteWorkspaceHttp(r *http.Request) (*workspace.DeleteWorkspaceCommand, error) {
+	c := r.PathValue("name")
+	ns := r.PathValue("namespace")
+	return &workspace.DeleteWorkspaceCommand{Name: c, Owner: ns}, nil
+}
@@ -0,0 +1,116 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Delete", func() {
+	var (
+		ctrl    *gomock.Controller
+		request *http.Request
+		fake    *mocks.MockFakeResponseWriter
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		var err error
+		request, err = http.NewRequest(http.MethodDelete, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.SetPathValue("namespace", "bar")
+		request.SetPathValue("name", "foo")
+
+		fake = mocks.NewMockFakeResponseWriter(ctrl)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	DescribeTable("workspace DELETE handler: delete",
+		func(
+			mapperFunc workspace.DeleteWorkspaceMapperFunc,
+			deleteHandler workspace.DeleteWorkspaceCommandHandlerFunc,
+			marshaler marshal.MarshalerProvider,
+			responseFunc func() http.ResponseWriter,
+		) {
+			response := responseFunc()
+			handler := workspace.NewDeleteWorkspaceHandler(mapperFunc, deleteHandler, marshaler)
+			handler.ServeHTTP(response, request)
+		},
+		Entry("failure in marshal provider", workspace.MapDeleteWorkspaceHttp, nopDeleteHandler, errorMarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in delete handler", workspace.MapDeleteWorkspaceHttp, badDeleteHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("delete not found", workspace.MapDeleteWorkspaceHttp, notFoundDeleteHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusNotFound)
+			return fake
+		}),
+		Entry("delete forbidden", workspace.MapDeleteWorkspaceHttp, forbiddenDeleteHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusForbidden)
+			return fake
+		}),
+		Entry("failure marshaling response", workspace.MapDeleteWorkspaceHttp, nopDeleteHandler, badMarshalProvider, func() http.ResponseWriter {
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("failure to write response", workspace.MapDeleteWorkspaceHttp, nopDeleteHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("failed to write response body"))
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("workspace deleted", workspace.MapDeleteWorkspaceHttp, nopDeleteHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(a any) (int, error) {
+				slice, ok := a.([]byte)
+				Expect(ok).To(BeTrue())
+				return len(slice), nil
+			})
+			return fake
+		}),
+	)
+
+	It("maps the request to a delete command", func() {
+		// when
+		c, err := workspace.MapDeleteWorkspaceHttp(request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).To(Equal(&coreworkspace.DeleteWorkspaceCommand{Name: "foo", Owner: "bar"}))
+	})
+})
+
+func badDeleteHandler(ctx context.Context, cmd coreworkspace.DeleteWorkspaceCommand) (*coreworkspace.DeleteWorkspaceResponse, error) {
+	return nil, fmt.Errorf("bad delete handler")
+}
+
+func notFoundDeleteHandler(ctx context.Context, cmd coreworkspace.DeleteWorkspaceCommand) (*coreworkspace.DeleteWorkspaceResponse, error) {
+	return nil, kerrors.NewNotFound(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name)
+}
+
+func forbiddenDeleteHandler(ctx context.Context, cmd coreworkspace.DeleteWorkspaceCommand) (*coreworkspace.DeleteWorkspaceResponse, error) {
+	return nil, kerrors.NewForbidden(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name, fmt.Errorf("forbidden"))
+}
+
+func nopDeleteHandler(ctx context.Context, cmd coreworkspace.DeleteWorkspaceCommand) (*coreworkspace.DeleteWorkspaceResponse, error) {
+	return &coreworkspace.DeleteWorkspaceResponse{}, nil
+}
@@ -2,7 +2,13 @@ package workspace
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
 	"github.com/konflux-workspaces/workspaces/server/log"
@@ -34,7 +40,7 @@ func NewDefaultListWorkspaceHandler(
 	return NewListWorkspaceHandler(
 		MapListWorkspaceHttp,
 		handler,
-		marshal.DefaultMarshalerProvider,
+		TableMarshalerProvider,
 	)
 }
 
@@ -59,8 +65,8 @@ func (h *ListWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("building marshaler for request")
 	m, err := h.MarshalerProvider(r)
 	if err != nil {
-		l.Error("error building marshaler for request", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
 		return
 	}
 
@@ -68,8 +74,8 @@ func (h *ListWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("mapping request to list query")
 	q, err := h.MapperFunc(r)
 	if err != nil {
-		l.Error("error mapping request to query", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		l.Debug("error mapping request to query", "error", err)
+		replyError(l, w, m, badRequest(err))
 		return
 	}
 
@@ -77,8 +83,7 @@ func (h *ListWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("executing create query", "query", q)
 	qr, err := h.QueryHandler(r.Context(), *q)
 	if err != nil {
-		l.Error("error executing list query", "query", q, "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -87,7 +92,7 @@ func (h *ListWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	d, err := m.Marshal(qr.Workspaces)
 	if err != nil {
 		l.Error("error marshaling response", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -107,5 +112,45 @@ func MapListWorkspaceHttp(r *http.Request) (*workspace.ListWorkspaceQuery, error
 	if ns != "" {
 		q.Namespace = ns
 	}
+
+	// selectors
+	qp := r.URL.Query()
+	ls, fs, err := mapSelectors(qp)
+	if err != nil {
+		return nil, err
+	}
+	q.LabelSelector, q.FieldSelector = ls, fs
+
+	// pagination
+	if ls := qp.Get("limit"); ls != "" {
+		l, err := strconv.ParseInt(ls, 10, 64)
+		if err != nil || l < 0 {
+			return nil, fmt.Errorf("invalid limit %q: must be a non-negative integer", ls)
+		}
+		q.Limit = l
+	}
+	q.Continue = qp.Get("continue")
 	return &q, nil
 }
+
+// mapSelectors parses the label and field selectors in the query parameters, if set
+func mapSelectors(qp url.Values) (labels.Selector, fields.Selector, error) {
+	var ls labels.Selector
+	if v := qp.Get("labelSelector"); v != "" {
+		s, err := labels.Parse(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		ls = s
+	}
+
+	var fs fields.Selector
+	if v := qp.Get("fieldSelector"); v != "" {
+		s, err := fields.ParseSelector(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid field selector: %w", err)
+		}
+		fs = s
+	}
+	return ls, fs, nil
+}
@@ -10,6 +10,9 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
 	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
 
 	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
@@ -51,11 +54,11 @@ var _ = Describe("List", func() {
 			handler.ServeHTTP(response, request)
 		},
 		Entry("failure in marshal provider", workspace.MapListWorkspaceHttp, nopListHandler, errorMarshalProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("failure in read handler", workspace.MapListWorkspaceHttp, badListHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			expectStatus(fake, http.StatusInternalServerError)
 			return fake
 		}),
 		Entry("failure marshaling response", workspace.MapListWorkspaceHttp, nopListHandler, badMarshalProvider, func() http.ResponseWriter {
@@ -78,6 +81,36 @@ var _ = Describe("List", func() {
 			return fake
 		}),
 	)
+
+	DescribeTable("request mapping",
+		func(query string, expected *coreworkspace.ListWorkspaceQuery) {
+			// given
+			request.URL.RawQuery = query
+			request.SetPathValue("namespace", w.Namespace)
+
+			// when
+			q, err := workspace.MapListWorkspaceHttp(request)
+
+			// then
+			if expected == nil {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(q).To(Equal(expected))
+		},
+		Entry("no options", "", &coreworkspace.ListWorkspaceQuery{Namespace: "bar"}),
+		Entry("limit and continue", "limit=10&continue=token",
+			&coreworkspace.ListWorkspaceQuery{Namespace: "bar", Limit: 10, Continue: "token"}),
+		Entry("label selector", "labelSelector=app%3Dfoo",
+			&coreworkspace.ListWorkspaceQuery{Namespace: "bar", LabelSelector: labels.SelectorFromSet(labels.Set{"app": "foo"})}),
+		Entry("field selector", "fieldSelector=spec.visibility%3Dcommunity",
+			&coreworkspace.ListWorkspaceQuery{Namespace: "bar", FieldSelector: fields.OneTermEqualSelector("spec.visibility", "community")}),
+		Entry("invalid label selector", "labelSelector=app%3D%3D%3Dfoo", nil),
+		Entry("invalid field selector", "fieldSelector=spec.visibility", nil),
+		Entry("invalid limit", "limit=ten", nil),
+		Entry("negative limit", "limit=-1", nil),
+	)
 })
 
 func badListHandler(ctx context.Context, cmd coreworkspace.ListWorkspaceQuery) (*coreworkspace.ListWorkspaceResponse, error) {
@@ -0,0 +1,108 @@
+package workspace
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+)
+
+var (
+	_ http.Handler = &ListWorkspaceMembersHandler{}
+
+	_ ListWorkspaceMembersMapperFunc = MapListWorkspaceMembersHttp
+)
+
+// handler dependencies
+type ListWorkspaceMembersMapperFunc func(r *http.Request) (*workspace.ListWorkspaceMembersQuery, error)
+type ListWorkspaceMembersQueryHandlerFunc func(context.Context, workspace.ListWorkspaceMembersQuery) (*workspace.ListWorkspaceMembersResponse, error)
+
+// ListWorkspaceMembersHandler the http.Request handler for List Workspace Members endpoint
+type ListWorkspaceMembersHandler struct {
+	MapperFunc   ListWorkspaceMembersMapperFunc
+	QueryHandler ListWorkspaceMembersQueryHandlerFunc
+
+	MarshalerProvider marshal.MarshalerProvider
+}
+
+// NewDefaultListWorkspaceMembersHandler creates a ListWorkspaceMembersHandler
+func NewDefaultListWorkspaceMembersHandler(
+	handler ListWorkspaceMembersQueryHandlerFunc,
+) *ListWorkspaceMembersHandler {
+	return NewListWorkspaceMembersHandler(
+		MapListWorkspaceMembersHttp,
+		handler,
+		marshal.DefaultMarshalerProvider,
+	)
+}
+
+// NewListWorkspaceMembersHandler creates a ListWorkspaceMembersHandler
+func NewListWorkspaceMembersHandler(
+	mapperFunc ListWorkspaceMembersMapperFunc,
+	queryHandler ListWorkspaceMembersQueryHandlerFunc,
+	marshalerProvider marshal.MarshalerProvider,
+) *ListWorkspaceMembersHandler {
+	return &ListWorkspaceMembersHandler{
+		MapperFunc:        mapperFunc,
+		QueryHandler:      queryHandler,
+		MarshalerProvider: marshalerProvider,
+	}
+}
+
+func (h *ListWorkspaceMembersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := log.FromContext(r.Context())
+	l.Debug("executing list members")
+
+	// build marshaler for the given request
+	l.Debug("building marshaler for request")
+	m, err := h.MarshalerProvider(r)
+	if err != nil {
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
+		return
+	}
+
+	// map
+	l.Debug("mapping request to list members query")
+	q, err := h.MapperFunc(r)
+	if err != nil {
+		l.Debug("error mapping request to list members query", "error", err)
+		replyError(l, w, m, badRequest(err))
+		return
+	}
+
+	// execute
+	l.Debug("executing list members query", "query", q)
+	qr, err := h.QueryHandler(r.Context(), *q)
+	if err != nil {
+		replyError(l, w, m, err)
+		return
+	}
+
+	// marshal response
+	l.Debug("marshaling response", "response", &qr)
+	d, err := m.Marshal(qr.Members)
+	if err != nil {
+		l.Error("error marshaling response", "error", err)
+		replyError(l, w, m, err)
+		return
+	}
+
+	// reply
+	l.Debug("writing response", "response", d)
+	w.Header().Add(header.ContentType, m.ContentType())
+	if _, err := w.Write(d); err != nil {
+		l.Error("error writing response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func MapListWorkspaceMembersHttp(r *http.Request) (*workspace.ListWorkspaceMembersQuery, error) {
+	n := r.PathValue("name")
+	ns := r.PathValue("namespace")
+	return &workspace.ListWorkspaceMembersQuery{Name: n, Owner: ns}, nil
+}
@@ -0,0 +1,123 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("ListMembers", func() {
+	var (
+		ctrl    *gomock.Controller
+		request *http.Request
+		fake    *mocks.MockFakeResponseWriter
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		var err error
+		request, err = http.NewRequest(http.MethodGet, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo/members", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.SetPathValue("namespace", "bar")
+		request.SetPathValue("name", "foo")
+
+		fake = mocks.NewMockFakeResponseWriter(ctrl)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	DescribeTable("workspace members GET handler: list",
+		func(
+			mapperFunc workspace.ListWorkspaceMembersMapperFunc,
+			queryHandler workspace.ListWorkspaceMembersQueryHandlerFunc,
+			marshaler marshal.MarshalerProvider,
+			responseFunc func() http.ResponseWriter,
+		) {
+			response := responseFunc()
+			handler := workspace.NewListWorkspaceMembersHandler(mapperFunc, queryHandler, marshaler)
+			handler.ServeHTTP(response, request)
+		},
+		Entry("failure in marshal provider", workspace.MapListWorkspaceMembersHttp, nopListMembersHandler, errorMarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in list members handler", workspace.MapListWorkspaceMembersHttp, badListMembersHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("list members not found", workspace.MapListWorkspaceMembersHttp, notFoundListMembersHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusNotFound)
+			return fake
+		}),
+		Entry("list members forbidden", workspace.MapListWorkspaceMembersHttp, forbiddenListMembersHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusForbidden)
+			return fake
+		}),
+		Entry("failure marshaling response", workspace.MapListWorkspaceMembersHttp, nopListMembersHandler, badMarshalProvider, func() http.ResponseWriter {
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("failure to write response", workspace.MapListWorkspaceMembersHttp, nopListMembersHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("failed to write response body"))
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("members listed", workspace.MapListWorkspaceMembersHttp, nopListMembersHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(a any) (int, error) {
+				slice, ok := a.([]byte)
+				Expect(ok).To(BeTrue())
+				Expect(string(slice)).To(ContainSubstring(`"username":"baz"`))
+				return len(slice), nil
+			})
+			return fake
+		}),
+	)
+
+	It("maps the request to a list members query", func() {
+		// when
+		q, err := workspace.MapListWorkspaceMembersHttp(request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(q).To(Equal(&coreworkspace.ListWorkspaceMembersQuery{Name: "foo", Owner: "bar"}))
+	})
+})
+
+func badListMembersHandler(ctx context.Context, query coreworkspace.ListWorkspaceMembersQuery) (*coreworkspace.ListWorkspaceMembersResponse, error) {
+	return nil, fmt.Errorf("bad list members handler")
+}
+
+func notFoundListMembersHandler(ctx context.Context, query coreworkspace.ListWorkspaceMembersQuery) (*coreworkspace.ListWorkspaceMembersResponse, error) {
+	return nil, kerrors.NewNotFound(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), query.Name)
+}
+
+func forbiddenListMembersHandler(ctx context.Context, query coreworkspace.ListWorkspaceMembersQuery) (*coreworkspace.ListWorkspaceMembersResponse, error) {
+	return nil, kerrors.NewForbidden(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), query.Name, fmt.Errorf("forbidden"))
+}
+
+func nopListMembersHandler(ctx context.Context, query coreworkspace.ListWorkspaceMembersQuery) (*coreworkspace.ListWorkspaceMembersResponse, error) {
+	return &coreworkspace.ListWorkspaceMembersResponse{
+		Members: &restworkspacesv1alpha1.WorkspaceMemberList{
+			Items: []restworkspacesv1alpha1.WorkspaceMember{
+				{Username: "baz", Role: restworkspacesv1alpha1.WorkspaceRoleViewer},
+			},
+		},
+	}, nil
+}
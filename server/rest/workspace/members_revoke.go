@@ -0,0 +1,121 @@
+package workspace
+
+import (
+	"context"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+)
+
+var (
+	_ http.Handler = &DeleteWorkspaceMemberHandler{}
+
+	_ DeleteWorkspaceMemberMapperFunc = MapDeleteWorkspaceMemberHttp
+)
+
+// handler dependencies
+type DeleteWorkspaceMemberMapperFunc func(r *http.Request) (*workspace.RevokeWorkspaceMemberCommand, error)
+type RevokeWorkspaceMemberCommandHandlerFunc func(context.Context, workspace.RevokeWorkspaceMemberCommand) (*workspace.RevokeWorkspaceMemberResponse, error)
+
+// DeleteWorkspaceMemberHandler the http.Request handler for Revoke Workspace Member endpoint
+type DeleteWorkspaceMemberHandler struct {
+	MapperFunc     DeleteWorkspaceMemberMapperFunc
+	CommandHandler RevokeWorkspaceMemberCommandHandlerFunc
+
+	MarshalerProvider marshal.MarshalerProvider
+}
+
+// NewDefaultDeleteWorkspaceMemberHandler creates a DeleteWorkspaceMemberHandler
+func NewDefaultDeleteWorkspaceMemberHandler(
+	handler RevokeWorkspaceMemberCommandHandlerFunc,
+) *DeleteWorkspaceMemberHandler {
+	return NewDeleteWorkspaceMemberHandler(
+		MapDeleteWorkspaceMemberHttp,
+		handler,
+		marshal.DefaultMarshalerProvider,
+	)
+}
+
+// NewDeleteWorkspaceMemberHandler creates a DeleteWorkspaceMemberHandler
+func NewDeleteWorkspaceMemberHandler(
+	mapperFunc DeleteWorkspaceMemberMapperFunc,
+	commandHandler RevokeWorkspaceMemberCommandHandlerFunc,
+	marshalerProvider marshal.MarshalerProvider,
+) *DeleteWorkspaceMemberHandler {
+	return &DeleteWorkspaceMemberHandler{
+		MapperFunc:        mapperFunc,
+		CommandHandler:    commandHandler,
+		MarshalerProvider: marshalerProvider,
+	}
+}
+
+func (h *DeleteWorkspaceMemberHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := log.FromContext(r.Context())
+	l.Debug("executing revoke member")
+
+	// build marshaler for the given request
+	l.Debug("building marshaler for request")
+	m, err := h.MarshalerProvider(r)
+	if err != nil {
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
+		return
+	}
+
+	// map
+	l.Debug("mapping request to revoke member command")
+	c, err := h.MapperFunc(r)
+	if err != nil {
+		l.Debug("error mapping request to revoke member command", "error", err)
+		replyError(l, w, m, badRequest(err))
+		return
+	}
+
+	// execute
+	l.Debug("executing revoke member command", "command", c)
+	if _, err := h.CommandHandler(r.Context(), *c); err != nil {
+		replyError(l, w, m, err)
+		return
+	}
+
+	// marshal response
+	s := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+		Details: &metav1.StatusDetails{
+			Name:  c.Username,
+			Group: restworkspacesv1alpha1.GroupVersion.Group,
+			Kind:  "workspacemembers",
+		},
+	}
+	l.Debug("marshaling response", "response", &s)
+	d, err := m.Marshal(&s)
+	if err != nil {
+		l.Error("error marshaling response", "error", err)
+		replyError(l, w, m, err)
+		return
+	}
+
+	// reply
+	l.Debug("writing response", "response", d)
+	w.Header().Add(header.ContentType, m.ContentType())
+	if _, err := w.Write(d); err != nil {
+		l.Error("error writing response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func MapDeleteWorkspaceMemberHttp(r *http.Request) (*workspace.RevokeWorkspaceMemberCommand, error) {
+	return &workspace.RevokeWorkspaceMemberCommand{
+		Name:     r.PathValue("name"),
+		Owner:    r.PathValue("namespace"),
+		Username: r.PathValue("username"),
+	}, nil
+}
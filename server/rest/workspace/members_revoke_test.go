@@ -0,0 +1,117 @@
+package workspace_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("RevokeMember", func() {
+	var (
+		ctrl    *gomock.Controller
+		request *http.Request
+		fake    *mocks.MockFakeResponseWriter
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		var err error
+		request, err = http.NewRequest(http.MethodDelete, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo/members/baz", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.SetPathValue("namespace", "bar")
+		request.SetPathValue("name", "foo")
+		request.SetPathValue("username", "baz")
+
+		fake = mocks.NewMockFakeResponseWriter(ctrl)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	DescribeTable("workspace members DELETE handler: revoke",
+		func(
+			mapperFunc workspace.DeleteWorkspaceMemberMapperFunc,
+			commandHandler workspace.RevokeWorkspaceMemberCommandHandlerFunc,
+			marshaler marshal.MarshalerProvider,
+			responseFunc func() http.ResponseWriter,
+		) {
+			response := responseFunc()
+			handler := workspace.NewDeleteWorkspaceMemberHandler(mapperFunc, commandHandler, marshaler)
+			handler.ServeHTTP(response, request)
+		},
+		Entry("failure in marshal provider", workspace.MapDeleteWorkspaceMemberHttp, nopRevokeMemberHandler, errorMarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in revoke member handler", workspace.MapDeleteWorkspaceMemberHttp, badRevokeMemberHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("revoke member not found", workspace.MapDeleteWorkspaceMemberHttp, notFoundRevokeMemberHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusNotFound)
+			return fake
+		}),
+		Entry("revoke member forbidden", workspace.MapDeleteWorkspaceMemberHttp, forbiddenRevokeMemberHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusForbidden)
+			return fake
+		}),
+		Entry("failure marshaling response", workspace.MapDeleteWorkspaceMemberHttp, nopRevokeMemberHandler, badMarshalProvider, func() http.ResponseWriter {
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("failure to write response", workspace.MapDeleteWorkspaceMemberHttp, nopRevokeMemberHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("failed to write response body"))
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("member revoked", workspace.MapDeleteWorkspaceMemberHttp, nopRevokeMemberHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(a any) (int, error) {
+				slice, ok := a.([]byte)
+				Expect(ok).To(BeTrue())
+				return len(slice), nil
+			})
+			return fake
+		}),
+	)
+
+	It("maps the request to a revoke member command", func() {
+		// when
+		c, err := workspace.MapDeleteWorkspaceMemberHttp(request)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).To(Equal(&coreworkspace.RevokeWorkspaceMemberCommand{Name: "foo", Owner: "bar", Username: "baz"}))
+	})
+})
+
+func badRevokeMemberHandler(ctx context.Context, cmd coreworkspace.RevokeWorkspaceMemberCommand) (*coreworkspace.RevokeWorkspaceMemberResponse, error) {
+	return nil, fmt.Errorf("bad revoke member handler")
+}
+
+func notFoundRevokeMemberHandler(ctx context.Context, cmd coreworkspace.RevokeWorkspaceMemberCommand) (*coreworkspace.RevokeWorkspaceMemberResponse, error) {
+	return nil, kerrors.NewNotFound(restworkspacesv1alpha1.GroupVersion.WithResource("workspacemembers").GroupResource(), cmd.Username)
+}
+
+func forbiddenRevokeMemberHandler(ctx context.Context, cmd coreworkspace.RevokeWorkspaceMemberCommand) (*coreworkspace.RevokeWorkspaceMemberResponse, error) {
+	return nil, kerrors.NewForbidden(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name, fmt.Errorf("forbidden"))
+}
+
+func nopRevokeMemberHandler(ctx context.Context, cmd coreworkspace.RevokeWorkspaceMemberCommand) (*coreworkspace.RevokeWorkspaceMemberResponse, error) {
+	return &coreworkspace.RevokeWorkspaceMemberResponse{}, nil
+}
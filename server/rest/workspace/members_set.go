@@ -0,0 +1,143 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+)
+
+var (
+	_ http.Handler = &PutWorkspaceMemberHandler{}
+
+	_ PutWorkspaceMemberMapperFunc = MapPutWorkspaceMemberHttp
+)
+
+// handler dependencies
+type PutWorkspaceMemberMapperFunc func(*http.Request, marshal.UnmarshalerProvider) (*workspace.SetWorkspaceMemberCommand, error)
+type SetWorkspaceMemberCommandHandlerFunc func(context.Context, workspace.SetWorkspaceMemberCommand) (*workspace.SetWorkspaceMemberResponse, error)
+
+// PutWorkspaceMemberHandler the http.Request handler for Set Workspace Member endpoint
+type PutWorkspaceMemberHandler struct {
+	MapperFunc     PutWorkspaceMemberMapperFunc
+	CommandHandler SetWorkspaceMemberCommandHandlerFunc
+
+	MarshalerProvider   marshal.MarshalerProvider
+	UnmarshalerProvider marshal.UnmarshalerProvider
+}
+
+// NewDefaultPutWorkspaceMemberHandler creates a PutWorkspaceMemberHandler
+func NewDefaultPutWorkspaceMemberHandler(
+	handler SetWorkspaceMemberCommandHandlerFunc,
+) *PutWorkspaceMemberHandler {
+	return NewPutWorkspaceMemberHandler(
+		MapPutWorkspaceMemberHttp,
+		handler,
+		marshal.DefaultMarshalerProvider,
+		marshal.DefaultUnmarshalerProvider,
+	)
+}
+
+// NewPutWorkspaceMemberHandler creates a PutWorkspaceMemberHandler
+func NewPutWorkspaceMemberHandler(
+	mapperFunc PutWorkspaceMemberMapperFunc,
+	commandHandler SetWorkspaceMemberCommandHandlerFunc,
+	marshalerProvider marshal.MarshalerProvider,
+	unmarshalerProvider marshal.UnmarshalerProvider,
+) *PutWorkspaceMemberHandler {
+	return &PutWorkspaceMemberHandler{
+		MapperFunc:          mapperFunc,
+		CommandHandler:      commandHandler,
+		MarshalerProvider:   marshalerProvider,
+		UnmarshalerProvider: unmarshalerProvider,
+	}
+}
+
+func (h *PutWorkspaceMemberHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := log.FromContext(r.Context())
+	l.Debug("executing set member")
+
+	// build marshaler for the given request
+	l.Debug("building marshaler for request")
+	m, err := h.MarshalerProvider(r)
+	if err != nil {
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
+		return
+	}
+
+	// map
+	l.Debug("mapping request to set member command")
+	c, err := h.MapperFunc(r, h.UnmarshalerProvider)
+	if err != nil {
+		l.Debug("error mapping request to set member command", "error", err)
+		replyError(l, w, m, badRequest(err))
+		return
+	}
+
+	// execute
+	l.Debug("executing set member command", "command", c)
+	cr, err := h.CommandHandler(r.Context(), *c)
+	if err != nil {
+		replyError(l, w, m, err)
+		return
+	}
+
+	// marshal response
+	l.Debug("marshaling response", "response", &cr)
+	d, err := m.Marshal(cr.Member)
+	if err != nil {
+		l.Error("error marshaling response", "error", err)
+		replyError(l, w, m, err)
+		return
+	}
+
+	// reply
+	l.Debug("writing response", "response", d)
+	w.Header().Add(header.ContentType, m.ContentType())
+	if _, err := w.Write(d); err != nil {
+		l.Error("error writing response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func MapPutWorkspaceMemberHttp(r *http.Request, unmarshaler marshal.UnmarshalerProvider) (*workspace.SetWorkspaceMemberCommand, error) {
+	// build unmarshaler for the given request
+	u, err := unmarshaler(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse request body
+	d, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	// unmarshal body to WorkspaceMember
+	m := restworkspacesv1alpha1.WorkspaceMember{}
+	if err := u.Unmarshal(d, &m); err != nil {
+		return nil, fmt.Errorf("error unmarshaling request body: %w", err)
+	}
+
+	// the member is identified by the path
+	un := r.PathValue("username")
+	if m.Username != "" && m.Username != un {
+		return nil, fmt.Errorf("username in body (%s) does not match the one in path (%s)", m.Username, un)
+	}
+	m.Username = un
+
+	// build command
+	return &workspace.SetWorkspaceMemberCommand{
+		Name:   r.PathValue("name"),
+		Owner:  r.PathValue("namespace"),
+		Member: m,
+	}, nil
+}
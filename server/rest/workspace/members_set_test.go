@@ -0,0 +1,155 @@
+package workspace_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("SetMember", func() {
+	var (
+		ctrl *gomock.Controller
+		fake *mocks.MockFakeResponseWriter
+	)
+
+	buildRequest := func(body string) *http.Request {
+		request, err := http.NewRequest(http.MethodPut, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo/members/baz", bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+		request.SetPathValue("namespace", "bar")
+		request.SetPathValue("name", "foo")
+		request.SetPathValue("username", "baz")
+		return request
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		fake = mocks.NewMockFakeResponseWriter(ctrl)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	DescribeTable("workspace members PUT handler: set",
+		func(
+			mapperFunc workspace.PutWorkspaceMemberMapperFunc,
+			commandHandler workspace.SetWorkspaceMemberCommandHandlerFunc,
+			marshaler marshal.MarshalerProvider,
+			unmarshaler marshal.UnmarshalerProvider,
+			responseFunc func() http.ResponseWriter,
+		) {
+			response := responseFunc()
+			handler := workspace.NewPutWorkspaceMemberHandler(mapperFunc, commandHandler, marshaler, unmarshaler)
+			handler.ServeHTTP(response, buildRequest(`{"role":"viewer"}`))
+		},
+		Entry("failure in marshal provider", workspace.MapPutWorkspaceMemberHttp, nopSetMemberHandler, errorMarshalProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in unmarshal provider", workspace.MapPutWorkspaceMemberHttp, nopSetMemberHandler, marshal.DefaultMarshalerProvider, errorUnmarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure unmarshaling request", workspace.MapPutWorkspaceMemberHttp, nopSetMemberHandler, marshal.DefaultMarshalerProvider, badUnmarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in set member handler", workspace.MapPutWorkspaceMemberHttp, badSetMemberHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("set member not found", workspace.MapPutWorkspaceMemberHttp, notFoundSetMemberHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusNotFound)
+			return fake
+		}),
+		Entry("set member forbidden", workspace.MapPutWorkspaceMemberHttp, forbiddenSetMemberHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusForbidden)
+			return fake
+		}),
+		Entry("set member invalid", workspace.MapPutWorkspaceMemberHttp, invalidSetMemberHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusUnprocessableEntity)
+			return fake
+		}),
+		Entry("failure marshaling response", workspace.MapPutWorkspaceMemberHttp, nopSetMemberHandler, badMarshalProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("failure to write response", workspace.MapPutWorkspaceMemberHttp, nopSetMemberHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("failed to write response body"))
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("member set", workspace.MapPutWorkspaceMemberHttp, nopSetMemberHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(a any) (int, error) {
+				slice, ok := a.([]byte)
+				Expect(ok).To(BeTrue())
+				Expect(string(slice)).To(ContainSubstring(`"username":"baz"`))
+				return len(slice), nil
+			})
+			return fake
+		}),
+	)
+
+	It("maps the request to a set member command", func() {
+		// when
+		c, err := workspace.MapPutWorkspaceMemberHttp(buildRequest(`{"role":"contributor"}`), marshal.DefaultUnmarshalerProvider)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).To(Equal(&coreworkspace.SetWorkspaceMemberCommand{
+			Name:  "foo",
+			Owner: "bar",
+			Member: restworkspacesv1alpha1.WorkspaceMember{
+				Username: "baz",
+				Role:     restworkspacesv1alpha1.WorkspaceRoleContributor,
+			},
+		}))
+	})
+
+	It("rejects requests whose body refers to another user", func() {
+		// when
+		_, err := workspace.MapPutWorkspaceMemberHttp(buildRequest(`{"username":"other","role":"contributor"}`), marshal.DefaultUnmarshalerProvider)
+
+		// then
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func badSetMemberHandler(ctx context.Context, cmd coreworkspace.SetWorkspaceMemberCommand) (*coreworkspace.SetWorkspaceMemberResponse, error) {
+	return nil, fmt.Errorf("bad set member handler")
+}
+
+func notFoundSetMemberHandler(ctx context.Context, cmd coreworkspace.SetWorkspaceMemberCommand) (*coreworkspace.SetWorkspaceMemberResponse, error) {
+	return nil, kerrors.NewNotFound(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name)
+}
+
+func forbiddenSetMemberHandler(ctx context.Context, cmd coreworkspace.SetWorkspaceMemberCommand) (*coreworkspace.SetWorkspaceMemberResponse, error) {
+	return nil, kerrors.NewForbidden(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name, fmt.Errorf("forbidden"))
+}
+
+func invalidSetMemberHandler(ctx context.Context, cmd coreworkspace.SetWorkspaceMemberCommand) (*coreworkspace.SetWorkspaceMemberResponse, error) {
+	return nil, kerrors.NewInvalid(
+		restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceMember").GroupKind(),
+		cmd.Name,
+		field.ErrorList{field.NotFound(field.NewPath("username"), cmd.Member.Username)})
+}
+
+func nopSetMemberHandler(ctx context.Context, cmd coreworkspace.SetWorkspaceMemberCommand) (*coreworkspace.SetWorkspaceMemberResponse, error) {
+	return &coreworkspace.SetWorkspaceMemberResponse{Member: &cmd.Member}, nil
+}
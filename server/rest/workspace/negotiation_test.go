@@ -0,0 +1,153 @@
+package workspace_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Negotiation", func() {
+	var created *restworkspacesv1alpha1.Workspace
+
+	create := func(_ context.Context, c coreworkspace.CreateWorkspaceCommand) (*coreworkspace.CreateWorkspaceResponse, error) {
+		created = c.Workspace.DeepCopy()
+		return &coreworkspace.CreateWorkspaceResponse{Workspace: &c.Workspace}, nil
+	}
+	read := func(context.Context, coreworkspace.ReadWorkspaceQuery) (*coreworkspace.ReadWorkspaceResponse, error) {
+		return &coreworkspace.ReadWorkspaceResponse{
+			Workspace: &restworkspacesv1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}},
+		}, nil
+	}
+
+	BeforeEach(func() {
+		created = nil
+	})
+
+	serveCreate := func(contentType, accept string, body []byte) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces", bytes.NewReader(body))
+		r.SetPathValue("namespace", "bar")
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Accept", accept)
+
+		w := httptest.NewRecorder()
+		workspace.NewPostWorkspaceHandler(
+			workspace.MapPostWorkspaceHttp,
+			create,
+			marshal.DefaultMarshalerProvider,
+			marshal.DefaultUnmarshalerProvider,
+		).ServeHTTP(w, r)
+		return w
+	}
+
+	It("accepts YAML request bodies", func() {
+		// given
+		body := []byte("apiVersion: workspaces.konflux-ci.dev/v1alpha1\nkind: Workspace\nmetadata:\n  name: foo\nspec:\n  visibility: community\n")
+
+		// when
+		w := serveCreate("application/yaml", "application/yaml", body)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusCreated))
+		Expect(w.Header().Get("Content-Type")).To(Equal(marshal.ContentTypeYaml))
+		Expect(created).NotTo(BeNil())
+		Expect(created.Name).To(Equal("foo"))
+		Expect(created.Namespace).To(Equal("bar"))
+		Expect(created.Spec.Visibility).To(Equal(restworkspacesv1alpha1.WorkspaceVisibilityCommunity))
+
+		o := restworkspacesv1alpha1.Workspace{}
+		Expect((&marshal.YamlUnmarshaler{}).Unmarshal(w.Body.Bytes(), &o)).To(Succeed())
+		Expect(o.Name).To(Equal("foo"))
+	})
+
+	It("rejects request bodies of unsupported media types", func() {
+		// when
+		w := serveCreate("application/vnd.kubernetes.protobuf", "application/json", []byte{0x6b, 0x38, 0x73, 0x00})
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+		Expect(created).To(BeNil())
+	})
+
+	It("keeps the Status of wrapped errors", func() {
+		// given
+		r := httptest.NewRequest(http.MethodPost, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces", bytes.NewReader([]byte("{}")))
+		r.Header.Set("Content-Type", "application/json")
+		mapper := func(*http.Request, marshal.UnmarshalerProvider) (*coreworkspace.CreateWorkspaceCommand, error) {
+			return nil, fmt.Errorf("error mapping request: %w", marshal.NewUnsupportedMediaTypeError("text/plain", nil))
+		}
+
+		// when
+		w := httptest.NewRecorder()
+		workspace.NewPostWorkspaceHandler(
+			mapper,
+			create,
+			marshal.DefaultMarshalerProvider,
+			marshal.DefaultUnmarshalerProvider,
+		).ServeHTTP(w, r)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusUnsupportedMediaType))
+		Expect(created).To(BeNil())
+	})
+
+	It("replies with CBOR if requested", func() {
+		// given
+		r := httptest.NewRequest(http.MethodGet, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo", nil)
+		r.Header.Set("Accept", "application/json;q=0.5, application/cbor")
+
+		// when
+		w := httptest.NewRecorder()
+		workspace.NewDefaultReadWorkspaceHandler(read).ServeHTTP(w, r)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusOK))
+		Expect(w.Header().Get("Content-Type")).To(Equal(marshal.ContentTypeCbor))
+		o := restworkspacesv1alpha1.Workspace{}
+		Expect((&marshal.CborUnmarshaler{}).Unmarshal(w.Body.Bytes(), &o)).To(Succeed())
+		Expect(o.Name).To(Equal("foo"))
+	})
+
+	It("replies NotAcceptable if no supported media type is accepted", func() {
+		// given
+		r := httptest.NewRequest(http.MethodGet, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo", nil)
+		r.Header.Set("Accept", "text/html")
+
+		// when
+		w := httptest.NewRecorder()
+		workspace.NewDefaultReadWorkspaceHandler(read).ServeHTTP(w, r)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusNotAcceptable))
+		Expect(w.Header().Get("Content-Type")).To(Equal(marshal.ContentTypeJson))
+	})
+
+	It("streams watch events only as JSON", func() {
+		// given
+		r := httptest.NewRequest(http.MethodGet, "/apis/workspaces.io/v1alpha1/workspaces?watch=true", nil)
+		r.Header.Set("Accept", "application/yaml")
+		handler := func(context.Context, coreworkspace.WatchWorkspaceQuery) (*coreworkspace.WatchWorkspaceResponse, error) {
+			return &coreworkspace.WatchWorkspaceResponse{Watch: watch.NewFake()}, nil
+		}
+
+		// when
+		w := httptest.NewRecorder()
+		workspace.NewDefaultWatchWorkspaceHandler(handler).ServeHTTP(w, r)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusNotAcceptable))
+	})
+})
@@ -2,14 +2,14 @@ package workspace
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
-
-	"github.com/konflux-workspaces/workspaces/server/core"
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
 	"github.com/konflux-workspaces/workspaces/server/log"
 	"github.com/konflux-workspaces/workspaces/server/rest/header"
@@ -68,7 +68,7 @@ func (h *PatchWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	m, err := h.MarshalerProvider(r)
 	if err != nil {
 		l.Debug("error building marshaler for request", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		replyError(l, w, nil, badRequest(err))
 		return
 	}
 
@@ -77,7 +77,7 @@ func (h *PatchWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	c, err := h.MapperFunc(r)
 	if err != nil {
 		l.Debug("error mapping request to command", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		replyError(l, w, m, badRequest(err))
 		return
 	}
 
@@ -85,22 +85,7 @@ func (h *PatchWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	l.Debug("executing patch command", "command", c)
 	cr, err := h.CommandHandler(r.Context(), *c)
 	if err != nil {
-		l = l.With("error", err)
-		switch {
-		case errors.Is(err, core.ErrNotFound):
-			l.Debug("error executing patch command: resource not found")
-			w.WriteHeader(http.StatusNotFound)
-		case kerrors.IsForbidden(err):
-			serr := new(kerrors.StatusError)
-			errors.As(err, &serr)
-			w.WriteHeader(int(serr.Status().Code))
-			if _, err := w.Write([]byte(serr.Error())); err != nil {
-				l.Info("error writing response", "error", err)
-			}
-		default:
-			l.Error("error executing patch command")
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -109,13 +94,16 @@ func (h *PatchWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	d, err := m.Marshal(cr.Workspace)
 	if err != nil {
 		l.Error("unexpected error marshaling response", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		replyError(l, w, m, err)
 		return
 	}
 
 	// reply
 	l.Debug("writing response", "response", d)
 	w.Header().Add(header.ContentType, m.ContentType())
+	if cr.Created {
+		w.WriteHeader(http.StatusCreated)
+	}
 	if _, err := w.Write(d); err != nil {
 		l.Error("unexpected error writing response", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -139,31 +127,62 @@ func MapPatchWorkspaceHttp(r *http.Request) (*workspace.PatchWorkspaceCommand, e
 	n := r.PathValue("name")
 	ns := r.PathValue("namespace")
 
+	// retrieve field manager options from query
+	q := r.URL.Query()
+	fm := q.Get("fieldManager")
+	if fm == "" && pt != types.ApplyPatchType {
+		fm = prefixFromUserAgent(r.UserAgent())
+	}
+	f := false
+	if fs := q.Get("force"); fs != "" {
+		if f, err = strconv.ParseBool(fs); err != nil {
+			return nil, fmt.Errorf("invalid force value %q: %w", fs, err)
+		}
+	}
+
 	// build command
 	return &workspace.PatchWorkspaceCommand{
-		Workspace: n,
-		Owner:     ns,
-		PatchType: pt,
-		Patch:     d,
+		Workspace:    n,
+		Owner:        ns,
+		PatchType:    pt,
+		Patch:        d,
+		FieldManager: fm,
+		Force:        f,
 	}, nil
 }
 
+// prefixFromUserAgent builds the default field manager's name from the User-Agent,
+// as the Kubernetes API Server does
+func prefixFromUserAgent(u string) string {
+	m := strings.Split(u, "/")[0]
+	if len(m) > 128 {
+		return m[:128]
+	}
+	return m
+}
+
+// supportedPatchTypes lists the patch strategies the Content-Type header can select
+var supportedPatchTypes = []types.PatchType{
+	types.MergePatchType,
+	types.StrategicMergePatchType,
+	types.JSONPatchType,
+	types.ApplyPatchType,
+}
+
+// parsePatchType returns the patch strategy selected by the request's Content-Type header.
+// Media type parameters, e.g. `charset`, are ignored.
+// If the header is missing or selects no supported strategy, an UnsupportedMediaType error is returned.
 func parsePatchType(r *http.Request) (types.PatchType, error) {
-	ct, ok := r.Header["Content-Type"]
-	if !ok || len(ct) != 1 {
-		return "", fmt.Errorf("Content-Type header is required")
+	ct := r.Header.Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		if i := slices.Index(supportedPatchTypes, types.PatchType(mt)); i >= 0 {
+			return supportedPatchTypes[i], nil
+		}
 	}
 
-	switch ct[0] {
-	case string(types.MergePatchType):
-		return types.MergePatchType, nil
-	case string(types.StrategicMergePatchType):
-		return types.StrategicMergePatchType, nil
-	case string(types.JSONPatchType):
-		return types.JSONPatchType, nil
-	case string(types.ApplyPatchType):
-		return types.ApplyPatchType, nil
-	default:
-		return "", fmt.Errorf("unsupported Content-Type: %s", ct)
+	tt := make([]string, 0, len(supportedPatchTypes))
+	for _, t := range supportedPatchTypes {
+		tt = append(tt, string(t))
 	}
+	return "", marshal.NewUnsupportedMediaTypeErrorFor(ct, tt...)
 }
@@ -3,6 +3,7 @@ package workspace_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +11,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
@@ -53,22 +57,34 @@ var _ = Describe("Patch tests", func() {
 			handler.ServeHTTP(response, request)
 		},
 		Entry("failure in marshal provider", workspace.MapPatchWorkspaceHttp, nopPatchHandler, errorMarshalProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("no Content-Type in request", workspace.MapPatchWorkspaceHttp, nopPatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
 			request.Body = io.NopCloser(bytes.NewReader([]byte{}))
 			request.Header.Del("Content-Type")
-			fake.EXPECT().WriteHeader(http.StatusBadRequest).Times(1)
+			expectStatus(fake, http.StatusUnsupportedMediaType)
 			return fake
 		}),
 		Entry("invalid Content-Type", workspace.MapPatchWorkspaceHttp, nopPatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
 			request.Header.Set("Content-Type", "invalid")
-			fake.EXPECT().WriteHeader(http.StatusBadRequest).Times(1)
+			expectStatus(fake, http.StatusUnsupportedMediaType)
 			return fake
 		}),
 		Entry("failure in patch handler", workspace.MapPatchWorkspaceHttp, badPatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("conflict in patch handler", workspace.MapPatchWorkspaceHttp, conflictPatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().WriteHeader(http.StatusConflict)
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(d []byte) (int, error) {
+				s := metav1.Status{}
+				Expect(json.Unmarshal(d, &s)).To(Succeed())
+				Expect(s.Kind).To(Equal("Status"))
+				Expect(s.Reason).To(Equal(metav1.StatusReasonConflict))
+				return len(d), nil
+			})
 			return fake
 		}),
 		Entry("failure marshaling response", workspace.MapPatchWorkspaceHttp, nopPatchHandler, badMarshalProvider, func() http.ResponseWriter {
@@ -81,6 +97,12 @@ var _ = Describe("Patch tests", func() {
 			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
 			return fake
 		}),
+		Entry("workspace created by apply patch", workspace.MapPatchWorkspaceHttp, createPatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().WriteHeader(http.StatusCreated)
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(d []byte) (int, error) { return len(d), nil })
+			return fake
+		}),
 		Entry("failure to write response", workspace.MapPatchWorkspaceHttp, nopPatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
 			fake.EXPECT().Header().Return(http.Header{})
 			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(a any) (int, error) {
@@ -91,12 +113,55 @@ var _ = Describe("Patch tests", func() {
 			return fake
 		}),
 	)
+
+	DescribeTable("selects the patch type by the Content-Type header",
+		func(contentType string, expected types.PatchType) {
+			// given
+			request.Header.Set("Content-Type", contentType)
+
+			// when
+			c, err := workspace.MapPatchWorkspaceHttp(request)
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.PatchType).To(Equal(expected))
+		},
+		Entry("merge patch", "application/merge-patch+json", types.MergePatchType),
+		Entry("merge patch with charset", "application/merge-patch+json; charset=utf-8", types.MergePatchType),
+		Entry("strategic merge patch", "application/strategic-merge-patch+json", types.StrategicMergePatchType),
+		Entry("json patch with uppercase media type", "Application/JSON-Patch+JSON", types.JSONPatchType),
+		Entry("apply patch", "application/apply-patch+yaml", types.ApplyPatchType),
+	)
+
+	DescribeTable("returns UnsupportedMediaType for unsupported Content-Types",
+		func(contentType string) {
+			// given
+			request.Header.Set("Content-Type", contentType)
+
+			// when
+			_, err := workspace.MapPatchWorkspaceHttp(request)
+
+			// then
+			Expect(kerrors.IsUnsupportedMediaType(err)).To(BeTrue())
+		},
+		Entry("empty", ""),
+		Entry("json", "application/json"),
+		Entry("malformed", "application/merge-patch+json; charset"),
+	)
 })
 
+func conflictPatchHandler(ctx context.Context, cmd coreworkspace.PatchWorkspaceCommand) (*coreworkspace.PatchWorkspaceResponse, error) {
+	return nil, kerrors.NewConflict(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), "foo", fmt.Errorf("conflict"))
+}
+
 func badPatchHandler(ctx context.Context, cmd coreworkspace.PatchWorkspaceCommand) (*coreworkspace.PatchWorkspaceResponse, error) {
 	return nil, fmt.Errorf("bad patch handler")
 }
 
+func createPatchHandler(_ context.Context, cmd coreworkspace.PatchWorkspaceCommand) (*coreworkspace.PatchWorkspaceResponse, error) {
+	return &coreworkspace.PatchWorkspaceResponse{Workspace: &restworkspacesv1alpha1.Workspace{}, Created: true}, nil
+}
+
 func nopPatchHandler(_ctx context.Context, cmd coreworkspace.PatchWorkspaceCommand) (*coreworkspace.PatchWorkspaceResponse, error) {
 	return &coreworkspace.PatchWorkspaceResponse{}, nil
 }
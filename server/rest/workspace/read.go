@@ -2,10 +2,8 @@ package workspace
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
-	"github.com/konflux-workspaces/workspaces/server/core"
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
 	"github.com/konflux-workspaces/workspaces/server/log"
 	"github.com/konflux-workspaces/workspaces/server/rest/header"
@@ -37,7 +35,7 @@ func NewDefaultReadWorkspaceHandler(
 	return NewReadWorkspaceHandler(
 		MapReadWorkspaceHttp,
 		handler,
-		marshal.DefaultMarshalerProvider,
+		TableMarshalerProvider,
 	)
 }
 
@@ -62,8 +60,8 @@ func (h *ReadWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("building marshaler for request")
 	m, err := h.MarshalerProvider(r)
 	if err != nil {
-		l.Error("error building marshaler for request", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
 		return
 	}
 
@@ -71,8 +69,8 @@ func (h *ReadWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("mapping request to read query")
 	q, err := h.MapperFunc(r)
 	if err != nil {
-		l.Error("error mapping request to read query", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		l.Debug("error mapping request to read query", "error", err)
+		replyError(l, w, m, badRequest(err))
 		return
 	}
 
@@ -80,13 +78,7 @@ func (h *ReadWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("executing read query", "query", q)
 	qr, err := h.QueryHandler(r.Context(), *q)
 	if err != nil {
-		l.Error("error executing read query", "error", err)
-		switch {
-		case errors.Is(err, core.ErrNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -94,8 +86,8 @@ func (h *ReadWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	l.Debug("marshaling response", "query", qr)
 	d, err := m.Marshal(qr.Workspace)
 	if err != nil {
-		l.Error("error handling command", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		l.Error("error marshaling response", "error", err)
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -51,11 +51,11 @@ var _ = Describe("Read", func() {
 			handler.ServeHTTP(response, request)
 		},
 		Entry("failure in marshal provider", workspace.MapReadWorkspaceHttp, nopReadHandler, errorMarshalProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("failure in read handler", workspace.MapReadWorkspaceHttp, badReadHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			expectStatus(fake, http.StatusInternalServerError)
 			return fake
 		}),
 		Entry("failure marshaling response", workspace.MapReadWorkspaceHttp, nopReadHandler, badMarshalProvider, func() http.ResponseWriter {
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/status"
+)
+
+// replyError replies with the Kubernetes Status describing the error
+func replyError(l *slog.Logger, w http.ResponseWriter, m marshal.Marshaler, err error) {
+	s := status.FromError(err)
+	if s.Code >= http.StatusInternalServerError {
+		l.Error("error processing request", "error", err, "code", s.Code)
+	} else {
+		l.Debug("request rejected", "error", err, "code", s.Code, "reason", s.Reason)
+	}
+
+	if err := status.Write(w, m, err); err != nil {
+		l.Info("error writing response", "error", err)
+	}
+}
+
+// badRequest translates errors occurred mapping requests or negotiating their codecs into BadRequest errors,
+// unless they already carry a Kubernetes Status
+func badRequest(err error) error {
+	if s := kerrors.APIStatus(nil); errors.As(err, &s) {
+		return err
+	}
+	return kerrors.NewBadRequest(err.Error())
+}
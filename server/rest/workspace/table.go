@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	workspacesv1alpha1 "github.com/konflux-workspaces/workspaces/operator/api/v1alpha1"
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ marshal.TableConvertor = ConvertWorkspacesToTable
+
+// TableMarshalerProvider renders Workspaces as Tables for the requests accepting them, e.g. `kubectl get workspaces`
+var TableMarshalerProvider = marshal.NewTableMarshalerProvider(marshal.DefaultMarshalerProvider, ConvertWorkspacesToTable)
+
+// WatchMarshalerProvider is the TableMarshalerProvider for watch streams,
+// that are newline-delimited and so only support JSON
+var WatchMarshalerProvider = marshal.NewTableMarshalerProvider(marshal.NewMarshalerProvider(marshal.DefaultMarshal), ConvertWorkspacesToTable)
+
+// workspaceColumns are the columns of the Tables Workspaces are rendered as
+var workspaceColumns = []metav1.TableColumnDefinition{
+	{Name: "Name", Type: "string", Format: "name", Description: "Name of the Workspace"},
+	{Name: "Owner", Type: "string", Description: "Username of the Workspace's owner"},
+	{Name: "Visibility", Type: "string", Description: "Whether the Workspace is shared with the community or private"},
+	{Name: "Ready", Type: "string", Description: "Status of the Workspace's Ready condition"},
+	{Name: "Role", Type: "string", Description: "Role the requesting user is granted on the Workspace"},
+	{Name: "Age", Type: "date", Description: "Time since the Workspace was created"},
+	{Name: "Target Cluster", Type: "string", Priority: 1, Description: "URL of the cluster hosting the Workspace's namespaces"},
+}
+
+// ConvertWorkspacesToTable converts Workspaces and WorkspaceLists into a Table.
+// The object of each row is set according to the request's `includeObject` query parameter:
+// `None`, `Object`, or `Metadata`, the default one.
+func ConvertWorkspacesToTable(r *http.Request, v any) (*metav1.Table, bool, error) {
+	var t metav1.Table
+	var ww []restworkspacesv1alpha1.Workspace
+	switch o := v.(type) {
+	case *restworkspacesv1alpha1.Workspace:
+		t.ResourceVersion = o.ResourceVersion
+		ww = []restworkspacesv1alpha1.Workspace{*o}
+	case *restworkspacesv1alpha1.WorkspaceList:
+		o.ListMeta.DeepCopyInto(&t.ListMeta)
+		ww = o.Items
+	case restworkspacesv1alpha1.WorkspaceList:
+		o.ListMeta.DeepCopyInto(&t.ListMeta)
+		ww = o.Items
+	default:
+		return nil, false, nil
+	}
+
+	io := metav1.IncludeMetadata
+	if q := r.URL.Query().Get("includeObject"); q != "" {
+		io = metav1.IncludeObjectPolicy(q)
+	}
+	switch io {
+	case metav1.IncludeNone, metav1.IncludeObject, metav1.IncludeMetadata:
+	default:
+		return nil, false, kerrors.NewBadRequest(fmt.Sprintf("invalid includeObject value %q", io))
+	}
+
+	user, _ := r.Context().Value(ccontext.UserSignupComplaintNameKey).(string)
+	t.ColumnDefinitions = workspaceColumns
+	t.Rows = make([]metav1.TableRow, 0, len(ww))
+	for i := range ww {
+		t.Rows = append(t.Rows, workspaceTableRow(&ww[i], user, io))
+	}
+	return &t, true, nil
+}
+
+// workspaceTableRow renders a Workspace as a row of a Table
+func workspaceTableRow(w *restworkspacesv1alpha1.Workspace, user string, io metav1.IncludeObjectPolicy) metav1.TableRow {
+	ready := string(metav1.ConditionUnknown)
+	if c := meta.FindStatusCondition(w.Status.Conditions, workspacesv1alpha1.ConditionTypeReady); c != nil {
+		ready = string(c.Status)
+	}
+	age := "<unknown>"
+	if !w.CreationTimestamp.IsZero() {
+		age = duration.HumanDuration(time.Since(w.CreationTimestamp.Time))
+	}
+	targetCluster := ""
+	if w.Status.Space != nil {
+		targetCluster = w.Status.Space.TargetCluster
+	}
+
+	rw := metav1.TableRow{
+		Cells: []any{
+			w.Name,
+			w.Namespace,
+			string(w.Spec.Visibility),
+			ready,
+			string(workspaceRole(w, user)),
+			age,
+			targetCluster,
+		},
+	}
+
+	switch io {
+	case metav1.IncludeObject:
+		o := w.DeepCopy()
+		o.TypeMeta = metav1.TypeMeta{Kind: "Workspace", APIVersion: restworkspacesv1alpha1.GroupVersion.String()}
+		rw.Object = runtime.RawExtension{Object: o}
+	case metav1.IncludeMetadata:
+		m := metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{Kind: "PartialObjectMetadata", APIVersion: "meta.k8s.io/v1"},
+		}
+		w.ObjectMeta.DeepCopyInto(&m.ObjectMeta)
+		rw.Object = runtime.RawExtension{Object: &m}
+	}
+	return rw
+}
+
+// workspaceRole returns the role the user is granted on the Workspace.
+// Owners are granted the admin role, and users accessing a community Workspace
+// without a direct grant have no role.
+func workspaceRole(w *restworkspacesv1alpha1.Workspace, user string) restworkspacesv1alpha1.WorkspaceRole {
+	if w.Labels[restworkspacesv1alpha1.LabelIsOwner] == "true" {
+		return restworkspacesv1alpha1.WorkspaceRoleAdmin
+	}
+	for _, m := range w.Status.Members {
+		if m.Username == user {
+			return m.Role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package workspace_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ccontext "github.com/konflux-workspaces/workspaces/server/core/context"
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Table", func() {
+	const tableAccept = "application/json;as=Table;v=v1;g=meta.k8s.io,application/json;as=Table;v=v1beta1;g=meta.k8s.io,application/json"
+
+	var (
+		owned  restworkspacesv1alpha1.Workspace
+		shared restworkspacesv1alpha1.Workspace
+		list   workspace.ListWorkspaceQueryHandlerFunc
+		r      *http.Request
+	)
+
+	BeforeEach(func() {
+		owned = restworkspacesv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "owned",
+				Namespace:         "user",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-72 * time.Hour)),
+				Labels:            map[string]string{restworkspacesv1alpha1.LabelIsOwner: "true"},
+			},
+			Spec: restworkspacesv1alpha1.WorkspaceSpec{Visibility: restworkspacesv1alpha1.WorkspaceVisibilityPrivate},
+			Status: restworkspacesv1alpha1.WorkspaceStatus{
+				Space: &restworkspacesv1alpha1.SpaceInfo{Name: "owned", TargetCluster: "https://cluster"},
+				Conditions: []metav1.Condition{
+					{Type: "Ready", Status: metav1.ConditionTrue},
+				},
+			},
+		}
+		shared = restworkspacesv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shared",
+				Namespace: "other",
+				Labels:    map[string]string{restworkspacesv1alpha1.LabelIsOwner: "false"},
+			},
+			Spec: restworkspacesv1alpha1.WorkspaceSpec{Visibility: restworkspacesv1alpha1.WorkspaceVisibilityCommunity},
+			Status: restworkspacesv1alpha1.WorkspaceStatus{
+				Members: []restworkspacesv1alpha1.WorkspaceMember{
+					{Username: "user", Role: restworkspacesv1alpha1.WorkspaceRoleContributor},
+				},
+			},
+		}
+		list = func(context.Context, coreworkspace.ListWorkspaceQuery) (*coreworkspace.ListWorkspaceResponse, error) {
+			return &coreworkspace.ListWorkspaceResponse{
+				Workspaces: restworkspacesv1alpha1.WorkspaceList{
+					ListMeta: metav1.ListMeta{ResourceVersion: "42", Continue: "token"},
+					Items:    []restworkspacesv1alpha1.Workspace{owned, shared},
+				},
+			}, nil
+		}
+
+		ctx := context.WithValue(context.TODO(), ccontext.UserSignupComplaintNameKey, "user")
+		r = httptest.NewRequest(http.MethodGet, "/apis/workspaces.konflux-ci.dev/v1alpha1/workspaces", nil).WithContext(ctx)
+	})
+
+	serveList := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		workspace.NewDefaultListWorkspaceHandler(list).ServeHTTP(w, r)
+		return w
+	}
+
+	It("renders the list as a Table if requested", func() {
+		// given
+		r.Header.Set("Accept", tableAccept)
+
+		// when
+		w := serveList()
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusOK))
+		t := metav1.Table{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &t)).To(Succeed())
+		Expect(t.Kind).To(Equal("Table"))
+		Expect(t.APIVersion).To(Equal("meta.k8s.io/v1"))
+		Expect(t.ResourceVersion).To(Equal("42"))
+		Expect(t.Continue).To(Equal("token"))
+
+		cc := []string{}
+		for _, c := range t.ColumnDefinitions {
+			cc = append(cc, c.Name)
+		}
+		Expect(cc).To(Equal([]string{"Name", "Owner", "Visibility", "Ready", "Role", "Age", "Target Cluster"}))
+		Expect(t.Rows).To(HaveLen(2))
+		Expect(t.Rows[0].Cells).To(Equal([]any{"owned", "user", "private", "True", "admin", "3d", "https://cluster"}))
+		Expect(t.Rows[1].Cells).To(Equal([]any{"shared", "other", "community", "Unknown", "contributor", "<unknown>", ""}))
+	})
+
+	It("includes the rows' metadata by default", func() {
+		// given
+		r.Header.Set("Accept", tableAccept)
+
+		// when
+		w := serveList()
+
+		// then
+		t := metav1.Table{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &t)).To(Succeed())
+		m := metav1.PartialObjectMetadata{}
+		Expect(json.Unmarshal(t.Rows[0].Object.Raw, &m)).To(Succeed())
+		Expect(m.Kind).To(Equal("PartialObjectMetadata"))
+		Expect(m.Name).To(Equal("owned"))
+		Expect(m.Labels).To(HaveKeyWithValue(restworkspacesv1alpha1.LabelIsOwner, "true"))
+	})
+
+	It("omits the rows' objects if requested", func() {
+		// given
+		r.Header.Set("Accept", tableAccept)
+		r.URL.RawQuery = "includeObject=None"
+
+		// when
+		w := serveList()
+
+		// then
+		t := metav1.Table{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &t)).To(Succeed())
+		Expect(t.Rows[0].Object.Raw).To(BeNil())
+	})
+
+	It("rejects invalid includeObject values", func() {
+		// given
+		r.Header.Set("Accept", tableAccept)
+		r.URL.RawQuery = "includeObject=Invalid"
+
+		// when
+		w := serveList()
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("renders the list as it is if a Table is not requested", func() {
+		// given
+		r.Header.Set("Accept", "application/json")
+
+		// when
+		w := serveList()
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusOK))
+		ww := restworkspacesv1alpha1.WorkspaceList{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &ww)).To(Succeed())
+		Expect(ww.Items).To(HaveLen(2))
+	})
+
+	It("renders a single workspace as a Table with one row", func() {
+		// given
+		r.Header.Set("Accept", tableAccept)
+		read := func(context.Context, coreworkspace.ReadWorkspaceQuery) (*coreworkspace.ReadWorkspaceResponse, error) {
+			return &coreworkspace.ReadWorkspaceResponse{Workspace: &shared}, nil
+		}
+
+		// when
+		w := httptest.NewRecorder()
+		workspace.NewDefaultReadWorkspaceHandler(read).ServeHTTP(w, r)
+
+		// then
+		Expect(w.Code).To(Equal(http.StatusOK))
+		t := metav1.Table{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &t)).To(Succeed())
+		Expect(t.Rows).To(HaveLen(1))
+		Expect(t.Rows[0].Cells[0]).To(Equal("shared"))
+	})
+})
@@ -0,0 +1,136 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+)
+
+var (
+	_ http.Handler = &PostWorkspaceTransferHandler{}
+
+	_ PostWorkspaceTransferMapperFunc = MapPostWorkspaceTransferHttp
+)
+
+// handler dependencies
+type PostWorkspaceTransferMapperFunc func(*http.Request, marshal.UnmarshalerProvider) (*workspace.TransferWorkspaceCommand, error)
+type TransferWorkspaceCommandHandlerFunc func(context.Context, workspace.TransferWorkspaceCommand) (*workspace.TransferWorkspaceResponse, error)
+
+// PostWorkspaceTransferHandler the http.Request handler for Transfer Workspace endpoint
+type PostWorkspaceTransferHandler struct {
+	MapperFunc     PostWorkspaceTransferMapperFunc
+	CommandHandler TransferWorkspaceCommandHandlerFunc
+
+	MarshalerProvider   marshal.MarshalerProvider
+	UnmarshalerProvider marshal.UnmarshalerProvider
+}
+
+// NewDefaultPostWorkspaceTransferHandler creates a PostWorkspaceTransferHandler
+func NewDefaultPostWorkspaceTransferHandler(
+	handler TransferWorkspaceCommandHandlerFunc,
+) *PostWorkspaceTransferHandler {
+	return NewPostWorkspaceTransferHandler(
+		MapPostWorkspaceTransferHttp,
+		handler,
+		marshal.DefaultMarshalerProvider,
+		marshal.DefaultUnmarshalerProvider,
+	)
+}
+
+// NewPostWorkspaceTransferHandler creates a PostWorkspaceTransferHandler
+func NewPostWorkspaceTransferHandler(
+	mapperFunc PostWorkspaceTransferMapperFunc,
+	commandHandler TransferWorkspaceCommandHandlerFunc,
+	marshalerProvider marshal.MarshalerProvider,
+	unmarshalerProvider marshal.UnmarshalerProvider,
+) *PostWorkspaceTransferHandler {
+	return &PostWorkspaceTransferHandler{
+		MapperFunc:          mapperFunc,
+		CommandHandler:      commandHandler,
+		MarshalerProvider:   marshalerProvider,
+		UnmarshalerProvider: unmarshalerProvider,
+	}
+}
+
+func (h *PostWorkspaceTransferHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := log.FromContext(r.Context())
+	l.Debug("executing transfer")
+
+	// build marshaler for the given request
+	l.Debug("building marshaler for request")
+	m, err := h.MarshalerProvider(r)
+	if err != nil {
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
+		return
+	}
+
+	// map
+	l.Debug("mapping request to transfer command")
+	c, err := h.MapperFunc(r, h.UnmarshalerProvider)
+	if err != nil {
+		l.Debug("error mapping request to transfer command", "error", err)
+		replyError(l, w, m, badRequest(err))
+		return
+	}
+
+	// execute
+	l.Debug("executing transfer command", "command", c)
+	cr, err := h.CommandHandler(r.Context(), *c)
+	if err != nil {
+		replyError(l, w, m, err)
+		return
+	}
+
+	// marshal response
+	l.Debug("marshaling response", "response", &cr)
+	d, err := m.Marshal(cr.Workspace)
+	if err != nil {
+		l.Error("error marshaling response", "error", err)
+		replyError(l, w, m, err)
+		return
+	}
+
+	// reply
+	l.Debug("writing response", "response", d)
+	w.Header().Add(header.ContentType, m.ContentType())
+	if _, err := w.Write(d); err != nil {
+		l.Error("error writing response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func MapPostWorkspaceTransferHttp(r *http.Request, unmarshaler marshal.UnmarshalerProvider) (*workspace.TransferWorkspaceCommand, error) {
+	// build unmarshaler for the given request
+	u, err := unmarshaler(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse request body
+	d, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	// unmarshal body to WorkspaceTransfer
+	t := restworkspacesv1alpha1.WorkspaceTransfer{}
+	if err := u.Unmarshal(d, &t); err != nil {
+		return nil, fmt.Errorf("error unmarshaling request body: %w", err)
+	}
+
+	// build command
+	return &workspace.TransferWorkspaceCommand{
+		Name:     r.PathValue("name"),
+		Owner:    r.PathValue("namespace"),
+		Transfer: t,
+	}, nil
+}
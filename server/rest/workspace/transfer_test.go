@@ -0,0 +1,159 @@
+package workspace_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Transfer", func() {
+	var (
+		ctrl *gomock.Controller
+		fake *mocks.MockFakeResponseWriter
+	)
+
+	buildRequest := func(body string) *http.Request {
+		request, err := http.NewRequest(http.MethodPost, "/apis/workspaces.io/v1alpha1/namespaces/bar/workspaces/foo/transfer", bytes.NewBufferString(body))
+		Expect(err).NotTo(HaveOccurred())
+		request.SetPathValue("namespace", "bar")
+		request.SetPathValue("name", "foo")
+		return request
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		fake = mocks.NewMockFakeResponseWriter(ctrl)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	DescribeTable("workspace transfer POST handler",
+		func(
+			mapperFunc workspace.PostWorkspaceTransferMapperFunc,
+			commandHandler workspace.TransferWorkspaceCommandHandlerFunc,
+			marshaler marshal.MarshalerProvider,
+			unmarshaler marshal.UnmarshalerProvider,
+			responseFunc func() http.ResponseWriter,
+		) {
+			response := responseFunc()
+			handler := workspace.NewPostWorkspaceTransferHandler(mapperFunc, commandHandler, marshaler, unmarshaler)
+			handler.ServeHTTP(response, buildRequest(`{"newOwner":"baz"}`))
+		},
+		Entry("failure in marshal provider", workspace.MapPostWorkspaceTransferHttp, nopTransferHandler, errorMarshalProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in unmarshal provider", workspace.MapPostWorkspaceTransferHttp, nopTransferHandler, marshal.DefaultMarshalerProvider, errorUnmarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure unmarshaling request", workspace.MapPostWorkspaceTransferHttp, nopTransferHandler, marshal.DefaultMarshalerProvider, badUnmarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in transfer handler", workspace.MapPostWorkspaceTransferHttp, badTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("transfer not found", workspace.MapPostWorkspaceTransferHttp, notFoundTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusNotFound)
+			return fake
+		}),
+		Entry("transfer forbidden", workspace.MapPostWorkspaceTransferHttp, forbiddenTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusForbidden)
+			return fake
+		}),
+		Entry("transfer conflict", workspace.MapPostWorkspaceTransferHttp, conflictTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusConflict)
+			return fake
+		}),
+		Entry("transfer invalid", workspace.MapPostWorkspaceTransferHttp, invalidTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusUnprocessableEntity)
+			return fake
+		}),
+		Entry("failure marshaling response", workspace.MapPostWorkspaceTransferHttp, nopTransferHandler, badMarshalProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("failure to write response", workspace.MapPostWorkspaceTransferHttp, nopTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("failed to write response body"))
+			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("workspace transferred", workspace.MapPostWorkspaceTransferHttp, nopTransferHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(a any) (int, error) {
+				slice, ok := a.([]byte)
+				Expect(ok).To(BeTrue())
+				Expect(string(slice)).To(ContainSubstring(`"namespace":"baz"`))
+				return len(slice), nil
+			})
+			return fake
+		}),
+	)
+
+	It("maps the request to a transfer command", func() {
+		// when
+		c, err := workspace.MapPostWorkspaceTransferHttp(buildRequest(`{"newOwner":"baz","previousOwnerRole":"viewer"}`), marshal.DefaultUnmarshalerProvider)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).To(Equal(&coreworkspace.TransferWorkspaceCommand{
+			Name:  "foo",
+			Owner: "bar",
+			Transfer: restworkspacesv1alpha1.WorkspaceTransfer{
+				NewOwner:          "baz",
+				PreviousOwnerRole: restworkspacesv1alpha1.WorkspaceRoleViewer,
+			},
+		}))
+	})
+})
+
+func badTransferHandler(ctx context.Context, cmd coreworkspace.TransferWorkspaceCommand) (*coreworkspace.TransferWorkspaceResponse, error) {
+	return nil, fmt.Errorf("bad transfer handler")
+}
+
+func notFoundTransferHandler(ctx context.Context, cmd coreworkspace.TransferWorkspaceCommand) (*coreworkspace.TransferWorkspaceResponse, error) {
+	return nil, kerrors.NewNotFound(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name)
+}
+
+func forbiddenTransferHandler(ctx context.Context, cmd coreworkspace.TransferWorkspaceCommand) (*coreworkspace.TransferWorkspaceResponse, error) {
+	return nil, kerrors.NewForbidden(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name, fmt.Errorf("forbidden"))
+}
+
+func conflictTransferHandler(ctx context.Context, cmd coreworkspace.TransferWorkspaceCommand) (*coreworkspace.TransferWorkspaceResponse, error) {
+	return nil, kerrors.NewAlreadyExists(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), cmd.Name)
+}
+
+func invalidTransferHandler(ctx context.Context, cmd coreworkspace.TransferWorkspaceCommand) (*coreworkspace.TransferWorkspaceResponse, error) {
+	return nil, kerrors.NewInvalid(
+		restworkspacesv1alpha1.GroupVersion.WithKind("WorkspaceTransfer").GroupKind(),
+		cmd.Name,
+		field.ErrorList{field.NotFound(field.NewPath("newOwner"), cmd.Transfer.NewOwner)})
+}
+
+func nopTransferHandler(ctx context.Context, cmd coreworkspace.TransferWorkspaceCommand) (*coreworkspace.TransferWorkspaceResponse, error) {
+	return &coreworkspace.TransferWorkspaceResponse{
+		Workspace: &restworkspacesv1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: cmd.Name, Namespace: cmd.Transfer.NewOwner},
+		},
+	}, nil
+}
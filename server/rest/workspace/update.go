@@ -2,14 +2,10 @@ package workspace
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
-
-	"github.com/konflux-workspaces/workspaces/server/core"
 	"github.com/konflux-workspaces/workspaces/server/core/workspace"
 	"github.com/konflux-workspaces/workspaces/server/log"
 	"github.com/konflux-workspaces/workspaces/server/rest/header"
@@ -73,7 +69,7 @@ func (h *UpdateWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	m, err := h.MarshalerProvider(r)
 	if err != nil {
 		l.Debug("error building marshaler for request", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		replyError(l, w, nil, badRequest(err))
 		return
 	}
 
@@ -82,7 +78,7 @@ func (h *UpdateWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	c, err := h.MapperFunc(r, h.UnmarshalerProvider)
 	if err != nil {
 		l.Debug("error mapping request to command", "error", err)
-		w.WriteHeader(http.StatusBadRequest)
+		replyError(l, w, m, badRequest(err))
 		return
 	}
 
@@ -90,23 +86,7 @@ func (h *UpdateWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	l.Debug("executing update command", "command", c)
 	cr, err := h.CommandHandler(r.Context(), *c)
 	if err != nil {
-		l = l.With("error", err)
-
-		switch {
-		case errors.Is(err, core.ErrNotFound):
-			l.Debug("error executing update command: resource not found")
-			w.WriteHeader(http.StatusNotFound)
-		case kerrors.IsForbidden(err):
-			serr := new(kerrors.StatusError)
-			errors.As(err, &serr)
-			w.WriteHeader(int(serr.Status().Code))
-			if _, err := w.Write([]byte(serr.Error())); err != nil {
-				l.Info("error writing response", "error", err)
-			}
-		default:
-			l.Error("error executing update command")
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		replyError(l, w, m, err)
 		return
 	}
 
@@ -115,7 +95,7 @@ func (h *UpdateWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	d, err := m.Marshal(cr.Workspace)
 	if err != nil {
 		l.Error("unexpected error marshaling response", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
+		replyError(l, w, m, err)
 		return
 	}
 
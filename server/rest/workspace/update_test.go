@@ -3,6 +3,7 @@ package workspace_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +12,9 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
 
 	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
@@ -53,24 +57,36 @@ var _ = Describe("Update tests", func() {
 			handler.ServeHTTP(response, request)
 		},
 		Entry("failure in marshal provider", workspace.MapPutWorkspaceHttp, nopUpdateHandler, errorMarshalProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("failure in unmarshal provider", workspace.MapPutWorkspaceHttp, nopUpdateHandler, marshal.DefaultMarshalerProvider, errorUnmarshalProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("no body sent in request", workspace.MapPutWorkspaceHttp, nopUpdateHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
 			request.Body = io.NopCloser(bytes.NewReader([]byte{}))
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("failure unmarshaling request", workspace.MapPutWorkspaceHttp, nopUpdateHandler, marshal.DefaultMarshalerProvider, badUnmarshalProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusBadRequest)
+			expectStatus(fake, http.StatusBadRequest)
 			return fake
 		}),
 		Entry("failure in update handler", workspace.MapPutWorkspaceHttp, badUpdateHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
-			fake.EXPECT().WriteHeader(http.StatusInternalServerError)
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("conflict in update handler", workspace.MapPutWorkspaceHttp, conflictUpdateHandler, marshal.DefaultMarshalerProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().WriteHeader(http.StatusConflict)
+			fake.EXPECT().Write(gomock.Any()).DoAndReturn(func(d []byte) (int, error) {
+				s := metav1.Status{}
+				Expect(json.Unmarshal(d, &s)).To(Succeed())
+				Expect(s.Kind).To(Equal("Status"))
+				Expect(s.Reason).To(Equal(metav1.StatusReasonConflict))
+				return len(d), nil
+			})
 			return fake
 		}),
 		Entry("failure marshaling response", workspace.MapPutWorkspaceHttp, nopUpdateHandler, badMarshalProvider, marshal.DefaultUnmarshalerProvider, func() http.ResponseWriter {
@@ -95,6 +111,10 @@ var _ = Describe("Update tests", func() {
 	)
 })
 
+func conflictUpdateHandler(ctx context.Context, cmd coreworkspace.UpdateWorkspaceCommand) (*coreworkspace.UpdateWorkspaceResponse, error) {
+	return nil, kerrors.NewConflict(restworkspacesv1alpha1.GroupVersion.WithResource("workspaces").GroupResource(), "foo", fmt.Errorf("conflict"))
+}
+
 func badUpdateHandler(ctx context.Context, cmd coreworkspace.UpdateWorkspaceCommand) (*coreworkspace.UpdateWorkspaceResponse, error) {
 	return nil, fmt.Errorf("bad update handler")
 }
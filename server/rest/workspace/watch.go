@@ -0,0 +1,168 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/log"
+	"github.com/konflux-workspaces/workspaces/server/rest/header"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+)
+
+var (
+	_ http.Handler = &WatchWorkspaceHandler{}
+
+	_ WatchWorkspaceMapperFunc = MapWatchWorkspaceHttp
+)
+
+// handler dependencies
+type WatchWorkspaceMapperFunc func(r *http.Request) (*workspace.WatchWorkspaceQuery, error)
+type WatchWorkspaceQueryHandlerFunc func(context.Context, workspace.WatchWorkspaceQuery) (*workspace.WatchWorkspaceResponse, error)
+
+// WatchWorkspaceHandler the http.Request handler for Watch Workspaces endpoints
+type WatchWorkspaceHandler struct {
+	MapperFunc   WatchWorkspaceMapperFunc
+	QueryHandler WatchWorkspaceQueryHandlerFunc
+
+	MarshalerProvider marshal.MarshalerProvider
+}
+
+// NewDefaultWatchWorkspaceHandler creates a WatchWorkspaceHandler
+func NewDefaultWatchWorkspaceHandler(
+	handler WatchWorkspaceQueryHandlerFunc,
+) *WatchWorkspaceHandler {
+	return NewWatchWorkspaceHandler(
+		MapWatchWorkspaceHttp,
+		handler,
+		WatchMarshalerProvider,
+	)
+}
+
+// NewWatchWorkspaceHandler creates a WatchWorkspaceHandler
+func NewWatchWorkspaceHandler(
+	mapperFunc WatchWorkspaceMapperFunc,
+	queryHandler WatchWorkspaceQueryHandlerFunc,
+	marshalerProvider marshal.MarshalerProvider,
+) *WatchWorkspaceHandler {
+	return &WatchWorkspaceHandler{
+		MapperFunc:        mapperFunc,
+		QueryHandler:      queryHandler,
+		MarshalerProvider: marshalerProvider,
+	}
+}
+
+func (h *WatchWorkspaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l := log.FromContext(r.Context())
+	l.Debug("executing watch")
+
+	// build marshaler for the given request
+	l.Debug("building marshaler for request")
+	m, err := h.MarshalerProvider(r)
+	if err != nil {
+		l.Debug("error building marshaler for request", "error", err)
+		replyError(l, w, nil, badRequest(err))
+		return
+	}
+
+	// map
+	l.Debug("mapping request to watch query")
+	q, err := h.MapperFunc(r)
+	if err != nil {
+		l.Debug("error mapping request to watch query", "error", err)
+		replyError(l, w, m, badRequest(err))
+		return
+	}
+
+	// execute
+	l.Debug("executing watch query", "query", q)
+	qr, err := h.QueryHandler(r.Context(), *q)
+	if err != nil {
+		replyError(l, w, m, err)
+		return
+	}
+	defer qr.Watch.Stop()
+
+	// stream events
+	l.Debug("streaming events")
+	w.Header().Add(header.ContentType, m.ContentType())
+	w.WriteHeader(http.StatusOK)
+	f, _ := w.(http.Flusher)
+	if f != nil {
+		f.Flush()
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			l.Debug("request closed, stopping watch")
+			return
+
+		case e, ok := <-qr.Watch.ResultChan():
+			if !ok {
+				l.Debug("watch closed")
+				return
+			}
+
+			d, err := marshalWatchEvent(m, e.Type, e.Object)
+			if err != nil {
+				l.Error("error marshaling watch event", "error", err)
+				return
+			}
+			if _, err := w.Write(d); err != nil {
+				l.Info("error writing watch event", "error", err)
+				return
+			}
+			if f != nil {
+				f.Flush()
+			}
+		}
+	}
+}
+
+// marshalWatchEvent marshals an event as a newline terminated metav1.WatchEvent
+func marshalWatchEvent(m marshal.Marshaler, t watch.EventType, o runtime.Object) ([]byte, error) {
+	od, err := m.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := m.Marshal(metav1.WatchEvent{
+		Type:   string(t),
+		Object: runtime.RawExtension{Raw: od},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(d, '\n'), nil
+}
+
+func MapWatchWorkspaceHttp(r *http.Request) (*workspace.WatchWorkspaceQuery, error) {
+	q := r.URL.Query()
+	b := false
+	if v := q.Get("allowWatchBookmarks"); v != "" {
+		var err error
+		if b, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid allowWatchBookmarks value %q: %w", v, err)
+		}
+	}
+
+	ls, fs, err := mapSelectors(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &workspace.WatchWorkspaceQuery{
+		Namespace:           r.PathValue("namespace"),
+		Name:                r.PathValue("name"),
+		LabelSelector:       ls,
+		FieldSelector:       fs,
+		ResourceVersion:     q.Get("resourceVersion"),
+		AllowWatchBookmarks: b,
+	}, nil
+}
@@ -0,0 +1,188 @@
+package workspace_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace/mocks"
+
+	coreworkspace "github.com/konflux-workspaces/workspaces/server/core/workspace"
+	"github.com/konflux-workspaces/workspaces/server/rest/marshal"
+	"github.com/konflux-workspaces/workspaces/server/rest/workspace"
+
+	restworkspacesv1alpha1 "github.com/konflux-workspaces/workspaces/server/api/v1alpha1"
+)
+
+var _ = Describe("Watch", func() {
+	var (
+		ctrl    *gomock.Controller
+		w       *restworkspacesv1alpha1.Workspace
+		request *http.Request
+		fake    *mocks.MockFakeResponseWriter
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		w = &restworkspacesv1alpha1.Workspace{}
+		w.Name = "foo"
+		w.Namespace = "bar"
+
+		request = buildWatchRequest(w.Namespace, "watch=true")
+		fake = mocks.NewMockFakeResponseWriter(ctrl)
+	})
+
+	AfterEach(func() { ctrl.Finish() })
+
+	DescribeTable("workspace GET handler: watch",
+		func(
+			mapperFunc workspace.WatchWorkspaceMapperFunc,
+			watchHandler workspace.WatchWorkspaceQueryHandlerFunc,
+			marshaler marshal.MarshalerProvider,
+			responseFunc func() http.ResponseWriter,
+		) {
+			response := responseFunc()
+			handler := workspace.NewWatchWorkspaceHandler(mapperFunc, watchHandler, marshaler)
+			handler.ServeHTTP(response, request)
+		},
+		Entry("failure in marshal provider", workspace.MapWatchWorkspaceHttp, nopWatchHandler, errorMarshalProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in mapper", badWatchMapper, nopWatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure in watch handler", workspace.MapWatchWorkspaceHttp, badWatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusInternalServerError)
+			return fake
+		}),
+		Entry("resource version too old", workspace.MapWatchWorkspaceHttp, expiredWatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusGone)
+			return fake
+		}),
+		Entry("invalid resource version", workspace.MapWatchWorkspaceHttp, badRequestWatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			expectStatus(fake, http.StatusBadRequest)
+			return fake
+		}),
+		Entry("failure marshaling event", workspace.MapWatchWorkspaceHttp, nopWatchHandler, badMarshalProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().WriteHeader(http.StatusOK)
+			return fake
+		}),
+		Entry("failure writing event", workspace.MapWatchWorkspaceHttp, nopWatchHandler, marshal.DefaultMarshalerProvider, func() http.ResponseWriter {
+			fake.EXPECT().Header().Return(http.Header{})
+			fake.EXPECT().WriteHeader(http.StatusOK)
+			fake.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("failed to write response body"))
+			return fake
+		}),
+	)
+
+	It("should stream the watch events", func() {
+		// given
+		fw := watch.NewFake()
+		handler := workspace.NewDefaultWatchWorkspaceHandler(
+			func(context.Context, coreworkspace.WatchWorkspaceQuery) (*coreworkspace.WatchWorkspaceResponse, error) {
+				return &coreworkspace.WatchWorkspaceResponse{Watch: fw}, nil
+			})
+		rec := httptest.NewRecorder()
+		go func() {
+			defer GinkgoRecover()
+			fw.Add(w.DeepCopy())
+			fw.Delete(w.DeepCopy())
+			fw.Stop()
+		}()
+
+		// when
+		handler.ServeHTTP(rec, request)
+
+		// then
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(Equal(marshal.ContentTypeJson))
+		tt := []string{}
+		s := bufio.NewScanner(rec.Body)
+		for s.Scan() {
+			e := metav1.WatchEvent{}
+			Expect(json.Unmarshal(s.Bytes(), &e)).To(Succeed())
+			o := restworkspacesv1alpha1.Workspace{}
+			Expect(json.Unmarshal(e.Object.Raw, &o)).To(Succeed())
+			Expect(o.Name).To(Equal(w.Name))
+			tt = append(tt, e.Type)
+		}
+		Expect(tt).To(Equal([]string{string(watch.Added), string(watch.Deleted)}))
+	})
+
+	DescribeTable("request mapping",
+		func(path, query string, expected *coreworkspace.WatchWorkspaceQuery) {
+			// given
+			r := buildWatchRequest(path, query)
+			r.SetPathValue("namespace", path)
+
+			// when
+			q, err := workspace.MapWatchWorkspaceHttp(r)
+
+			// then
+			if expected == nil {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(q).To(Equal(expected))
+		},
+		Entry("no options", "bar", "watch=true", &coreworkspace.WatchWorkspaceQuery{Namespace: "bar"}),
+		Entry("resource version and bookmarks", "bar", "watch=true&resourceVersion=42&allowWatchBookmarks=true",
+			&coreworkspace.WatchWorkspaceQuery{Namespace: "bar", ResourceVersion: "42", AllowWatchBookmarks: true}),
+		Entry("invalid bookmarks", "bar", "watch=true&allowWatchBookmarks=maybe", nil),
+		Entry("label selector", "bar", "watch=true&labelSelector=app%3Dfoo",
+			&coreworkspace.WatchWorkspaceQuery{Namespace: "bar", LabelSelector: labels.SelectorFromSet(labels.Set{"app": "foo"})}),
+		Entry("field selector", "bar", "watch=true&fieldSelector=spec.visibility%3Dcommunity",
+			&coreworkspace.WatchWorkspaceQuery{Namespace: "bar", FieldSelector: fields.OneTermEqualSelector("spec.visibility", "community")}),
+		Entry("invalid label selector", "bar", "watch=true&labelSelector=app%3D%3D%3Dfoo", nil),
+		Entry("invalid field selector", "bar", "watch=true&fieldSelector=spec.visibility", nil),
+	)
+})
+
+func badWatchMapper(*http.Request) (*coreworkspace.WatchWorkspaceQuery, error) {
+	return nil, fmt.Errorf("bad watch mapper")
+}
+
+func badWatchHandler(context.Context, coreworkspace.WatchWorkspaceQuery) (*coreworkspace.WatchWorkspaceResponse, error) {
+	return nil, fmt.Errorf("bad watch handler")
+}
+
+func expiredWatchHandler(context.Context, coreworkspace.WatchWorkspaceQuery) (*coreworkspace.WatchWorkspaceResponse, error) {
+	return nil, kerrors.NewResourceExpired("too old resource version")
+}
+
+func badRequestWatchHandler(context.Context, coreworkspace.WatchWorkspaceQuery) (*coreworkspace.WatchWorkspaceResponse, error) {
+	return nil, kerrors.NewBadRequest("invalid resource version")
+}
+
+// nopWatchHandler returns a watch notifying a single event
+func nopWatchHandler(context.Context, coreworkspace.WatchWorkspaceQuery) (*coreworkspace.WatchWorkspaceResponse, error) {
+	fw := watch.NewFakeWithChanSize(1, false)
+	fw.Add(&restworkspacesv1alpha1.Workspace{})
+	return &coreworkspace.WatchWorkspaceResponse{Watch: fw}, nil
+}
+
+func buildWatchRequest(namespace, query string) *http.Request {
+	url := fmt.Sprintf("/apis/workspaces.io/v1alpha1/namespaces/%s/workspaces?%s", namespace, query)
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	Expect(err).NotTo(HaveOccurred())
+	request.Header.Add("Accept", marshal.DefaultMarshal.ContentType())
+	return request
+}